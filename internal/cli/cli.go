@@ -0,0 +1,150 @@
+// Package cli implements the non-interactive ki subcommands
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Exit codes returned by Run
+const (
+	ExitOK    = 0
+	ExitError = 1
+	ExitUsage = 2
+)
+
+// env carries what a subcommand needs to run
+type env struct {
+	ctx    context.Context
+	stdout io.Writer
+	stderr io.Writer
+}
+
+// command is a single ki subcommand
+type command struct {
+	usage   string
+	summary string
+	run     func(e env, args []string) error
+}
+
+var commands map[string]command
+
+// The table is filled in init because the commands print their own usage
+func init() {
+	commands = map[string]command{
+		"list":       {"list [-o format]", "List KIND clusters", runList},
+		"status":     {"status NAME [-o format]", "Show the status and nodes of a cluster", runStatus},
+		"create":     {"create [--name NAME] [--template TEMPLATE] [--image IMAGE] [--control-planes N] [--workers N] [--registry]", "Create a cluster", runCreate},
+		"delete":     {"delete NAME", "Delete a cluster", runDelete},
+		"stop":       {"stop NAME", "Stop the node containers of a cluster", runStop},
+		"start":      {"start NAME", "Start a stopped cluster", runStart},
+		"restart":    {"restart NAME", "Restart the node containers of a cluster", runRestart},
+		"pods":       {"pods NAME [-n NAMESPACE] [-o format]", "List the pods of a cluster", runPods},
+		"registry":   {"registry create | attach NAME | detach NAME", "Manage the local registry shared by clusters", runRegistry},
+		"kubeconfig": {"kubeconfig export NAME [--internal] [FILE] | merge NAME | remove NAME | use NAME", "Export a cluster's kubeconfig or manage it in the default kubeconfig", runKubeconfig},
+		"load":       {"load IMAGE... | --archive FILE... [--name CLUSTER] [--nodes NODE,...]", "Load Docker images or image archives into a cluster", runLoad},
+		"build":      {"build CONTEXT -t TAG [-f DOCKERFILE] [--name CLUSTER] [--nodes NODE,...]", "Build an image and load it into a cluster", runBuild},
+		"logs":       {"logs [--name CLUSTER] [DIR]", "Export cluster logs", runLogs},
+		"templates":  {"templates [-o format]", "List cluster templates", runTemplates},
+	}
+}
+
+// usageError is returned for invalid invocations and maps to ExitUsage
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+func usagef(format string, args ...any) error {
+	return usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// Run executes the subcommand in args and returns the process exit code
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || isHelp(args[0]) {
+		printUsage(stdout)
+		return ExitOK
+	}
+
+	c, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "Error: unknown command %q\n\n", args[0])
+		printUsage(stderr)
+		return ExitUsage
+	}
+
+	err := c.run(env{ctx: ctx, stdout: stdout, stderr: stderr}, args[1:])
+	var usageErr usageError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, flag.ErrHelp):
+		return ExitOK
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(stderr, "Error: %v\nUsage: ki %s\n", err, c.usage)
+		return ExitUsage
+	default:
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+}
+
+func isHelp(arg string) bool {
+	return arg == "help" || arg == "-h" || arg == "--help"
+}
+
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Usage: ki [--provider docker|podman|nerdctl] [command]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run without a command to start the interactive UI.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Output formats (-o): table, json, yaml")
+}
+
+// newFlagSet creates a flag set for a subcommand that reports errors instead of exiting
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	// Run prints parse errors together with the usage line
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parseArgs parses flags anywhere in args and returns the positional arguments
+func parseArgs(e env, fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				fmt.Fprintf(e.stdout, "Usage: ki %s\n", commands[fs.Name()].usage)
+				fs.SetOutput(e.stdout)
+				fs.PrintDefaults()
+				return nil, err
+			}
+			return nil, usageError{msg: err.Error()}
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
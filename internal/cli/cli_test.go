@@ -0,0 +1,514 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+	"ki/internal/cmd"
+)
+
+// fakeCommands records calls to cmd.CommandInterface; methods it does not
+// override panic through the nil embedded interface
+type fakeCommands struct {
+	cmd.CommandInterface
+
+	clusters []cmd.Cluster
+	pods     []cmd.Pod
+	err      error
+
+	created cmd.ClusterConfig
+	deleted string
+	stopped string
+	started string
+	image   string
+	cluster string
+	nodes   string
+	logsDir string
+	podsIn  string
+
+	registry string // last registry action, e.g. "attach dev"
+	archives string
+	built    cmd.BuildOptions
+
+	kubeconfig string // last kubeconfig action, e.g. "merge dev"
+	detailed   []string
+}
+
+func (f *fakeCommands) GetClusters(ctx context.Context) ([]cmd.Cluster, error) {
+	return f.clusters, f.err
+}
+
+func (f *fakeCommands) GetClusterNames(ctx context.Context) ([]string, error) {
+	names := make([]string, len(f.clusters))
+	for i, c := range f.clusters {
+		names[i] = c.Name
+	}
+	return names, f.err
+}
+
+func (f *fakeCommands) GetClusterDetail(ctx context.Context, name string) (cmd.Cluster, error) {
+	f.detailed = append(f.detailed, name)
+	for _, c := range f.clusters {
+		if c.Name == name {
+			return c, f.err
+		}
+	}
+	return cmd.Cluster{Name: name}, f.err
+}
+
+func (f *fakeCommands) GetPods(ctx context.Context, cluster, namespace string) ([]cmd.Pod, error) {
+	f.cluster, f.podsIn = cluster, namespace
+	return f.pods, f.err
+}
+
+func (f *fakeCommands) CreateCluster(ctx context.Context, config cmd.ClusterConfig, out io.Writer) error {
+	f.created = config
+	fmt.Fprintln(out, " ✓ Preparing nodes 📦")
+	return f.err
+}
+
+func (f *fakeCommands) DeleteCluster(ctx context.Context, name string, out io.Writer) error {
+	f.deleted = name
+	return f.err
+}
+
+func (f *fakeCommands) StopCluster(ctx context.Context, name string, out io.Writer) error {
+	f.stopped = name
+	return f.err
+}
+
+func (f *fakeCommands) StartCluster(ctx context.Context, name string, out io.Writer) error {
+	f.started = name
+	return f.err
+}
+
+func (f *fakeCommands) LoadDockerImage(ctx context.Context, images []string, cluster string, nodes []string, out io.Writer) error {
+	f.image, f.cluster, f.nodes = strings.Join(images, " "), cluster, strings.Join(nodes, " ")
+	return f.err
+}
+
+func (f *fakeCommands) LoadImageArchive(ctx context.Context, archives []string, cluster string, nodes []string, out io.Writer) error {
+	f.archives, f.cluster, f.nodes = strings.Join(archives, " "), cluster, strings.Join(nodes, " ")
+	return f.err
+}
+
+func (f *fakeCommands) BuildAndLoadImage(ctx context.Context, opts cmd.BuildOptions, cluster string, nodes []string, out io.Writer) error {
+	f.built, f.cluster, f.nodes = opts, cluster, strings.Join(nodes, " ")
+	return f.err
+}
+
+func (f *fakeCommands) GetKubeconfig(ctx context.Context, cluster string, internal bool) (string, error) {
+	f.kubeconfig = fmt.Sprintf("get %s %v", cluster, internal)
+	return "apiVersion: v1\n", f.err
+}
+
+func (f *fakeCommands) ExportKubeconfig(ctx context.Context, cluster, path string, internal bool) error {
+	f.kubeconfig = fmt.Sprintf("export %s %s %v", cluster, path, internal)
+	return f.err
+}
+
+func (f *fakeCommands) MergeKubeconfig(ctx context.Context, cluster string) error {
+	f.kubeconfig = "merge " + cluster
+	return f.err
+}
+
+func (f *fakeCommands) RemoveKubeconfig(ctx context.Context, cluster string) error {
+	f.kubeconfig = "remove " + cluster
+	return f.err
+}
+
+func (f *fakeCommands) UseKubeContext(ctx context.Context, cluster string) error {
+	f.kubeconfig = "use " + cluster
+	return f.err
+}
+
+func (f *fakeCommands) ExportLogs(ctx context.Context, cluster, dir string, out io.Writer) error {
+	f.cluster, f.logsDir = cluster, dir
+	return f.err
+}
+
+func (f *fakeCommands) CreateRegistry(ctx context.Context, out io.Writer) error {
+	f.registry = "create"
+	return f.err
+}
+
+func (f *fakeCommands) AttachRegistry(ctx context.Context, cluster string, out io.Writer) error {
+	f.registry = "attach " + cluster
+	return f.err
+}
+
+func (f *fakeCommands) DetachRegistry(ctx context.Context, cluster string, out io.Writer) error {
+	f.registry = "detach " + cluster
+	return f.err
+}
+
+// run swaps in fake and a temporary template store, then runs ki with args
+func run(t *testing.T, fake *fakeCommands, args ...string) (int, string, string) {
+	t.Helper()
+	return runWithTemplates(t, fake, cmd.TemplateStore{Dir: filepath.Join(t.TempDir(), "templates")}, args...)
+}
+
+// runWithTemplates runs ki with args against fake and the given template store
+func runWithTemplates(t *testing.T, fake *fakeCommands, templates cmd.TemplateStore, args ...string) (int, string, string) {
+	t.Helper()
+
+	originalCommands, originalTemplates := cmd.Commands, cmd.Templates
+	cmd.Commands = fake
+	cmd.Templates = templates
+	defer func() {
+		cmd.Commands, cmd.Templates = originalCommands, originalTemplates
+	}()
+
+	var stdout, stderr bytes.Buffer
+	code := Run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+var testClusters = []cmd.Cluster{
+	{
+		Name:        "dev",
+		Status:      "running",
+		KubeVersion: "v1.30.0",
+		Nodes: []cmd.Node{
+			{Name: "dev-control-plane", Role: "control-plane", Status: "Ready", Version: "v1.30.0", InternalIP: "172.18.0.2", Age: "1d"},
+		},
+	},
+	{Name: "test", Status: "running"},
+}
+
+func TestRunUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"no arguments", nil, ExitOK},
+		{"help", []string{"help"}, ExitOK},
+		{"unknown command", []string{"frobnicate"}, ExitUsage},
+		{"unknown flag", []string{"list", "--frobnicate"}, ExitUsage},
+		{"unknown output format", []string{"list", "-o", "xml"}, ExitUsage},
+		{"delete without a name", []string{"delete"}, ExitUsage},
+		{"load without an image", []string{"load", "--name", "dev"}, ExitUsage},
+		{"load without an archive", []string{"load", "--archive"}, ExitUsage},
+		{"build without a tag", []string{"build", "."}, ExitUsage},
+		{"build without a context", []string{"build", "-t", "myapp:dev"}, ExitUsage},
+		{"status with two names", []string{"status", "a", "b"}, ExitUsage},
+		{"template with node counts", []string{"create", "--template", "workers", "--workers", "2"}, ExitUsage},
+		{"subcommand help", []string{"create", "-h"}, ExitOK},
+		{"registry without an action", []string{"registry"}, ExitUsage},
+		{"registry attach without a name", []string{"registry", "attach"}, ExitUsage},
+		{"unknown registry action", []string{"registry", "prune"}, ExitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _, _ := run(t, &fakeCommands{}, tt.args...)
+			if code != tt.expected {
+				t.Errorf("Run(%v) = %d, expected %d", tt.args, code, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRunList(t *testing.T) {
+	fake := &fakeCommands{clusters: testClusters}
+
+	code, stdout, _ := run(t, fake, "list")
+	if code != ExitOK {
+		t.Fatalf("list exited with %d", code)
+	}
+	for _, expected := range []string{"NAME", "dev", "v1.30.0", "test"} {
+		if !strings.Contains(stdout, expected) {
+			t.Errorf("list table should contain %q.\nGot:\n%s", expected, stdout)
+		}
+	}
+
+	code, stdout, _ = run(t, fake, "list", "-o", "json")
+	if code != ExitOK {
+		t.Fatalf("list -o json exited with %d", code)
+	}
+	var clusters []cmd.Cluster
+	if err := json.Unmarshal([]byte(stdout), &clusters); err != nil {
+		t.Fatalf("list -o json is not valid JSON: %v\n%s", err, stdout)
+	}
+	if len(clusters) != 2 || clusters[0].Nodes[0].InternalIP != "172.18.0.2" {
+		t.Errorf("list -o json decoded to %+v", clusters)
+	}
+
+	code, stdout, _ = run(t, fake, "list", "--output", "yaml")
+	if code != ExitOK {
+		t.Fatalf("list --output yaml exited with %d", code)
+	}
+	clusters = nil
+	if err := yaml.Unmarshal([]byte(stdout), &clusters); err != nil || len(clusters) != 2 {
+		t.Errorf("list -o yaml decoded to %+v (%v)", clusters, err)
+	}
+}
+
+func TestRunListError(t *testing.T) {
+	code, _, stderr := run(t, &fakeCommands{err: errors.New("kind not running")}, "list")
+	if code != ExitError {
+		t.Errorf("list should exit with %d on failure, got %d", ExitError, code)
+	}
+	if !strings.Contains(stderr, "kind not running") {
+		t.Errorf("stderr should contain the error, got %q", stderr)
+	}
+}
+
+func TestRunStatus(t *testing.T) {
+	fake := &fakeCommands{clusters: testClusters}
+
+	code, stdout, _ := run(t, fake, "status", "dev")
+	if code != ExitOK {
+		t.Fatalf("status exited with %d", code)
+	}
+	if !strings.Contains(stdout, "dev-control-plane") {
+		t.Errorf("status should list the nodes.\nGot:\n%s", stdout)
+	}
+	if len(fake.detailed) != 1 || fake.detailed[0] != "dev" {
+		t.Errorf("status should only query dev, queried %v", fake.detailed)
+	}
+
+	broken := &fakeCommands{clusters: []cmd.Cluster{{Name: "dev", Status: "running", Error: "failed to get nodes: connection refused"}}}
+	if _, stdout, _ := run(t, broken, "status", "dev"); !strings.Contains(stdout, "failed to get nodes: connection refused") {
+		t.Errorf("status should say why the nodes are missing.\nGot:\n%s", stdout)
+	}
+
+	code, _, stderr := run(t, fake, "status", "missing")
+	if code != ExitError || !strings.Contains(stderr, `cluster "missing" not found`) {
+		t.Errorf("status of a missing cluster = %d, %q", code, stderr)
+	}
+}
+
+func TestRunPods(t *testing.T) {
+	fake := &fakeCommands{pods: []cmd.Pod{
+		{Namespace: "kube-system", Name: "coredns-abc", Ready: "1/1", Status: "Running", Age: "2d", Node: "dev-control-plane"},
+	}}
+
+	code, stdout, _ := run(t, fake, "pods", "dev", "-n", "kube-system")
+	if code != ExitOK {
+		t.Fatalf("pods exited with %d", code)
+	}
+	if fake.cluster != "dev" || fake.podsIn != "kube-system" {
+		t.Errorf("pods queried %q in %q, expected dev in kube-system", fake.cluster, fake.podsIn)
+	}
+	if !strings.Contains(stdout, "coredns-abc") || !strings.Contains(stdout, "RESTARTS") {
+		t.Errorf("pods should print a table.\nGot:\n%s", stdout)
+	}
+
+	if code, _, _ := run(t, fake, "pods"); code != ExitUsage {
+		t.Errorf("pods without a cluster = %d, expected %d", code, ExitUsage)
+	}
+}
+
+func TestRunCreate(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedName  string
+		controlPlanes int
+		workers       int
+	}{
+		{"defaults", []string{"create"}, "kind", 1, 0},
+		{"node counts", []string{"create", "--name", "dev", "--control-planes", "3", "--workers", "2"}, "dev", 3, 2},
+		{"builtin template", []string{"create", "--template", "workers", "--name", "ci"}, "ci", 1, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeCommands{}
+			code, stdout, stderr := run(t, fake, tt.args...)
+			if code != ExitOK {
+				t.Fatalf("create exited with %d: %s", code, stderr)
+			}
+			if fake.created.Name != tt.expectedName {
+				t.Errorf("created cluster %q, expected %q", fake.created.Name, tt.expectedName)
+			}
+			if got := fake.created.CountNodes(cmd.RoleControlPlane); got != tt.controlPlanes {
+				t.Errorf("created %d control-plane nodes, expected %d", got, tt.controlPlanes)
+			}
+			if got := fake.created.CountNodes(cmd.RoleWorker); got != tt.workers {
+				t.Errorf("created %d workers, expected %d", got, tt.workers)
+			}
+			if !strings.Contains(stderr, "Preparing nodes") {
+				t.Error("KIND output should be streamed to stderr")
+			}
+			if !strings.Contains(stdout, "created") {
+				t.Errorf("stdout should confirm the create, got %q", stdout)
+			}
+		})
+	}
+}
+
+func TestRunCreateImage(t *testing.T) {
+	templates := cmd.TemplateStore{Dir: filepath.Join(t.TempDir(), "templates")}
+	if err := templates.Save(cmd.Template{Name: "bare"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{
+		{"create", "--workers", "1", "--image", "kindest/node:v1.30.0"},
+		{"create", "--template", "bare", "--image", "kindest/node:v1.30.0"},
+	} {
+		fake := &fakeCommands{}
+		if code, _, stderr := runWithTemplates(t, fake, templates, args...); code != ExitOK {
+			t.Fatalf("%v exited with %d: %s", args, code, stderr)
+		}
+		if len(fake.created.Nodes) == 0 {
+			t.Errorf("%v created no explicit nodes, so the image is lost", args)
+		}
+		for _, node := range fake.created.Nodes {
+			if node.Image != "kindest/node:v1.30.0" {
+				t.Errorf("%v created %s node with image %q", args, node.Role, node.Image)
+			}
+		}
+	}
+}
+
+func TestRunCreateRegistry(t *testing.T) {
+	fake := &fakeCommands{}
+	if code, _, stderr := run(t, fake, "create", "--name", "dev", "--registry"); code != ExitOK {
+		t.Fatalf("create --registry exited with %d: %s", code, stderr)
+	}
+	if !fake.created.Registry {
+		t.Error("create --registry should attach the local registry")
+	}
+}
+
+func TestRunRegistry(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"registry", "create"}, "create"},
+		{[]string{"registry", "attach", "dev"}, "attach dev"},
+		{[]string{"registry", "detach", "dev"}, "detach dev"},
+	}
+	for _, tt := range tests {
+		fake := &fakeCommands{}
+		code, stdout, _ := run(t, fake, tt.args...)
+		if code != ExitOK || fake.registry != tt.expected {
+			t.Errorf("Run(%v) = %d, ran %q, expected %q", tt.args, code, fake.registry, tt.expected)
+		}
+		if !strings.Contains(stdout, "Registry") {
+			t.Errorf("Run(%v) should confirm the action, got %q", tt.args, stdout)
+		}
+	}
+}
+
+func TestRunKubeconfig(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"kubeconfig", "export", "dev"}, "get dev false"},
+		{[]string{"kubeconfig", "export", "dev", "--internal", "dev.kubeconfig"}, "export dev dev.kubeconfig true"},
+		{[]string{"kubeconfig", "merge", "dev"}, "merge dev"},
+		{[]string{"kubeconfig", "remove", "dev"}, "remove dev"},
+		{[]string{"kubeconfig", "use", "dev"}, "use dev"},
+	}
+	for _, tt := range tests {
+		fake := &fakeCommands{}
+		code, stdout, _ := run(t, fake, tt.args...)
+		if code != ExitOK || fake.kubeconfig != tt.expected || stdout == "" {
+			t.Errorf("Run(%v) = %d, ran %q, expected %q", tt.args, code, fake.kubeconfig, tt.expected)
+		}
+	}
+
+	// Without a file the kubeconfig is printed for redirection
+	if _, stdout, _ := run(t, &fakeCommands{}, "kubeconfig", "export", "dev"); stdout != "apiVersion: v1\n" {
+		t.Errorf("kubeconfig export without a file printed %q", stdout)
+	}
+
+	for _, args := range [][]string{
+		{"kubeconfig"},
+		{"kubeconfig", "dev"},
+		{"kubeconfig", "merge", "dev", "test"},
+		{"kubeconfig", "rename", "dev"},
+	} {
+		if code, _, _ := run(t, &fakeCommands{}, args...); code != ExitUsage {
+			t.Errorf("Run(%v) = %d, expected %d", args, code, ExitUsage)
+		}
+	}
+}
+
+func TestRunCreateUnknownTemplate(t *testing.T) {
+	code, _, _ := run(t, &fakeCommands{}, "create", "--template", "missing")
+	if code != ExitError {
+		t.Errorf("create with an unknown template = %d, expected %d", code, ExitError)
+	}
+}
+
+func TestRunClusterOperations(t *testing.T) {
+	fake := &fakeCommands{}
+
+	if code, _, _ := run(t, fake, "delete", "dev"); code != ExitOK || fake.deleted != "dev" {
+		t.Errorf("delete dev = %d, deleted %q", code, fake.deleted)
+	}
+
+	if code, stdout, _ := run(t, fake, "stop", "dev"); code != ExitOK || fake.stopped != "dev" || !strings.Contains(stdout, "stopped") {
+		t.Errorf("stop dev = %d, stopped %q", code, fake.stopped)
+	}
+	if code, _, _ := run(t, fake, "start", "dev"); code != ExitOK || fake.started != "dev" {
+		t.Errorf("start dev = %d, started %q", code, fake.started)
+	}
+	if code, _, _ := run(t, fake, "stop"); code != ExitUsage {
+		t.Errorf("stop without a name = %d, expected %d", code, ExitUsage)
+	}
+
+	// Flags may follow the positional argument
+	if code, _, _ := run(t, fake, "load", "nginx:latest", "--name", "dev"); code != ExitOK || fake.image != "nginx:latest" || fake.cluster != "dev" {
+		t.Errorf("load = %d, loaded %q into %q", code, fake.image, fake.cluster)
+	}
+	if code, _, _ := run(t, fake, "load", "nginx:latest", "redis:7"); code != ExitOK || fake.image != "nginx:latest redis:7" {
+		t.Errorf("load of two images = %d, loaded %q", code, fake.image)
+	}
+	if code, _, _ := run(t, fake, "load", "nginx:latest", "--name", "dev", "--nodes", "dev-worker, dev-worker2"); code != ExitOK || fake.nodes != "dev-worker dev-worker2" {
+		t.Errorf("load --nodes = %d, loaded into nodes %q", code, fake.nodes)
+	}
+	if code, stdout, _ := run(t, fake, "load", "--archive", "app.tar", "--name", "dev"); code != ExitOK || fake.archives != "app.tar" || !strings.Contains(stdout, "Archive 'app.tar' loaded") {
+		t.Errorf("load --archive = %d, loaded %q", code, fake.archives)
+	}
+	expected := cmd.BuildOptions{Context: "./app", Dockerfile: "./app/Dockerfile.dev", Tag: "myapp:dev"}
+	if code, _, _ := run(t, fake, "build", "./app", "-t", "myapp:dev", "-f", "./app/Dockerfile.dev", "--name", "dev"); code != ExitOK || fake.built != expected || fake.cluster != "dev" {
+		t.Errorf("build = %d, built %+v into %q", code, fake.built, fake.cluster)
+	}
+
+	if code, stdout, _ := run(t, fake, "logs", "--name", "dev", "/tmp/logs"); code != ExitOK || fake.logsDir != "/tmp/logs" ||
+		!strings.Contains(stdout, "exported to /tmp/logs") {
+		t.Errorf("logs = %d, exported to %q, printed %q", code, fake.logsDir, stdout)
+	}
+	t.Setenv("TMPDIR", t.TempDir())
+	if code, stdout, _ := run(t, fake, "logs"); code != ExitOK || fake.logsDir == "" ||
+		!strings.Contains(stdout, "Logs of 'kind' exported to "+fake.logsDir) {
+		t.Errorf("logs without a directory = %d, exported to %q, printed %q", code, fake.logsDir, stdout)
+	}
+
+	fake.err = errors.New("boom")
+	if code, _, _ := run(t, fake, "delete", "dev"); code != ExitError {
+		t.Errorf("failed delete should exit with %d, got %d", ExitError, code)
+	}
+}
+
+func TestRunTemplates(t *testing.T) {
+	code, stdout, _ := run(t, &fakeCommands{}, "templates", "-o", "json")
+	if code != ExitOK {
+		t.Fatalf("templates exited with %d", code)
+	}
+
+	var templates []templateSummary
+	if err := json.Unmarshal([]byte(stdout), &templates); err != nil {
+		t.Fatalf("templates -o json is not valid JSON: %v", err)
+	}
+	if len(templates) != len(cmd.BuiltinTemplates()) {
+		t.Errorf("expected the builtin templates, got %+v", templates)
+	}
+}
@@ -0,0 +1,471 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"ki/internal/cmd"
+)
+
+func runList(e env, args []string) error {
+	fs := newFlagSet("list")
+	format := outputFlag(fs)
+	positional, err := parseArgs(e, fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usagef("list takes no arguments")
+	}
+	if err := validateFormat(*format); err != nil {
+		return err
+	}
+
+	clusters, err := cmd.Commands.GetClusters(e.ctx)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(e.stdout, *format, clusters, func(tw *tabwriter.Writer) {
+		row(tw, "NAME", "STATUS", "NODES", "VERSION")
+		for _, c := range clusters {
+			row(tw, c.Name, c.Status, len(c.Nodes), orDash(c.KubeVersion))
+		}
+	})
+}
+
+func runStatus(e env, args []string) error {
+	fs := newFlagSet("status")
+	format := outputFlag(fs)
+	positional, err := parseArgs(e, fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("status needs exactly one cluster name")
+	}
+	if err := validateFormat(*format); err != nil {
+		return err
+	}
+
+	cluster, err := findCluster(e, positional[0])
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(e.stdout, *format, cluster, func(tw *tabwriter.Writer) {
+		row(tw, "Cluster:", cluster.Name)
+		row(tw, "Status:", cluster.Status)
+		row(tw, "Version:", orDash(cluster.KubeVersion))
+		if cluster.Error != "" {
+			row(tw, "Error:", cluster.Error)
+		}
+		row(tw)
+		row(tw, "NODE", "ROLE", "STATUS", "VERSION", "INTERNAL-IP", "AGE")
+		for _, n := range cluster.Nodes {
+			row(tw, n.Name, n.Role, n.Status, n.Version, n.InternalIP, n.Age)
+		}
+		row(tw)
+		row(tw, "CONTAINER", "ROLE", "STATE")
+		for _, c := range cluster.Containers {
+			row(tw, c.Name, c.Role, c.State)
+		}
+	})
+}
+
+func runPods(e env, args []string) error {
+	fs := newFlagSet("pods")
+	format := outputFlag(fs)
+	namespace := fs.String("n", "", "only list pods in this namespace")
+	fs.StringVar(namespace, "namespace", "", "only list pods in this namespace")
+	positional, err := parseArgs(e, fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("pods needs exactly one cluster name")
+	}
+	if err := validateFormat(*format); err != nil {
+		return err
+	}
+
+	pods, err := cmd.Commands.GetPods(e.ctx, positional[0], *namespace)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(e.stdout, *format, pods, func(tw *tabwriter.Writer) {
+		row(tw, "NAMESPACE", "NAME", "READY", "STATUS", "RESTARTS", "AGE", "NODE")
+		for _, p := range pods {
+			row(tw, p.Namespace, p.Name, p.Ready, p.Status, fmt.Sprint(p.Restarts), p.Age, orDash(p.Node))
+		}
+	})
+}
+
+func runCreate(e env, args []string) error {
+	fs := newFlagSet("create")
+	name := fs.String("name", "", "cluster name (defaults to the template's name or \"kind\")")
+	template := fs.String("template", "", "create the cluster from a saved template")
+	image := fs.String("image", "", "node image for every node")
+	controlPlanes := fs.Int("control-planes", 1, "number of control-plane nodes")
+	workers := fs.Int("workers", 0, "number of worker nodes")
+	registry := fs.Bool("registry", false, "attach the local registry at "+cmd.RegistryHost)
+	positional, err := parseArgs(e, fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usagef("create takes no arguments, use --name to name the cluster")
+	}
+
+	counts := false
+	fs.Visit(func(f *flag.Flag) {
+		counts = counts || f.Name == "control-planes" || f.Name == "workers"
+	})
+
+	var config cmd.ClusterConfig
+	switch {
+	case *template != "" && counts:
+		return usagef("--control-planes and --workers cannot be combined with --template")
+	case *template != "":
+		t, err := cmd.Templates.Get(*template)
+		if err != nil {
+			return err
+		}
+		config = t.Cluster
+	default:
+		if *controlPlanes < 1 || *workers < 0 {
+			return usagef("need at least one control-plane node and a non-negative number of workers")
+		}
+		config = cmd.NewClusterConfig("", *controlPlanes, *workers, "")
+	}
+
+	if *name != "" {
+		config.Name = *name
+	}
+	if config.Name == "" {
+		config.Name = "kind"
+	}
+	if *image != "" {
+		// Without a node list KIND creates one control-plane node, which
+		// needs to be spelled out to carry the image
+		if len(config.Nodes) == 0 {
+			config.Nodes = cmd.NewClusterConfig("", 1, 0, "").Nodes
+		}
+		for i := range config.Nodes {
+			config.Nodes[i].Image = *image
+		}
+	}
+	if *registry {
+		config.Registry = true
+	}
+
+	// KIND's progress goes to stderr so stdout stays clean for scripts
+	if err := cmd.Commands.CreateCluster(e.ctx, config, e.stderr); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "Cluster '%s' created\n", config.Name)
+	return nil
+}
+
+func runDelete(e env, args []string) error {
+	return runClusterAction(e, "delete", "deleted", cmd.Commands.DeleteCluster, args)
+}
+
+func runStop(e env, args []string) error {
+	return runClusterAction(e, "stop", "stopped", cmd.Commands.StopCluster, args)
+}
+
+func runStart(e env, args []string) error {
+	return runClusterAction(e, "start", "started", cmd.Commands.StartCluster, args)
+}
+
+func runRestart(e env, args []string) error {
+	return runClusterAction(e, "restart", "restarted", cmd.Commands.RestartCluster, args)
+}
+
+// runClusterAction runs an operation that takes a single cluster name
+func runClusterAction(e env, name, done string, action func(context.Context, string, io.Writer) error, args []string) error {
+	fs := newFlagSet(name)
+	positional, err := parseArgs(e, fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("%s needs exactly one cluster name", name)
+	}
+
+	cluster := positional[0]
+	if err := action(e.ctx, cluster, e.stderr); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "Cluster '%s' %s\n", cluster, done)
+	return nil
+}
+
+func runRegistry(e env, args []string) error {
+	fs := newFlagSet("registry")
+	positional, err := parseArgs(e, fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return usagef("registry needs an action: create, attach or detach")
+	}
+
+	action, positional := positional[0], positional[1:]
+	switch action {
+	case "create":
+		if len(positional) > 0 {
+			return usagef("registry create takes no arguments")
+		}
+		if err := cmd.Commands.CreateRegistry(e.ctx, e.stderr); err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stdout, "Registry running at %s\n", cmd.RegistryHost)
+		return nil
+	case "attach", "detach":
+		if len(positional) != 1 {
+			return usagef("registry %s needs exactly one cluster name", action)
+		}
+		cluster := positional[0]
+		if action == "attach" {
+			if err := cmd.Commands.AttachRegistry(e.ctx, cluster, e.stderr); err != nil {
+				return err
+			}
+			fmt.Fprintf(e.stdout, "Registry %s attached to cluster '%s'\n", cmd.RegistryHost, cluster)
+			return nil
+		}
+		if err := cmd.Commands.DetachRegistry(e.ctx, cluster, e.stderr); err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stdout, "Registry detached from cluster '%s'\n", cluster)
+		return nil
+	}
+	return usagef("unknown registry action %q, expected create, attach or detach", action)
+}
+
+func runKubeconfig(e env, args []string) error {
+	fs := newFlagSet("kubeconfig")
+	internal := fs.Bool("internal", false, "export the kubeconfig used from inside the kind network")
+	positional, err := parseArgs(e, fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 {
+		return usagef("kubeconfig needs an action and a cluster name")
+	}
+
+	action, cluster, positional := positional[0], positional[1], positional[2:]
+	if action != "export" && len(positional) > 0 {
+		return usagef("kubeconfig %s takes a single cluster name", action)
+	}
+	switch action {
+	case "export":
+		if len(positional) > 1 {
+			return usagef("kubeconfig export takes at most one file")
+		}
+		if len(positional) == 0 {
+			kubeconfig, err := cmd.Commands.GetKubeconfig(e.ctx, cluster, *internal)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(e.stdout, kubeconfig)
+			return nil
+		}
+		if err := cmd.Commands.ExportKubeconfig(e.ctx, cluster, positional[0], *internal); err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stdout, "Kubeconfig of '%s' exported to %s\n", cluster, positional[0])
+		return nil
+	case "merge":
+		if err := cmd.Commands.MergeKubeconfig(e.ctx, cluster); err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stdout, "Kubeconfig of '%s' merged into %s\n", cluster, cmd.KubeconfigPath())
+		return nil
+	case "remove":
+		if err := cmd.Commands.RemoveKubeconfig(e.ctx, cluster); err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stdout, "Kubeconfig of '%s' removed from %s\n", cluster, cmd.KubeconfigPath())
+		return nil
+	case "use":
+		if err := cmd.Commands.UseKubeContext(e.ctx, cluster); err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stdout, "kubectl now uses '%s' by default\n", cluster)
+		return nil
+	}
+	return usagef("unknown kubeconfig action %q, expected export, merge, remove or use", action)
+}
+
+func runLoad(e env, args []string) error {
+	fs := newFlagSet("load")
+	cluster := fs.String("name", "", "cluster to load the image into (defaults to \"kind\")")
+	archive := fs.Bool("archive", false, "load image tarballs instead of images by name")
+	nodes := fs.String("nodes", "", "comma separated nodes to load into (defaults to every node)")
+	positional, err := parseArgs(e, fs, args)
+	if err != nil {
+		return err
+	}
+
+	if *archive {
+		if len(positional) == 0 {
+			return usagef("load --archive needs at least one archive")
+		}
+		if err := cmd.Commands.LoadImageArchive(e.ctx, positional, *cluster, splitList(*nodes), e.stderr); err != nil {
+			return err
+		}
+		for _, archive := range positional {
+			fmt.Fprintf(e.stdout, "Archive '%s' loaded\n", archive)
+		}
+		return nil
+	}
+
+	if len(positional) == 0 {
+		return usagef("load needs at least one image name")
+	}
+	if err := cmd.Commands.LoadDockerImage(e.ctx, positional, *cluster, splitList(*nodes), e.stderr); err != nil {
+		return err
+	}
+	for _, image := range positional {
+		fmt.Fprintf(e.stdout, "Image '%s' loaded\n", image)
+	}
+	return nil
+}
+
+func runBuild(e env, args []string) error {
+	fs := newFlagSet("build")
+	cluster := fs.String("name", "", "cluster to load the image into (defaults to \"kind\")")
+	tag := fs.String("t", "", "name and tag of the image, e.g. myapp:dev")
+	dockerfile := fs.String("f", "", "Dockerfile to build (defaults to CONTEXT/Dockerfile)")
+	nodes := fs.String("nodes", "", "comma separated nodes to load into (defaults to every node)")
+	positional, err := parseArgs(e, fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("build needs exactly one build context")
+	}
+	if *tag == "" {
+		return usagef("build needs an image tag (-t)")
+	}
+
+	opts := cmd.BuildOptions{Context: positional[0], Dockerfile: *dockerfile, Tag: *tag}
+	if err := cmd.Commands.BuildAndLoadImage(e.ctx, opts, *cluster, splitList(*nodes), e.stderr); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "Image '%s' built and loaded\n", *tag)
+	return nil
+}
+
+// splitList splits a comma separated flag value, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func runLogs(e env, args []string) error {
+	fs := newFlagSet("logs")
+	cluster := fs.String("name", "", "cluster to export logs from (defaults to \"kind\")")
+	positional, err := parseArgs(e, fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		return usagef("logs takes at most one output directory")
+	}
+
+	name := *cluster
+	if name == "" {
+		name = "kind"
+	}
+
+	// Pick the temporary directory KIND would, so it can be printed
+	var dir string
+	if len(positional) == 1 {
+		dir = positional[0]
+	} else if dir, err = os.MkdirTemp("", "ki-logs-"+name+"-"); err != nil {
+		return fmt.Errorf("failed to create the log directory: %w", err)
+	}
+	if err := cmd.Commands.ExportLogs(e.ctx, *cluster, dir, e.stderr); err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "Logs of '%s' exported to %s\n", name, dir)
+	return nil
+}
+
+// templateSummary is the scriptable view of a template
+type templateSummary struct {
+	Name        string `json:"name" yaml:"name"`
+	Nodes       string `json:"nodes" yaml:"nodes"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+func runTemplates(e env, args []string) error {
+	fs := newFlagSet("templates")
+	format := outputFlag(fs)
+	positional, err := parseArgs(e, fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usagef("templates takes no arguments")
+	}
+	if err := validateFormat(*format); err != nil {
+		return err
+	}
+
+	templates, err := cmd.Templates.List()
+	var broken *cmd.BrokenTemplatesError
+	if errors.As(err, &broken) {
+		fmt.Fprintf(e.stderr, "Warning: %v\n", err)
+	} else if err != nil {
+		return err
+	}
+
+	summaries := make([]templateSummary, len(templates))
+	for i, t := range templates {
+		summaries[i] = templateSummary{Name: t.Name, Nodes: t.Summary(), Description: t.Description}
+	}
+
+	return writeOutput(e.stdout, *format, summaries, func(tw *tabwriter.Writer) {
+		row(tw, "NAME", "NODES", "DESCRIPTION")
+		for _, t := range templates {
+			row(tw, t.Name, t.Summary(), orDash(t.Description))
+		}
+	})
+}
+
+// findCluster looks up the details of a cluster by name, failing when it does
+// not exist. Only that cluster is queried.
+func findCluster(e env, name string) (cmd.Cluster, error) {
+	names, err := cmd.Commands.GetClusterNames(e.ctx)
+	if err != nil {
+		return cmd.Cluster{}, err
+	}
+	if !slices.Contains(names, name) {
+		return cmd.Cluster{}, fmt.Errorf("cluster %q not found", name)
+	}
+	return cmd.Commands.GetClusterDetail(e.ctx, name)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by -o
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatYAML  = "yaml"
+)
+
+// outputFlag registers -o and --output on fs
+func outputFlag(fs *flag.FlagSet) *string {
+	format := FormatTable
+	fs.StringVar(&format, "o", FormatTable, "output format: table, json or yaml")
+	fs.StringVar(&format, "output", FormatTable, "output format: table, json or yaml")
+	return &format
+}
+
+// validateFormat rejects unknown output formats before any work is done
+func validateFormat(format string) error {
+	switch format {
+	case FormatTable, FormatJSON, FormatYAML:
+		return nil
+	}
+	return usagef("unknown output format %q, expected table, json or yaml", format)
+}
+
+// writeOutput writes v as JSON or YAML, or calls table to render it as a table
+func writeOutput(w io.Writer, format string, v any, table func(tw *tabwriter.Writer)) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return enc.Close()
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		table(tw)
+		return tw.Flush()
+	}
+}
+
+// row writes tab separated columns followed by a newline
+func row(tw *tabwriter.Writer, columns ...any) {
+	for i, column := range columns {
+		if i > 0 {
+			fmt.Fprint(tw, "\t")
+		}
+		fmt.Fprint(tw, column)
+	}
+	fmt.Fprintln(tw)
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"runtime"
+)
+
+// clipboardCommands are the tools that take clipboard contents on stdin, in the
+// order they are tried on each platform
+var clipboardCommands = map[string][][]string{
+	"darwin":  {{"pbcopy"}},
+	"windows": {{"clip"}},
+	"linux": {
+		{"wl-copy"},
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+		{"clip.exe"}, // WSL
+	},
+}
+
+// ErrNoClipboard is returned when no clipboard tool is installed
+var ErrNoClipboard = errors.New("no clipboard tool found, install wl-copy, xclip or xsel")
+
+// clipboardCommand returns the first installed clipboard tool for goos
+func clipboardCommand(goos string, lookPath func(string) (string, error)) ([]string, error) {
+	for _, candidate := range clipboardCommands[goos] {
+		if _, err := lookPath(candidate[0]); err == nil {
+			return candidate, nil
+		}
+	}
+	return nil, ErrNoClipboard
+}
+
+// CopyToClipboard puts text on the system clipboard
+func CopyToClipboard(ctx context.Context, text string) error {
+	tool, err := clipboardCommand(runtime.GOOS, exec.LookPath)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+	return runStepInput(ctx, nil, "Copying to the clipboard", text, tool[0], tool[1:]...)
+}
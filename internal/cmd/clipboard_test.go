@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClipboardCommand(t *testing.T) {
+	lookPath := func(installed ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, tool := range installed {
+				if tool == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		}
+	}
+
+	tool, err := clipboardCommand("linux", lookPath("xsel", "xclip"))
+	if err != nil || strings.Join(tool, " ") != "xclip -selection clipboard" {
+		t.Errorf("clipboardCommand() = %v, %v, expected xclip before xsel", tool, err)
+	}
+	if tool, _ := clipboardCommand("darwin", lookPath("pbcopy")); len(tool) != 1 || tool[0] != "pbcopy" {
+		t.Errorf("clipboardCommand() on macOS = %v", tool)
+	}
+	if _, err := clipboardCommand("linux", lookPath()); !errors.Is(err, ErrNoClipboard) {
+		t.Errorf("clipboardCommand() without tools = %v, expected ErrNoClipboard", err)
+	}
+}
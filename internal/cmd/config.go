@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KIND config API identifiers
+const (
+	ConfigKind       = "Cluster"
+	ConfigAPIVersion = "kind.x-k8s.io/v1alpha4"
+)
+
+// Node roles understood by KIND
+const (
+	RoleControlPlane = "control-plane"
+	RoleWorker       = "worker"
+)
+
+// ClusterConfig describes a KIND cluster to create
+type ClusterConfig struct {
+	Name                    string          `yaml:"name,omitempty"`
+	Nodes                   []NodeConfig    `yaml:"nodes,omitempty"`
+	Networking              Networking      `yaml:"networking,omitempty"`
+	FeatureGates            map[string]bool `yaml:"featureGates,omitempty"`
+	ContainerdConfigPatches []string        `yaml:"containerdConfigPatches,omitempty"`
+	KubeadmConfigPatches    []string        `yaml:"kubeadmConfigPatches,omitempty"`
+	// Registry attaches the shared local registry; it is a ki setting and not
+	// part of the KIND config
+	Registry bool `yaml:"registry,omitempty"`
+}
+
+// NodeConfig describes a single node of a KIND cluster
+type NodeConfig struct {
+	Role                 string            `yaml:"role"`
+	Image                string            `yaml:"image,omitempty"`
+	Labels               map[string]string `yaml:"labels,omitempty"`
+	ExtraPortMappings    []PortMapping     `yaml:"extraPortMappings,omitempty"`
+	ExtraMounts          []Mount           `yaml:"extraMounts,omitempty"`
+	KubeadmConfigPatches []string          `yaml:"kubeadmConfigPatches,omitempty"`
+}
+
+// PortMapping maps a host port to a port on the node container
+type PortMapping struct {
+	ContainerPort int    `yaml:"containerPort"`
+	HostPort      int    `yaml:"hostPort,omitempty"`
+	ListenAddress string `yaml:"listenAddress,omitempty"`
+	Protocol      string `yaml:"protocol,omitempty"`
+}
+
+// Mount mounts a host path into the node container
+type Mount struct {
+	HostPath      string `yaml:"hostPath"`
+	ContainerPath string `yaml:"containerPath"`
+	ReadOnly      bool   `yaml:"readOnly,omitempty"`
+}
+
+// Networking holds the cluster wide network settings
+type Networking struct {
+	IPFamily          string `yaml:"ipFamily,omitempty"`
+	APIServerAddress  string `yaml:"apiServerAddress,omitempty"`
+	APIServerPort     int    `yaml:"apiServerPort,omitempty"`
+	PodSubnet         string `yaml:"podSubnet,omitempty"`
+	ServiceSubnet     string `yaml:"serviceSubnet,omitempty"`
+	DisableDefaultCNI bool   `yaml:"disableDefaultCNI,omitempty"`
+	KubeProxyMode     string `yaml:"kubeProxyMode,omitempty"`
+}
+
+// clusterDocument is the on-disk shape of a KIND Cluster config
+type clusterDocument struct {
+	Kind          string `yaml:"kind"`
+	APIVersion    string `yaml:"apiVersion"`
+	ClusterConfig `yaml:",inline"`
+}
+
+// NewClusterConfig builds a config with the given number of control-plane and worker nodes
+func NewClusterConfig(name string, controlPlanes, workers int, image string) ClusterConfig {
+	config := ClusterConfig{Name: name}
+	for i := 0; i < controlPlanes; i++ {
+		config.Nodes = append(config.Nodes, NodeConfig{Role: RoleControlPlane, Image: image})
+	}
+	for i := 0; i < workers; i++ {
+		config.Nodes = append(config.Nodes, NodeConfig{Role: RoleWorker, Image: image})
+	}
+	return config
+}
+
+// CountNodes returns the number of nodes with the given role
+func (c ClusterConfig) CountNodes(role string) int {
+	count := 0
+	for _, node := range c.Nodes {
+		if node.Role == role {
+			count++
+		}
+	}
+	return count
+}
+
+// Validate checks the config for mistakes KIND would only report after starting
+func (c ClusterConfig) Validate() error {
+	if len(c.Nodes) > 0 && c.CountNodes(RoleControlPlane) == 0 {
+		return fmt.Errorf("cluster needs at least one control-plane node")
+	}
+	for i, node := range c.Nodes {
+		if node.Role != RoleControlPlane && node.Role != RoleWorker {
+			return fmt.Errorf("node %d: unknown role %q", i, node.Role)
+		}
+		for _, pm := range node.ExtraPortMappings {
+			if pm.ContainerPort <= 0 || pm.ContainerPort > 65535 || pm.HostPort < 0 || pm.HostPort > 65535 {
+				return fmt.Errorf("node %d: invalid port mapping %d:%d", i, pm.HostPort, pm.ContainerPort)
+			}
+		}
+		for _, m := range node.ExtraMounts {
+			if m.HostPath == "" || m.ContainerPath == "" {
+				return fmt.Errorf("node %d: mount needs both a host and a container path", i)
+			}
+		}
+	}
+	return nil
+}
+
+// RenderClusterConfig renders the config as a KIND Cluster document
+func RenderClusterConfig(c ClusterConfig) ([]byte, error) {
+	doc := clusterDocument{
+		Kind:          ConfigKind,
+		APIVersion:    ConfigAPIVersion,
+		ClusterConfig: c,
+	}
+	// The name is passed with --name so it can be overridden at create time
+	doc.Name = ""
+	// A registry needs containerd to read the hosts.toml files ki writes into the nodes
+	if doc.Registry {
+		if !slices.Contains(doc.ContainerdConfigPatches, RegistryContainerdPatch) {
+			doc.ContainerdConfigPatches = append(append([]string{}, doc.ContainerdConfigPatches...), RegistryContainerdPatch)
+		}
+		doc.Registry = false
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render cluster config: %w", err)
+	}
+	return out, nil
+}
+
+// ParsePortMappings parses "[listenAddress:]hostPort:containerPort[/protocol]"
+// entries separated by commas. IPv6 listen addresses go in brackets.
+func ParsePortMappings(s string) ([]PortMapping, error) {
+	var mappings []PortMapping
+	for _, entry := range splitList(s) {
+		protocol := ""
+		if idx := strings.Index(entry, "/"); idx >= 0 {
+			protocol = strings.ToUpper(entry[idx+1:])
+			entry = entry[:idx]
+		}
+
+		listenAddress := ""
+		ports := entry
+		if idx := strings.LastIndex(entry, ":"); idx >= 0 {
+			if prev := strings.LastIndex(entry[:idx], ":"); prev >= 0 {
+				listenAddress = strings.TrimSuffix(strings.TrimPrefix(entry[:prev], "["), "]")
+				ports = entry[prev+1:]
+				if listenAddress == "" {
+					return nil, fmt.Errorf("invalid port mapping %q, expected [listenAddress:]hostPort:containerPort", entry)
+				}
+			}
+		}
+
+		parts := strings.Split(ports, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid port mapping %q, expected [listenAddress:]hostPort:containerPort", entry)
+		}
+		hostPort, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid host port %q", parts[0])
+		}
+		containerPort, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid container port %q", parts[1])
+		}
+
+		mappings = append(mappings, PortMapping{
+			ContainerPort: containerPort,
+			HostPort:      hostPort,
+			ListenAddress: listenAddress,
+			Protocol:      protocol,
+		})
+	}
+	return mappings, nil
+}
+
+// FormatPortMappings is the inverse of ParsePortMappings
+func FormatPortMappings(mappings []PortMapping) string {
+	entries := make([]string, 0, len(mappings))
+	for _, pm := range mappings {
+		entry := fmt.Sprintf("%d:%d", pm.HostPort, pm.ContainerPort)
+		switch {
+		case strings.Contains(pm.ListenAddress, ":"):
+			entry = "[" + pm.ListenAddress + "]:" + entry
+		case pm.ListenAddress != "":
+			entry = pm.ListenAddress + ":" + entry
+		}
+		if pm.Protocol != "" {
+			entry += "/" + strings.ToLower(pm.Protocol)
+		}
+		entries = append(entries, entry)
+	}
+	return strings.Join(entries, ",")
+}
+
+// ParseMounts parses "hostPath:containerPath[:ro]" entries separated by commas
+func ParseMounts(s string) ([]Mount, error) {
+	var mounts []Mount
+	for _, entry := range splitList(s) {
+		parts := strings.Split(entry, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid mount %q, expected hostPath:containerPath[:ro]", entry)
+		}
+
+		mount := Mount{HostPath: parts[0], ContainerPath: parts[1]}
+		if len(parts) == 3 {
+			if parts[2] != "ro" {
+				return nil, fmt.Errorf("invalid mount option %q, only 'ro' is supported", parts[2])
+			}
+			mount.ReadOnly = true
+		}
+		mounts = append(mounts, mount)
+	}
+	return mounts, nil
+}
+
+// FormatMounts is the inverse of ParseMounts
+func FormatMounts(mounts []Mount) string {
+	entries := make([]string, 0, len(mounts))
+	for _, m := range mounts {
+		entry := m.HostPath + ":" + m.ContainerPath
+		if m.ReadOnly {
+			entry += ":ro"
+		}
+		entries = append(entries, entry)
+	}
+	return strings.Join(entries, ",")
+}
+
+// ParseFeatureGates parses "Name=true" entries separated by commas
+func ParseFeatureGates(s string) (map[string]bool, error) {
+	entries := splitList(s)
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	gates := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid feature gate %q, expected Name=true|false", entry)
+		}
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for feature gate %s: %q", name, value)
+		}
+		gates[name] = enabled
+	}
+	return gates, nil
+}
+
+// FormatFeatureGates is the inverse of ParseFeatureGates
+func FormatFeatureGates(gates map[string]bool) string {
+	names := make([]string, 0, len(gates))
+	for name := range gates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	entries := make([]string, 0, len(names))
+	for _, name := range names {
+		entries = append(entries, fmt.Sprintf("%s=%t", name, gates[name]))
+	}
+	return strings.Join(entries, ",")
+}
+
+// splitList splits a comma separated list, dropping empty entries
+func splitList(s string) []string {
+	var entries []string
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderClusterConfig(t *testing.T) {
+	config := NewClusterConfig("dev", 1, 2, "kindest/node:v1.29.2")
+	config.Nodes[0].ExtraPortMappings = []PortMapping{{ContainerPort: 80, HostPort: 8080}}
+	config.Nodes[0].ExtraMounts = []Mount{{HostPath: "/tmp/data", ContainerPath: "/data", ReadOnly: true}}
+	config.Networking.PodSubnet = "10.244.0.0/16"
+	config.ContainerdConfigPatches = []string{"[plugins]\n"}
+
+	out, err := RenderClusterConfig(config)
+	if err != nil {
+		t.Fatalf("RenderClusterConfig() error = %v", err)
+	}
+	result := string(out)
+
+	contains := []string{
+		"kind: Cluster",
+		"apiVersion: kind.x-k8s.io/v1alpha4",
+		"role: control-plane",
+		"role: worker",
+		"image: kindest/node:v1.29.2",
+		"containerPort: 80",
+		"hostPort: 8080",
+		"hostPath: /tmp/data",
+		"readOnly: true",
+		"podSubnet: 10.244.0.0/16",
+		"containerdConfigPatches:",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderClusterConfig() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+
+	if strings.Contains(result, "name: dev") {
+		t.Error("RenderClusterConfig() should leave the name to --name")
+	}
+	if strings.Count(result, "role: worker") != 2 {
+		t.Errorf("Expected 2 worker nodes.\nGot:\n%s", result)
+	}
+}
+
+func TestClusterConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ClusterConfig
+		wantErr bool
+	}{
+		{
+			name:   "name only",
+			config: ClusterConfig{Name: "kind"},
+		},
+		{
+			name:   "control-plane and workers",
+			config: NewClusterConfig("kind", 3, 2, ""),
+		},
+		{
+			name:    "workers without control-plane",
+			config:  NewClusterConfig("kind", 0, 2, ""),
+			wantErr: true,
+		},
+		{
+			name:    "unknown role",
+			config:  ClusterConfig{Nodes: []NodeConfig{{Role: "master"}}},
+			wantErr: true,
+		},
+		{
+			name: "invalid port",
+			config: ClusterConfig{Nodes: []NodeConfig{{
+				Role:              RoleControlPlane,
+				ExtraPortMappings: []PortMapping{{ContainerPort: 70000}},
+			}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParsePortMappings(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []PortMapping
+		wantErr  bool
+	}{
+		{
+			name:     "empty",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:  "multiple with protocol",
+			input: "80:80, 443:8443/udp",
+			expected: []PortMapping{
+				{HostPort: 80, ContainerPort: 80},
+				{HostPort: 443, ContainerPort: 8443, Protocol: "UDP"},
+			},
+		},
+		{
+			name:  "listen address",
+			input: "127.0.0.1:8080:80,[::1]:8443:443/tcp",
+			expected: []PortMapping{
+				{HostPort: 8080, ContainerPort: 80, ListenAddress: "127.0.0.1"},
+				{HostPort: 8443, ContainerPort: 443, ListenAddress: "::1", Protocol: "TCP"},
+			},
+		},
+		{
+			name:    "missing container port",
+			input:   "80",
+			wantErr: true,
+		},
+		{
+			name:    "empty listen address",
+			input:   ":80:80",
+			wantErr: true,
+		},
+		{
+			name:    "non numeric",
+			input:   "http:80",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParsePortMappings(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePortMappings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(result) != len(tt.expected) {
+				t.Fatalf("ParsePortMappings() returned %d mappings, expected %d", len(result), len(tt.expected))
+			}
+			for i := range result {
+				if result[i] != tt.expected[i] {
+					t.Errorf("Mapping[%d] = %+v, expected %+v", i, result[i], tt.expected[i])
+				}
+			}
+			if formatted := FormatPortMappings(result); !tt.wantErr && formatted != "" {
+				again, err := ParsePortMappings(formatted)
+				if err != nil || len(again) != len(result) {
+					t.Fatalf("ParsePortMappings(%q) = %+v, %v, expected a round trip", formatted, again, err)
+				}
+				for i := range again {
+					if again[i] != result[i] {
+						t.Errorf("Round trip mapping[%d] = %+v, expected %+v", i, again[i], result[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParseMounts(t *testing.T) {
+	mounts, err := ParseMounts("/src:/app, /cache:/var/cache:ro")
+	if err != nil {
+		t.Fatalf("ParseMounts() error = %v", err)
+	}
+	if len(mounts) != 2 {
+		t.Fatalf("ParseMounts() returned %d mounts, expected 2", len(mounts))
+	}
+	if mounts[0].ReadOnly || !mounts[1].ReadOnly {
+		t.Errorf("ParseMounts() read-only flags wrong: %+v", mounts)
+	}
+	if FormatMounts(mounts) != "/src:/app,/cache:/var/cache:ro" {
+		t.Errorf("FormatMounts() = %q", FormatMounts(mounts))
+	}
+
+	for _, input := range []string{"/only-host", "/a:/b:rw", ":/b"} {
+		if _, err := ParseMounts(input); err == nil {
+			t.Errorf("ParseMounts(%q) should fail", input)
+		}
+	}
+}
+
+func TestParseFeatureGates(t *testing.T) {
+	gates, err := ParseFeatureGates("B=false,A=true")
+	if err != nil {
+		t.Fatalf("ParseFeatureGates() error = %v", err)
+	}
+	if !gates["A"] || gates["B"] {
+		t.Errorf("ParseFeatureGates() = %v", gates)
+	}
+	if FormatFeatureGates(gates) != "A=true,B=false" {
+		t.Errorf("FormatFeatureGates() = %q", FormatFeatureGates(gates))
+	}
+
+	if _, err := ParseFeatureGates("A=maybe"); err == nil {
+		t.Error("ParseFeatureGates() should reject non boolean values")
+	}
+}
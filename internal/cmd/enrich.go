@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// EnrichWorkers is how many clusters are enriched at the same time
+	EnrichWorkers = 4
+	// EnrichTTL is how long an enriched cluster is reused before it is queried again
+	EnrichTTL = 10 * time.Second
+)
+
+// clusterCache keeps the last enrichment of each cluster, so lists render
+// from the last known state while it is refreshed
+var clusterCache = struct {
+	sync.Mutex
+	entries map[string]cachedCluster
+}{entries: map[string]cachedCluster{}}
+
+type cachedCluster struct {
+	cluster Cluster
+	fetched time.Time
+}
+
+// CachedCluster returns the last known state of a cluster, however old it is
+func CachedCluster(name string) (Cluster, bool) {
+	clusterCache.Lock()
+	defer clusterCache.Unlock()
+	entry, ok := clusterCache.entries[name]
+	return entry.cluster, ok
+}
+
+// forgetCluster drops the cached state of a cluster after an operation changed it
+func forgetCluster(name string) {
+	clusterCache.Lock()
+	defer clusterCache.Unlock()
+	delete(clusterCache.entries, name)
+}
+
+// EnrichClusters enriches the named clusters, at most EnrichWorkers at a time,
+// and calls found with each one as soon as it is ready. Clusters enriched less
+// than maxAge ago are served from the cache. found is called from several
+// goroutines; EnrichClusters returns once every cluster was passed to it or
+// ctx is cancelled.
+func EnrichClusters(ctx context.Context, names []string, maxAge time.Duration, found func(Cluster)) {
+	enrichClusters(ctx, names, maxAge, EnrichClusterInfo, found)
+}
+
+func enrichClusters(ctx context.Context, names []string, maxAge time.Duration,
+	enrich func(context.Context, Cluster) Cluster, found func(Cluster)) {
+	work := make(chan string)
+	var wg sync.WaitGroup
+	for range min(EnrichWorkers, len(names)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range work {
+				found(enrichCluster(ctx, name, maxAge, enrich))
+			}
+		}()
+	}
+
+feed:
+	for _, name := range names {
+		select {
+		case work <- name:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+}
+
+// enrichCluster returns the cached cluster when it is fresh enough and
+// enriches and caches it otherwise
+func enrichCluster(ctx context.Context, name string, maxAge time.Duration,
+	enrich func(context.Context, Cluster) Cluster) Cluster {
+	clusterCache.Lock()
+	entry, ok := clusterCache.entries[name]
+	clusterCache.Unlock()
+	if ok && time.Since(entry.fetched) < maxAge {
+		return entry.cluster
+	}
+
+	cluster := enrich(ctx, Cluster{Name: name})
+	if ctx.Err() == nil {
+		cacheCluster(cluster)
+	}
+	return cluster
+}
+
+// cacheCluster stores a freshly enriched cluster
+func cacheCluster(cluster Cluster) {
+	clusterCache.Lock()
+	defer clusterCache.Unlock()
+	clusterCache.entries[cluster.Name] = cachedCluster{cluster: cluster, fetched: time.Now()}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEnrichClustersBounded(t *testing.T) {
+	names := make([]string, 3*EnrichWorkers)
+	for i := range names {
+		names[i] = fmt.Sprintf("bounded-%d", i)
+		t.Cleanup(func() { forgetCluster(names[i]) })
+	}
+
+	var running, peak atomic.Int32
+	enrich := func(ctx context.Context, c Cluster) Cluster {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		c.Status = StatusRunning
+		return c
+	}
+
+	var mu sync.Mutex
+	found := map[string]string{}
+	enrichClusters(context.Background(), names, 0, enrich, func(c Cluster) {
+		mu.Lock()
+		found[c.Name] = c.Status
+		mu.Unlock()
+	})
+
+	if len(found) != len(names) {
+		t.Errorf("enrichClusters() found %d clusters, expected %d", len(found), len(names))
+	}
+	if p := peak.Load(); p > EnrichWorkers || p < 2 {
+		t.Errorf("enrichClusters() ran %d enrichments at once, expected 2 to %d", p, EnrichWorkers)
+	}
+	if cached, ok := CachedCluster(names[0]); !ok || cached.Status != StatusRunning {
+		t.Errorf("CachedCluster() = %+v, %v, expected the enriched cluster", cached, ok)
+	}
+}
+
+func TestEnrichClustersCache(t *testing.T) {
+	cacheCluster(Cluster{Name: "cached", Status: StatusStopped})
+	t.Cleanup(func() { forgetCluster("cached") })
+
+	calls := 0
+	enrich := func(ctx context.Context, c Cluster) Cluster {
+		calls++
+		c.Status = StatusRunning
+		return c
+	}
+	var got Cluster
+	found := func(c Cluster) { got = c }
+
+	enrichClusters(context.Background(), []string{"cached"}, time.Minute, enrich, found)
+	if calls != 0 || got.Status != StatusStopped {
+		t.Errorf("a fresh cluster was enriched again: calls = %d, status = %q", calls, got.Status)
+	}
+
+	enrichClusters(context.Background(), []string{"cached"}, 0, enrich, found)
+	if calls != 1 || got.Status != StatusRunning {
+		t.Errorf("maxAge 0 should enrich again: calls = %d, status = %q", calls, got.Status)
+	}
+
+	forgetCluster("cached")
+	if _, ok := CachedCluster("cached"); ok {
+		t.Error("forgetCluster() kept the cluster")
+	}
+}
+
+func TestEnrichClustersCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		enrichClusters(ctx, []string{"a", "b", "c", "d", "e", "f"}, 0, func(ctx context.Context, c Cluster) Cluster {
+			return c
+		}, func(Cluster) {})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("enrichClusters() did not return after ctx was cancelled")
+	}
+	if _, ok := CachedCluster("a"); ok {
+		t.Error("a cancelled enrichment should not be cached")
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// Timeouts applied to KIND operations on top of the caller's context
+const (
+	QueryTimeout    = 30 * time.Second
+	CreateTimeout   = 10 * time.Minute
+	DeleteTimeout   = 2 * time.Minute
+	LoadTimeout     = 10 * time.Minute
+	BuildTimeout    = 60 * time.Minute
+	ExportTimeout   = 5 * time.Minute
+	StopTimeout     = 2 * time.Minute
+	StartTimeout    = 5 * time.Minute
+	RegistryTimeout = 5 * time.Minute
+	WorkerTimeout   = 5 * time.Minute
+)
+
+// waitDelay is how long a cancelled command gets to exit after being interrupted
+const waitDelay = 5 * time.Second
+
+// command creates a command bound to ctx. Cancelling ctx interrupts the process
+// so tools like kind can clean up, and kills it if it has not exited after waitDelay.
+func command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = waitDelay
+	return cmd
+}
+
+// contextError prefers the context's error over the one of a killed process
+func contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// commandError is contextError, with the last line a failed process wrote to
+// stderr in place of a bare exit status
+func commandError(ctx context.Context, err error) error {
+	err = contextError(ctx, err)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(bytes.TrimSpace(exitErr.Stderr)) > 0 {
+		return errors.New(lastLine(string(exitErr.Stderr)))
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestCommandCancellation(t *testing.T) {
+	if _, err := exec.LookPath("sleep"); err != nil {
+		t.Skip("sleep command not found, skipping test")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := command(ctx, "sleep", "30").Run()
+	if err == nil {
+		t.Fatal("Expected the command to be interrupted")
+	}
+	if elapsed := time.Since(start); elapsed > waitDelay+time.Second {
+		t.Errorf("Cancelled command took %s to return", elapsed)
+	}
+	if !errors.Is(contextError(ctx, err), context.DeadlineExceeded) {
+		t.Errorf("contextError() = %v, want deadline exceeded", contextError(ctx, err))
+	}
+}
+
+func TestContextError(t *testing.T) {
+	processErr := errors.New("exit status 1")
+
+	if err := contextError(context.Background(), processErr); err != processErr {
+		t.Errorf("contextError() with a live context = %v, want %v", err, processErr)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := contextError(ctx, processErr); !errors.Is(err, context.Canceled) {
+		t.Errorf("contextError() with a cancelled context = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestCommandError(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh command not found, skipping test")
+	}
+
+	_, err := command(context.Background(), "sh", "-c", "echo first >&2; echo 'error: context not found' >&2; exit 1").Output()
+	if got := commandError(context.Background(), err); got == nil || got.Error() != "error: context not found" {
+		t.Errorf("commandError() = %v, want the last stderr line", got)
+	}
+
+	_, err = command(context.Background(), "sh", "-c", "exit 1").Output()
+	if got := commandError(context.Background(), err); got != err {
+		t.Errorf("commandError() without stderr = %v, want %v", got, err)
+	}
+}
@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// noneTag is what runtimes print for a missing repository or tag
+const noneTag = "<none>"
+
+// imageFormat prints repository, tag, ID, size and age of every image. docker,
+// podman and nerdctl understand the same fields.
+const imageFormat = "{{.Repository}}\t{{.Tag}}\t{{.ID}}\t{{.Size}}\t{{.CreatedSince}}"
+
+// Image is an image in the local store of the container runtime
+type Image struct {
+	Repository string `json:"repository" yaml:"repository"`
+	Tag        string `json:"tag" yaml:"tag"`
+	ID         string `json:"id" yaml:"id"`
+	Size       string `json:"size" yaml:"size"`
+	Created    string `json:"created" yaml:"created"`
+}
+
+// Ref returns the name the image is loaded by, e.g. "nginx:1.27"
+func (i Image) Ref() string {
+	if i.Tag == "" || i.Tag == noneTag {
+		return i.Repository
+	}
+	return i.Repository + ":" + i.Tag
+}
+
+// GetImages lists the tagged images of the active container runtime
+func GetImages(ctx context.Context) ([]Image, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	// Full IDs can be compared with the IDs containerd reports on the nodes
+	output, err := command(ctx, ActiveProvider, "images", "--no-trunc", "--format", imageFormat).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", contextError(ctx, err))
+	}
+	return ParseImages(string(output)), nil
+}
+
+// ParseImages parses tab separated image lines. Dangling images without a
+// repository are skipped since they cannot be loaded by name.
+func ParseImages(output string) []Image {
+	images := make([]Image, 0)
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) < 3 || fields[0] == "" || fields[0] == noneTag {
+			continue
+		}
+
+		image := Image{Repository: fields[0], Tag: fields[1], ID: fields[2]}
+		if len(fields) > 3 {
+			image.Size = fields[3]
+		}
+		if len(fields) > 4 {
+			image.Created = fields[4]
+		}
+		images = append(images, image)
+	}
+	return images
+}
+
+// LoadImageArchive loads image tarballs, e.g. written by docker save in CI, into
+// a KIND cluster or the given nodes of it
+func LoadImageArchive(ctx context.Context, archives []string, clusterName string, nodes []string, out io.Writer) error {
+	if len(archives) == 0 {
+		return fmt.Errorf("failed to load archive: no archive given")
+	}
+	for _, archive := range archives {
+		info, err := os.Stat(archive)
+		if err != nil {
+			return fmt.Errorf("failed to load archive: %w", err)
+		}
+		if info.IsDir() {
+			return fmt.Errorf("failed to load archive: %s is a directory", archive)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, LoadTimeout)
+	defer cancel()
+
+	// kind load image-archive takes a single archive
+	for _, archive := range archives {
+		if output, err := loadImageArchive(ctx, archive, clusterName, nodes, out); err != nil {
+			return fmt.Errorf("failed to load archive %s: %w\n%s", archive, err, string(output))
+		}
+	}
+	return nil
+}
+
+// BuildOptions describes an image built from a Dockerfile
+type BuildOptions struct {
+	Context    string // build context directory
+	Dockerfile string // defaults to the Dockerfile in Context
+	Tag        string // the name the image is built and loaded as
+}
+
+// ParseBuildSpec parses "CONTEXT TAG [DOCKERFILE]" as typed into the build prompt
+func ParseBuildSpec(spec string) (BuildOptions, error) {
+	fields := strings.Fields(spec)
+	if len(fields) < 2 || len(fields) > 3 {
+		return BuildOptions{}, fmt.Errorf("expected a build context and an image tag, e.g. ./app myapp:dev")
+	}
+	opts := BuildOptions{Context: fields[0], Tag: fields[1]}
+	if len(fields) == 3 {
+		opts.Dockerfile = fields[2]
+	}
+	return opts, nil
+}
+
+// BuildArgs returns the runtime arguments that build the image
+func BuildArgs(opts BuildOptions) ([]string, error) {
+	if opts.Tag == "" {
+		return nil, fmt.Errorf("an image tag is required")
+	}
+	if opts.Context == "" {
+		opts.Context = "."
+	}
+	info, err := os.Stat(opts.Context)
+	if err != nil {
+		return nil, fmt.Errorf("invalid build context: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("invalid build context: %s is not a directory", opts.Context)
+	}
+
+	dockerfile := opts.Dockerfile
+	if dockerfile == "" {
+		dockerfile = filepath.Join(opts.Context, "Dockerfile")
+	}
+	if _, err := os.Stat(dockerfile); err != nil {
+		return nil, fmt.Errorf("invalid Dockerfile: %w", err)
+	}
+
+	args := []string{"build", "-t", opts.Tag}
+	if opts.Dockerfile != "" {
+		args = append(args, "-f", opts.Dockerfile)
+	}
+	return append(args, opts.Context), nil
+}
+
+// BuildAndLoadImage builds an image with the active container runtime and
+// loads it into a KIND cluster or the given nodes of it, streaming the build
+// output to out
+func BuildAndLoadImage(ctx context.Context, opts BuildOptions, clusterName string, nodes []string, out io.Writer) error {
+	args, err := BuildArgs(opts)
+	if err != nil {
+		return fmt.Errorf("failed to build image: %w", err)
+	}
+
+	buildCtx, cancel := context.WithTimeout(ctx, BuildTimeout)
+	defer cancel()
+
+	step(out, "•", fmt.Sprintf("Building image %s ...", opts.Tag))
+	if output, err := run(buildCtx, out, ActiveProvider, args...); err != nil {
+		step(out, "✗", "Building image "+opts.Tag)
+		return fmt.Errorf("failed to build image: %w\n%s", err, string(output))
+	}
+	step(out, "✓", "Building image "+opts.Tag)
+
+	step(out, "•", fmt.Sprintf("Loading image %s ...", opts.Tag))
+	return LoadDockerImage(ctx, []string{opts.Tag}, clusterName, nodes, out)
+}
+
+// States of an image in a cluster compared with the local image store
+const (
+	ImageCurrent  = "current"   // every node has the local image
+	ImageStale    = "stale"     // a node has an older or different image under the same name
+	ImageMissing  = "missing"   // some or all nodes do not have the local image
+	ImageNotLocal = "not local" // there is no local image to compare with or load
+)
+
+// ClusterImage is an image found on the nodes of a cluster
+type ClusterImage struct {
+	Ref      string            `json:"ref" yaml:"ref"`                               // normalized, e.g. "docker.io/library/nginx:1.27"
+	LocalRef string            `json:"localRef,omitempty" yaml:"localRef,omitempty"` // the name it is loaded by
+	LocalID  string            `json:"localID,omitempty" yaml:"localID,omitempty"`
+	NodeIDs  map[string]string `json:"nodeIDs" yaml:"nodeIDs"` // node name to image ID
+	Missing  []string          `json:"missing,omitempty" yaml:"missing,omitempty"`
+	Status   string            `json:"status" yaml:"status"`
+}
+
+// Outdated reports whether the cluster holds the image but not the local
+// version on every node. Local images no node has are not outdated, only
+// missing.
+func (i ClusterImage) Outdated() bool {
+	return i.Status == ImageStale || (i.Status == ImageMissing && len(i.NodeIDs) > 0)
+}
+
+// criImages is the output of crictl images -o json
+type criImages struct {
+	Images []struct {
+		ID       string   `json:"id"`
+		RepoTags []string `json:"repoTags"`
+	} `json:"images"`
+}
+
+// GetClusterImages lists the images in each node's containerd and compares them
+// with the local image store
+func GetClusterImages(ctx context.Context, clusterName string) ([]ClusterImage, error) {
+	nodes, err := registryNodes(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	nodeImages := make(map[string]map[string]string, len(nodes))
+	for _, node := range nodes {
+		output, err := command(ctx, ActiveProvider, "exec", node, "crictl", "images", "-o", "json").Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list images on %s: %w", node, contextError(ctx, err))
+		}
+		if nodeImages[node], err = ParseCRIImages(output); err != nil {
+			return nil, fmt.Errorf("failed to list images on %s: %w", node, err)
+		}
+	}
+
+	local, err := GetImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return CompareImages(nodes, nodeImages, local), nil
+}
+
+// ParseCRIImages maps the normalized tags of crictl images -o json output to image IDs
+func ParseCRIImages(data []byte) (map[string]string, error) {
+	var list criImages
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse crictl output: %w", err)
+	}
+	images := make(map[string]string)
+	for _, image := range list.Images {
+		for _, tag := range image.RepoTags {
+			images[NormalizeImageRef(tag)] = image.ID
+		}
+	}
+	return images, nil
+}
+
+// CompareImages lists every image found on the nodes and every local image,
+// sorted by name, with its state compared with the local images. Local images
+// no node has are missing on all of them.
+func CompareImages(nodes []string, nodeImages map[string]map[string]string, local []Image) []ClusterImage {
+	localByRef := make(map[string]Image, len(local))
+	for _, image := range local {
+		localByRef[NormalizeImageRef(image.Ref())] = image
+	}
+
+	byRef := make(map[string]*ClusterImage)
+	for _, node := range nodes {
+		for ref, id := range nodeImages[node] {
+			image, ok := byRef[ref]
+			if !ok {
+				image = &ClusterImage{Ref: ref, NodeIDs: map[string]string{}}
+				if l, found := localByRef[ref]; found {
+					image.LocalRef, image.LocalID = l.Ref(), l.ID
+				}
+				byRef[ref] = image
+			}
+			image.NodeIDs[node] = id
+		}
+	}
+
+	for ref, l := range localByRef {
+		if _, ok := byRef[ref]; !ok {
+			byRef[ref] = &ClusterImage{Ref: ref, LocalRef: l.Ref(), LocalID: l.ID, NodeIDs: map[string]string{}}
+		}
+	}
+
+	images := make([]ClusterImage, 0, len(byRef))
+	for _, image := range byRef {
+		for _, node := range nodes {
+			if _, ok := image.NodeIDs[node]; !ok {
+				image.Missing = append(image.Missing, node)
+			}
+		}
+		image.Status = imageStatus(*image)
+		images = append(images, *image)
+	}
+	sort.Slice(images, func(i, j int) bool { return images[i].Ref < images[j].Ref })
+	return images
+}
+
+func imageStatus(image ClusterImage) string {
+	if image.LocalID == "" {
+		return ImageNotLocal
+	}
+	for _, id := range image.NodeIDs {
+		if trimDigest(id) != trimDigest(image.LocalID) {
+			return ImageStale
+		}
+	}
+	if len(image.Missing) > 0 {
+		return ImageMissing
+	}
+	return ImageCurrent
+}
+
+// NormalizeImageRef expands a reference the way containerd names images, e.g.
+// "nginx" becomes "docker.io/library/nginx:latest"
+func NormalizeImageRef(ref string) string {
+	name, tag := ref, "latest"
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		name, tag = ref[:i], ref[i+1:]
+	}
+
+	domain, _, found := strings.Cut(name, "/")
+	switch {
+	case !found:
+		name = "docker.io/library/" + name
+	case !strings.ContainsAny(domain, ".:") && domain != "localhost":
+		name = "docker.io/" + name
+	}
+	return name + ":" + tag
+}
+
+func trimDigest(id string) string {
+	return strings.TrimPrefix(id, "sha256:")
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParseImages(t *testing.T) {
+	output := "nginx\t1.27\ta1b2c3d4e5f6\t192MB\t2 weeks ago\n" +
+		"<none>\t<none>\t0123456789ab\t80MB\t3 days ago\n" +
+		"localhost/myapp\t<none>\td4e5f6a7b8c9\t25MB\t5 minutes ago\n" +
+		"\n"
+
+	images := ParseImages(output)
+	if len(images) != 2 {
+		t.Fatalf("ParseImages() returned %d images, expected 2 without the dangling one: %+v", len(images), images)
+	}
+
+	expected := Image{Repository: "nginx", Tag: "1.27", ID: "a1b2c3d4e5f6", Size: "192MB", Created: "2 weeks ago"}
+	if images[0] != expected {
+		t.Errorf("ParseImages()[0] = %+v, expected %+v", images[0], expected)
+	}
+	if ref := images[0].Ref(); ref != "nginx:1.27" {
+		t.Errorf("Ref() = %q, expected nginx:1.27", ref)
+	}
+	if ref := images[1].Ref(); ref != "localhost/myapp" {
+		t.Errorf("Ref() of an untagged image = %q, expected the repository", ref)
+	}
+
+	if images := ParseImages(""); len(images) != 0 {
+		t.Errorf("ParseImages(\"\") = %+v, expected none", images)
+	}
+}
+
+func TestNormalizeImageRef(t *testing.T) {
+	tests := map[string]string{
+		"nginx":                          "docker.io/library/nginx:latest",
+		"nginx:1.27":                     "docker.io/library/nginx:1.27",
+		"bitnami/redis:7":                "docker.io/bitnami/redis:7",
+		"registry.k8s.io/pause:3.9":      "registry.k8s.io/pause:3.9",
+		"localhost/myapp":                "localhost/myapp:latest",
+		"localhost:5001/myapp:dev":       "localhost:5001/myapp:dev",
+		"docker.io/library/nginx:latest": "docker.io/library/nginx:latest",
+	}
+	for ref, expected := range tests {
+		if got := NormalizeImageRef(ref); got != expected {
+			t.Errorf("NormalizeImageRef(%q) = %q, expected %q", ref, got, expected)
+		}
+	}
+}
+
+func TestParseCRIImages(t *testing.T) {
+	data := []byte(`{"images": [
+		{"id": "sha256:aaa", "repoTags": ["docker.io/library/nginx:1.27"], "repoDigests": [], "size": "1000"},
+		{"id": "sha256:bbb", "repoTags": ["registry.k8s.io/pause:3.9", "registry.k8s.io/pause:latest"]},
+		{"id": "sha256:ccc", "repoTags": []}
+	]}`)
+
+	images, err := ParseCRIImages(data)
+	if err != nil {
+		t.Fatalf("ParseCRIImages() error = %v", err)
+	}
+	if len(images) != 3 || images["docker.io/library/nginx:1.27"] != "sha256:aaa" || images["registry.k8s.io/pause:latest"] != "sha256:bbb" {
+		t.Errorf("ParseCRIImages() = %v", images)
+	}
+
+	if _, err := ParseCRIImages([]byte("crictl: command not found")); err == nil {
+		t.Error("ParseCRIImages() should fail on output that is not JSON")
+	}
+}
+
+func TestCompareImages(t *testing.T) {
+	nodes := []string{"dev-control-plane", "dev-worker"}
+	nodeImages := map[string]map[string]string{
+		"dev-control-plane": {
+			"docker.io/library/nginx:1.27": "sha256:new",
+			"docker.io/library/myapp:dev":  "sha256:old",
+			"registry.k8s.io/pause:3.9":    "sha256:pause",
+			"docker.io/library/redis:7":    "sha256:redis",
+		},
+		"dev-worker": {
+			"docker.io/library/nginx:1.27": "sha256:new",
+			"docker.io/library/myapp:dev":  "sha256:new-app",
+			"registry.k8s.io/pause:3.9":    "sha256:pause",
+		},
+	}
+	local := []Image{
+		{Repository: "nginx", Tag: "1.27", ID: "sha256:new"},
+		{Repository: "myapp", Tag: "dev", ID: "sha256:new-app"},
+		{Repository: "redis", Tag: "7", ID: "sha256:redis"},
+		{Repository: "unloaded", Tag: "latest", ID: "sha256:other"},
+	}
+
+	images := CompareImages(nodes, nodeImages, local)
+	status := make(map[string]ClusterImage)
+	for _, image := range images {
+		status[image.Ref] = image
+	}
+
+	if len(images) != 5 {
+		t.Fatalf("CompareImages() returned %d images, expected the 4 on the nodes and 1 only local: %+v", len(images), images)
+	}
+	if images[0].Ref != "docker.io/library/myapp:dev" {
+		t.Errorf("CompareImages() should sort by name, got %s first", images[0].Ref)
+	}
+
+	tests := []struct {
+		ref      string
+		expected string
+		outdated bool
+	}{
+		{"docker.io/library/nginx:1.27", ImageCurrent, false},
+		{"docker.io/library/myapp:dev", ImageStale, true},
+		{"docker.io/library/redis:7", ImageMissing, true},
+		{"registry.k8s.io/pause:3.9", ImageNotLocal, false},
+		{"docker.io/library/unloaded:latest", ImageMissing, false},
+	}
+	for _, tt := range tests {
+		image := status[tt.ref]
+		if image.Status != tt.expected || image.Outdated() != tt.outdated {
+			t.Errorf("%s: status %q (outdated %v), expected %q (outdated %v)", tt.ref, image.Status, image.Outdated(), tt.expected, tt.outdated)
+		}
+	}
+
+	redis := status["docker.io/library/redis:7"]
+	if redis.LocalRef != "redis:7" || len(redis.Missing) != 1 || redis.Missing[0] != "dev-worker" {
+		t.Errorf("redis should be loadable as redis:7 and missing on dev-worker, got %+v", redis)
+	}
+	unloaded := status["docker.io/library/unloaded:latest"]
+	if unloaded.LocalRef != "unloaded:latest" || len(unloaded.NodeIDs) != 0 || !slices.Equal(unloaded.Missing, nodes) {
+		t.Errorf("unloaded should be loadable as unloaded:latest and missing on every node, got %+v", unloaded)
+	}
+}
+
+func TestBuildArgs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM scratch\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	custom := filepath.Join(dir, "Dockerfile.dev")
+	if err := os.WriteFile(custom, []byte("FROM scratch\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	args, err := BuildArgs(BuildOptions{Context: dir, Tag: "myapp:dev"})
+	if err != nil || !slices.Equal(args, []string{"build", "-t", "myapp:dev", dir}) {
+		t.Errorf("BuildArgs() = %v, %v", args, err)
+	}
+
+	args, err = BuildArgs(BuildOptions{Context: dir, Dockerfile: custom, Tag: "myapp:dev"})
+	if err != nil || !slices.Equal(args, []string{"build", "-t", "myapp:dev", "-f", custom, dir}) {
+		t.Errorf("BuildArgs() with a Dockerfile = %v, %v", args, err)
+	}
+
+	invalid := map[string]BuildOptions{
+		"no tag":             {Context: dir},
+		"missing context":    {Context: filepath.Join(dir, "missing"), Tag: "myapp:dev"},
+		"context is a file":  {Context: custom, Tag: "myapp:dev"},
+		"missing Dockerfile": {Context: dir, Dockerfile: filepath.Join(dir, "Containerfile"), Tag: "myapp:dev"},
+	}
+	for name, opts := range invalid {
+		if _, err := BuildArgs(opts); err == nil {
+			t.Errorf("BuildArgs() should fail with %s", name)
+		}
+	}
+}
+
+func TestParseBuildSpec(t *testing.T) {
+	opts, err := ParseBuildSpec("  ./app   myapp:dev ")
+	if err != nil || opts != (BuildOptions{Context: "./app", Tag: "myapp:dev"}) {
+		t.Errorf("ParseBuildSpec() = %+v, %v", opts, err)
+	}
+	opts, err = ParseBuildSpec("./app myapp:dev ./app/Dockerfile.dev")
+	if err != nil || opts.Dockerfile != "./app/Dockerfile.dev" {
+		t.Errorf("ParseBuildSpec() with a Dockerfile = %+v, %v", opts, err)
+	}
+	for _, spec := range []string{"", "./app", "./app myapp:dev Dockerfile extra"} {
+		if _, err := ParseBuildSpec(spec); err == nil {
+			t.Errorf("ParseBuildSpec(%q) should fail", spec)
+		}
+	}
+}
+
+func TestLoadImageArchiveValidates(t *testing.T) {
+	dir := t.TempDir()
+	tests := map[string][]string{
+		"no archive":      nil,
+		"missing archive": {filepath.Join(dir, "missing.tar")},
+		"directory":       {dir},
+	}
+	for name, archives := range tests {
+		if err := LoadImageArchive(context.Background(), archives, "dev", nil, nil); err == nil {
+			t.Errorf("LoadImageArchive() should fail with %s", name)
+		}
+	}
+}
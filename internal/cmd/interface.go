@@ -1,51 +1,179 @@
 package cmd
 
+import (
+	"context"
+	"io"
+	"time"
+)
+
 // CommandInterface defines the interface for KIND operations
 // This allows for easy testing by providing mock implementations
+// Every operation is bound to ctx; long-running ones copy KIND's output to out
+// while they run
 type CommandInterface interface {
-	GetClusters() ([]Cluster, error)
-	GetClusterNodes(clusterName string) ([]Node, error)
-	GetClusterDetail(clusterName string) (Cluster, error)
-	CreateCluster(name string) error
-	DeleteCluster(name string) error
-	LoadDockerImage(imageName, clusterName string) error
-	BuildNodeImage(sourcePath string) error
-	ExportLogs(clusterName, outputPath string) error
+	GetClusters(ctx context.Context) ([]Cluster, error)
+	GetClusterNames(ctx context.Context) ([]string, error)
+	CachedCluster(name string) (Cluster, bool)
+	EnrichClusters(ctx context.Context, names []string, maxAge time.Duration, found func(Cluster))
+	GetClusterNodes(ctx context.Context, clusterName string) ([]Node, error)
+	GetClusterDetail(ctx context.Context, clusterName string) (Cluster, error)
+	GetPods(ctx context.Context, clusterName, namespace string) ([]Pod, error)
+	GetNodeDetail(ctx context.Context, clusterName, nodeName string) (*NodeDetail, error)
+	CreateCluster(ctx context.Context, config ClusterConfig, out io.Writer) error
+	DeleteCluster(ctx context.Context, name string, out io.Writer) error
+	StopCluster(ctx context.Context, name string, out io.Writer) error
+	StartCluster(ctx context.Context, name string, out io.Writer) error
+	RestartCluster(ctx context.Context, name string, out io.Writer) error
+	AddWorker(ctx context.Context, clusterName string, out io.Writer) (string, error)
+	RemoveWorker(ctx context.Context, clusterName, node string, out io.Writer) error
+	GetImages(ctx context.Context) ([]Image, error)
+	GetClusterImages(ctx context.Context, clusterName string) ([]ClusterImage, error)
+	LoadDockerImage(ctx context.Context, images []string, clusterName string, nodes []string, out io.Writer) error
+	LoadImageArchive(ctx context.Context, archives []string, clusterName string, nodes []string, out io.Writer) error
+	BuildAndLoadImage(ctx context.Context, opts BuildOptions, clusterName string, nodes []string, out io.Writer) error
+	BuildNodeImage(ctx context.Context, sourcePath string, out io.Writer) error
+	ExportLogs(ctx context.Context, clusterName, outputPath string, out io.Writer) error
+	StreamLogs(ctx context.Context, source LogSource, opts LogOptions, out io.Writer) error
+	CreateRegistry(ctx context.Context, out io.Writer) error
+	AttachRegistry(ctx context.Context, clusterName string, out io.Writer) error
+	DetachRegistry(ctx context.Context, clusterName string, out io.Writer) error
+	GetKubeconfig(ctx context.Context, clusterName string, internal bool) (string, error)
+	ExportKubeconfig(ctx context.Context, clusterName, path string, internal bool) error
+	MergeKubeconfig(ctx context.Context, clusterName string) error
+	RemoveKubeconfig(ctx context.Context, clusterName string) error
+	UseKubeContext(ctx context.Context, clusterName string) error
+	CopyToClipboard(ctx context.Context, text string) error
 }
 
 // DefaultCommands implements CommandInterface using the actual KIND commands
 type DefaultCommands struct{}
 
-func (d DefaultCommands) GetClusters() ([]Cluster, error) {
-	return GetClusters()
+func (d DefaultCommands) GetClusters(ctx context.Context) ([]Cluster, error) {
+	return GetClusters(ctx)
+}
+
+func (d DefaultCommands) GetClusterNames(ctx context.Context) ([]string, error) {
+	return GetClusterNames(ctx)
+}
+
+func (d DefaultCommands) CachedCluster(name string) (Cluster, bool) {
+	return CachedCluster(name)
+}
+
+func (d DefaultCommands) EnrichClusters(ctx context.Context, names []string, maxAge time.Duration, found func(Cluster)) {
+	EnrichClusters(ctx, names, maxAge, found)
+}
+
+func (d DefaultCommands) GetClusterNodes(ctx context.Context, clusterName string) ([]Node, error) {
+	return GetClusterNodes(ctx, clusterName)
+}
+
+func (d DefaultCommands) GetClusterDetail(ctx context.Context, clusterName string) (Cluster, error) {
+	return GetClusterDetail(ctx, clusterName)
+}
+
+func (d DefaultCommands) GetPods(ctx context.Context, clusterName, namespace string) ([]Pod, error) {
+	return GetPods(ctx, clusterName, namespace)
+}
+
+func (d DefaultCommands) GetNodeDetail(ctx context.Context, clusterName, nodeName string) (*NodeDetail, error) {
+	return GetNodeDetail(ctx, clusterName, nodeName)
+}
+
+func (d DefaultCommands) CreateCluster(ctx context.Context, config ClusterConfig, out io.Writer) error {
+	return CreateCluster(ctx, config, out)
+}
+
+func (d DefaultCommands) DeleteCluster(ctx context.Context, name string, out io.Writer) error {
+	return DeleteCluster(ctx, name, out)
+}
+
+func (d DefaultCommands) StopCluster(ctx context.Context, name string, out io.Writer) error {
+	return StopCluster(ctx, name, out)
+}
+
+func (d DefaultCommands) StartCluster(ctx context.Context, name string, out io.Writer) error {
+	return StartCluster(ctx, name, out)
+}
+
+func (d DefaultCommands) RestartCluster(ctx context.Context, name string, out io.Writer) error {
+	return RestartCluster(ctx, name, out)
+}
+
+func (d DefaultCommands) AddWorker(ctx context.Context, clusterName string, out io.Writer) (string, error) {
+	return AddWorker(ctx, clusterName, out)
+}
+
+func (d DefaultCommands) RemoveWorker(ctx context.Context, clusterName, node string, out io.Writer) error {
+	return RemoveWorker(ctx, clusterName, node, out)
+}
+
+func (d DefaultCommands) GetImages(ctx context.Context) ([]Image, error) {
+	return GetImages(ctx)
+}
+
+func (d DefaultCommands) GetClusterImages(ctx context.Context, clusterName string) ([]ClusterImage, error) {
+	return GetClusterImages(ctx, clusterName)
+}
+
+func (d DefaultCommands) LoadDockerImage(ctx context.Context, images []string, clusterName string, nodes []string, out io.Writer) error {
+	return LoadDockerImage(ctx, images, clusterName, nodes, out)
+}
+
+func (d DefaultCommands) LoadImageArchive(ctx context.Context, archives []string, clusterName string, nodes []string, out io.Writer) error {
+	return LoadImageArchive(ctx, archives, clusterName, nodes, out)
+}
+
+func (d DefaultCommands) BuildAndLoadImage(ctx context.Context, opts BuildOptions, clusterName string, nodes []string, out io.Writer) error {
+	return BuildAndLoadImage(ctx, opts, clusterName, nodes, out)
+}
+
+func (d DefaultCommands) BuildNodeImage(ctx context.Context, sourcePath string, out io.Writer) error {
+	return BuildNodeImage(ctx, sourcePath, out)
+}
+
+func (d DefaultCommands) ExportLogs(ctx context.Context, clusterName, outputPath string, out io.Writer) error {
+	return ExportLogs(ctx, clusterName, outputPath, out)
+}
+
+func (d DefaultCommands) StreamLogs(ctx context.Context, source LogSource, opts LogOptions, out io.Writer) error {
+	return StreamLogs(ctx, source, opts, out)
+}
+
+func (d DefaultCommands) CreateRegistry(ctx context.Context, out io.Writer) error {
+	return CreateRegistry(ctx, out)
+}
+
+func (d DefaultCommands) AttachRegistry(ctx context.Context, clusterName string, out io.Writer) error {
+	return AttachRegistry(ctx, clusterName, out)
 }
 
-func (d DefaultCommands) GetClusterNodes(clusterName string) ([]Node, error) {
-	return GetClusterNodes(clusterName)
+func (d DefaultCommands) DetachRegistry(ctx context.Context, clusterName string, out io.Writer) error {
+	return DetachRegistry(ctx, clusterName, out)
 }
 
-func (d DefaultCommands) GetClusterDetail(clusterName string) (Cluster, error) {
-	return GetClusterDetail(clusterName)
+func (d DefaultCommands) GetKubeconfig(ctx context.Context, clusterName string, internal bool) (string, error) {
+	return GetKubeconfig(ctx, clusterName, internal)
 }
 
-func (d DefaultCommands) CreateCluster(name string) error {
-	return CreateCluster(name)
+func (d DefaultCommands) ExportKubeconfig(ctx context.Context, clusterName, path string, internal bool) error {
+	return ExportKubeconfig(ctx, clusterName, path, internal)
 }
 
-func (d DefaultCommands) DeleteCluster(name string) error {
-	return DeleteCluster(name)
+func (d DefaultCommands) MergeKubeconfig(ctx context.Context, clusterName string) error {
+	return MergeKubeconfig(ctx, clusterName)
 }
 
-func (d DefaultCommands) LoadDockerImage(imageName, clusterName string) error {
-	return LoadDockerImage(imageName, clusterName)
+func (d DefaultCommands) RemoveKubeconfig(ctx context.Context, clusterName string) error {
+	return RemoveKubeconfig(ctx, clusterName)
 }
 
-func (d DefaultCommands) BuildNodeImage(sourcePath string) error {
-	return BuildNodeImage(sourcePath)
+func (d DefaultCommands) UseKubeContext(ctx context.Context, clusterName string) error {
+	return UseKubeContext(ctx, clusterName)
 }
 
-func (d DefaultCommands) ExportLogs(clusterName, outputPath string) error {
-	return ExportLogs(clusterName, outputPath)
+func (d DefaultCommands) CopyToClipboard(ctx context.Context, text string) error {
+	return CopyToClipboard(ctx, text)
 }
 
 // Global instance that can be replaced for testing
@@ -1,69 +1,86 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"strings"
 )
 
 // Cluster represents a KIND cluster
 type Cluster struct {
-	Name        string
-	Status      string
-	Nodes       []Node
-	KubeVersion string
-}
-
-// Node represents a Kubernetes node
-type Node struct {
-	Name       string
-	Role       string
-	Status     string
-	Age        string
-	Version    string
-	InternalIP string
+	Name        string      `json:"name" yaml:"name"`
+	Status      string      `json:"status" yaml:"status"`
+	Nodes       []Node      `json:"nodes" yaml:"nodes"`
+	Containers  []Container `json:"containers,omitempty" yaml:"containers,omitempty"`
+	KubeVersion string      `json:"kubeVersion,omitempty" yaml:"kubeVersion,omitempty"`
+	Registry    string      `json:"registry,omitempty" yaml:"registry,omitempty"` // host of the attached local registry
+	Error       string      `json:"error,omitempty" yaml:"error,omitempty"`       // why the status or nodes could not be read
 }
 
 // GetClusters retrieves all KIND clusters
-func GetClusters() ([]Cluster, error) {
-	cmd := exec.Command("kind", "get", "clusters")
-	output, err := cmd.Output()
+func GetClusters(ctx context.Context) ([]Cluster, error) {
+	names, err := GetClusterNames(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get clusters: %w", err)
+		return nil, err
 	}
 
-	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
-	clusters := make([]Cluster, 0)
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line != "" {
-			cluster := Cluster{
-				Name:   line,
-				Status: "running",
-			}
-			cluster = EnrichClusterInfo(cluster)
-			clusters = append(clusters, cluster)
-		}
+	index := make(map[string]int, len(names))
+	for i, name := range names {
+		index[name] = i
+	}
+	clusters := make([]Cluster, len(names))
+	EnrichClusters(ctx, names, EnrichTTL, func(c Cluster) {
+		clusters[index[c.Name]] = c
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get clusters: %w", err)
 	}
 
 	return clusters, nil
 }
 
-// EnrichClusterInfo adds additional information to a cluster
-func EnrichClusterInfo(c Cluster) Cluster {
-	contextName := "kind-" + c.Name
-	if c.Name == "kind" {
-		contextName = "kind"
-	}
+// GetClusterNames lists the names of the KIND clusters without querying them
+func GetClusterNames(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
 
-	cmd := exec.Command("kubectl", "get", "nodes", "--context", contextName, "-o", "wide", "--no-headers")
+	cmd := kindCommand(ctx, "get", "clusters")
 	output, err := cmd.Output()
 	if err != nil {
+		return nil, fmt.Errorf("failed to get clusters: %w", contextError(ctx, err))
+	}
+
+	names := make([]string, 0)
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
+
+// EnrichClusterInfo adds the status and nodes to a cluster. When they cannot
+// be read the cluster keeps what is known and Error says why.
+func EnrichClusterInfo(ctx context.Context, c Cluster) Cluster {
+	var err error
+	c.Containers, c.Status, err = clusterStatus(ctx, c.Name)
+	if err != nil {
+		c.Error = err.Error()
+		return c
+	}
+	if c.Status == StatusStopped {
 		return c
 	}
 
-	nodes := ParseNodes(string(output))
+	nodes, err := GetClusterNodes(ctx, c.Name)
+	if err != nil {
+		c.Error = err.Error()
+		return c
+	}
 	c.Nodes = nodes
 
 	if len(nodes) > 0 {
@@ -73,82 +90,122 @@ func EnrichClusterInfo(c Cluster) Cluster {
 	return c
 }
 
-// ParseNodes parses kubectl node output
-func ParseNodes(output string) []Node {
-	lines := strings.Split(strings.TrimSpace(output), "\n")
-	nodes := make([]Node, 0)
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-
-		fields := strings.Fields(line)
-		if len(fields) >= 6 {
-			role := "worker"
-			if strings.Contains(line, "control-plane") || strings.Contains(line, "master") {
-				role = "control-plane"
-			}
-
-			node := Node{
-				Name:       fields[0],
-				Status:     fields[1],
-				Role:       role,
-				Age:        fields[3],
-				Version:    fields[4],
-				InternalIP: fields[5],
-			}
-			nodes = append(nodes, node)
-		}
+// GetClusterDetail retrieves detailed information about a cluster
+func GetClusterDetail(ctx context.Context, clusterName string) (Cluster, error) {
+	cluster := EnrichClusterInfo(ctx, Cluster{Name: clusterName})
+	cacheCluster(cluster)
+	if cluster.Status == StatusRunning {
+		cluster.Registry = ClusterRegistry(ctx, clusterName)
 	}
+	return cluster, nil
+}
 
-	return nodes
+// kubeContext returns the kubeconfig context KIND creates for a cluster
+func kubeContext(clusterName string) string {
+	return "kind-" + clusterName
 }
 
-// GetClusterNodes retrieves nodes for a specific cluster
-func GetClusterNodes(clusterName string) ([]Node, error) {
-	contextName := "kind-" + clusterName
-	if clusterName == "kind" {
-		contextName = "kind"
+// CreateCluster creates a new KIND cluster from the given config. If ctx is
+// cancelled while KIND is running, the partially created cluster is deleted.
+func CreateCluster(ctx context.Context, config ClusterConfig, out io.Writer) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("failed to create cluster: %w", err)
 	}
 
-	cmd := exec.Command("kubectl", "get", "nodes", "--context", contextName, "-o", "wide", "--no-headers")
-	output, err := cmd.Output()
+	name := config.Name
+	if name == "" {
+		name = "kind"
+	}
+	defer forgetCluster(name)
+	args := []string{"create", "cluster", "--name", name}
+
+	configPath, err := writeClusterConfig(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get nodes: %w", err)
+		return fmt.Errorf("failed to create cluster: %w", err)
+	}
+	defer os.Remove(configPath)
+	args = append(args, "--config", configPath)
+
+	// A cluster of the same name may have existed, with other credentials
+	forgetKubeconfig(name)
+
+	createCtx, cancel := context.WithTimeout(ctx, CreateTimeout)
+	defer cancel()
+
+	output, err := runKind(createCtx, out, args...)
+	if err != nil && createCtx.Err() != nil {
+		// KIND leaves the node containers behind when interrupted
+		cleanupCtx, cleanupCancel := context.WithTimeout(context.Background(), DeleteTimeout)
+		defer cleanupCancel()
+		if _, cleanupErr := runKind(cleanupCtx, out, "delete", "cluster", "--name", name); cleanupErr != nil {
+			return fmt.Errorf("failed to create cluster: %w (cleanup of %s failed: %v)", createCtx.Err(), name, cleanupErr)
+		}
+		return fmt.Errorf("failed to create cluster: %w", createCtx.Err())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create cluster: %w\n%s", err, string(output))
 	}
 
-	return ParseNodes(string(output)), nil
+	if config.Registry {
+		return AttachRegistry(ctx, name, out)
+	}
+	return nil
 }
 
-// GetClusterDetail retrieves detailed information about a cluster
-func GetClusterDetail(clusterName string) (Cluster, error) {
-	cluster := Cluster{Name: clusterName, Status: "running"}
-	cluster = EnrichClusterInfo(cluster)
-	return cluster, nil
+// runKind runs kind and returns its combined output. When out is not nil the
+// output is also copied to it while kind is running.
+func runKind(ctx context.Context, out io.Writer, args ...string) ([]byte, error) {
+	return runCommand(ctx, kindCommand(ctx, args...), out)
+}
+
+// run runs a command and returns its combined output, copying it to out when
+// out is not nil
+func run(ctx context.Context, out io.Writer, name string, args ...string) ([]byte, error) {
+	return runCommand(ctx, command(ctx, name, args...), out)
 }
 
-// CreateCluster creates a new KIND cluster
-func CreateCluster(name string) error {
-	args := []string{"create", "cluster"}
-	if name != "" {
-		args = append(args, "--name", name)
+func runCommand(ctx context.Context, cmd *exec.Cmd, out io.Writer) ([]byte, error) {
+	var output bytes.Buffer
+	w := io.Writer(&output)
+	if out != nil {
+		w = io.MultiWriter(&output, out)
 	}
 
-	cmd := exec.Command("kind", args...)
-	output, err := cmd.CombinedOutput()
+	cmd.Stdout = w
+	cmd.Stderr = w
+	err := cmd.Run()
+
+	return output.Bytes(), contextError(ctx, err)
+}
+
+// writeClusterConfig renders the config into a temporary file and returns its path
+func writeClusterConfig(config ClusterConfig) (string, error) {
+	data, err := RenderClusterConfig(config)
 	if err != nil {
-		return fmt.Errorf("failed to create cluster: %w\n%s", err, string(output))
+		return "", err
 	}
 
-	return nil
+	f, err := os.CreateTemp("", "ki-cluster-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to write cluster config: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(data); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write cluster config: %w", err)
+	}
+	return f.Name(), nil
 }
 
 // DeleteCluster deletes a KIND cluster
-func DeleteCluster(name string) error {
-	cmd := exec.Command("kind", "delete", "cluster", "--name", name)
-	output, err := cmd.CombinedOutput()
+func DeleteCluster(ctx context.Context, name string, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, DeleteTimeout)
+	defer cancel()
+
+	defer forgetCluster(name)
+	forgetKubeconfig(name)
+	output, err := runKind(ctx, out, "delete", "cluster", "--name", name)
 	if err != nil {
 		return fmt.Errorf("failed to delete cluster: %w\n%s", err, string(output))
 	}
@@ -156,31 +213,45 @@ func DeleteCluster(name string) error {
 	return nil
 }
 
-// LoadDockerImage loads a Docker image into a KIND cluster
-func LoadDockerImage(imageName, clusterName string) error {
-	args := []string{"load", "docker-image", imageName}
-	if clusterName != "" {
-		args = append(args, "--name", clusterName)
+// LoadDockerImage loads one or more Docker images into a KIND cluster, or only
+// into the given nodes of it. Providers other than docker go through image
+// archives since kind load docker-image needs docker.
+func LoadDockerImage(ctx context.Context, images []string, clusterName string, nodes []string, out io.Writer) error {
+	if len(images) == 0 {
+		return fmt.Errorf("failed to load image: no image given")
 	}
 
-	cmd := exec.Command("kind", args...)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to load image: %w\n%s", err, string(output))
+	ctx, cancel := context.WithTimeout(ctx, LoadTimeout)
+	defer cancel()
+
+	if ActiveProvider == ProviderDocker {
+		args := append([]string{"load", "docker-image"}, images...)
+		args = append(args, loadTargetArgs(clusterName, nodes)...)
+		if output, err := runKind(ctx, out, args...); err != nil {
+			return fmt.Errorf("failed to load image: %w\n%s", err, string(output))
+		}
+		return nil
 	}
 
+	for _, image := range images {
+		if output, err := loadImageViaArchive(ctx, image, clusterName, nodes, out); err != nil {
+			return fmt.Errorf("failed to load image %s: %w\n%s", image, err, string(output))
+		}
+	}
 	return nil
 }
 
 // BuildNodeImage builds a KIND node image from source
-func BuildNodeImage(sourcePath string) error {
+func BuildNodeImage(ctx context.Context, sourcePath string, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, BuildTimeout)
+	defer cancel()
+
 	args := []string{"build", "node-image"}
 	if sourcePath != "" {
 		args = append(args, sourcePath)
 	}
 
-	cmd := exec.Command("kind", args...)
-	output, err := cmd.CombinedOutput()
+	output, err := runKind(ctx, out, args...)
 	if err != nil {
 		return fmt.Errorf("failed to build node image: %w\n%s", err, string(output))
 	}
@@ -189,7 +260,10 @@ func BuildNodeImage(sourcePath string) error {
 }
 
 // ExportLogs exports cluster logs
-func ExportLogs(clusterName, outputPath string) error {
+func ExportLogs(ctx context.Context, clusterName, outputPath string, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, ExportTimeout)
+	defer cancel()
+
 	args := []string{"export", "logs"}
 	if clusterName != "" {
 		args = append(args, "--name", clusterName)
@@ -198,11 +272,10 @@ func ExportLogs(clusterName, outputPath string) error {
 		args = append(args, outputPath)
 	}
 
-	cmd := exec.Command("kind", args...)
-	output, err := cmd.CombinedOutput()
+	output, err := runKind(ctx, out, args...)
 	if err != nil {
 		return fmt.Errorf("failed to export logs: %w\n%s", err, string(output))
 	}
 
 	return nil
-}
\ No newline at end of file
+}
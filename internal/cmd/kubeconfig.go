@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kubeconfig is a kubeconfig file. Fields ki does not use are kept as they are.
+type Kubeconfig struct {
+	APIVersion     string            `yaml:"apiVersion"`
+	Clusters       []KubeconfigEntry `yaml:"clusters"`
+	Contexts       []KubeconfigEntry `yaml:"contexts"`
+	CurrentContext string            `yaml:"current-context"`
+	Kind           string            `yaml:"kind"`
+	Preferences    map[string]any    `yaml:"preferences"`
+	Users          []KubeconfigEntry `yaml:"users"`
+	Extra          map[string]any    `yaml:",inline"`
+}
+
+// KubeconfigEntry is a named cluster, context or user of a kubeconfig
+type KubeconfigEntry struct {
+	Name  string         `yaml:"name"`
+	Extra map[string]any `yaml:",inline"`
+}
+
+// ParseKubeconfig decodes a kubeconfig. Empty data is an empty kubeconfig.
+func ParseKubeconfig(data []byte) (Kubeconfig, error) {
+	config := Kubeconfig{APIVersion: "v1", Kind: "Config"}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return Kubeconfig{}, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	if config.Preferences == nil {
+		config.Preferences = map[string]any{}
+	}
+	return config, nil
+}
+
+// Merge adds the clusters, contexts and users of other, replacing entries
+// with the same name. The current context is kept.
+func (k *Kubeconfig) Merge(other Kubeconfig) {
+	k.Clusters = mergeEntries(k.Clusters, other.Clusters)
+	k.Contexts = mergeEntries(k.Contexts, other.Contexts)
+	k.Users = mergeEntries(k.Users, other.Users)
+}
+
+// Remove deletes the cluster, context and user called name and reports whether
+// there was anything to delete. The current context is cleared when it is removed.
+func (k *Kubeconfig) Remove(name string) bool {
+	var removed bool
+	k.Clusters, removed = removeEntry(k.Clusters, name, removed)
+	k.Contexts, removed = removeEntry(k.Contexts, name, removed)
+	k.Users, removed = removeEntry(k.Users, name, removed)
+	if k.CurrentContext == name {
+		k.CurrentContext = ""
+	}
+	return removed
+}
+
+// HasContext reports whether the kubeconfig has a context called name
+func (k Kubeconfig) HasContext(name string) bool {
+	for _, entry := range k.Contexts {
+		if entry.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeEntries(entries, add []KubeconfigEntry) []KubeconfigEntry {
+	for _, entry := range add {
+		replaced := false
+		for i := range entries {
+			if entries[i].Name == entry.Name {
+				entries[i] = entry
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func removeEntry(entries []KubeconfigEntry, name string, removed bool) ([]KubeconfigEntry, bool) {
+	kept := entries[:0]
+	for _, entry := range entries {
+		if entry.Name == name {
+			removed = true
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	return kept, removed
+}
+
+// KubeconfigPath returns the kubeconfig kubectl uses by default: the first
+// file in KUBECONFIG, or ~/.kube/config
+func KubeconfigPath() string {
+	if paths := filepath.SplitList(os.Getenv("KUBECONFIG")); len(paths) > 0 && paths[0] != "" {
+		return paths[0]
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".kube", "config")
+}
+
+// GetKubeconfig returns the kubeconfig of a cluster. The internal one reaches
+// the API server from inside the cluster's network, e.g. from other containers.
+func GetKubeconfig(ctx context.Context, clusterName string, internal bool) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	args := []string{"get", "kubeconfig", "--name", clusterName}
+	if internal {
+		args = append(args, "--internal")
+	}
+	// Only stdout is the kubeconfig; kind warns on stderr, e.g. about
+	// experimental providers
+	output, err := kindCommand(ctx, args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get kubeconfig: %w", commandError(ctx, err))
+	}
+	return string(output), nil
+}
+
+// ExportKubeconfig writes the kubeconfig of a cluster to path
+func ExportKubeconfig(ctx context.Context, clusterName, path string, internal bool) error {
+	data, err := GetKubeconfig(ctx, clusterName, internal)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to export kubeconfig: %w", err)
+	}
+	// Kubeconfigs hold client keys
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		return fmt.Errorf("failed to export kubeconfig: %w", err)
+	}
+	return nil
+}
+
+// MergeKubeconfig adds the cluster's kubeconfig to the default kubeconfig
+// without changing its current context
+func MergeKubeconfig(ctx context.Context, clusterName string) error {
+	_, _, err := mergeKubeconfig(ctx, clusterName)
+	return err
+}
+
+// UseKubeContext merges the cluster's kubeconfig into the default kubeconfig
+// and makes its context the current one
+func UseKubeContext(ctx context.Context, clusterName string) error {
+	config, kubeContext, err := mergeKubeconfig(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	config.CurrentContext = kubeContext
+	return writeKubeconfig(KubeconfigPath(), config)
+}
+
+// RemoveKubeconfig deletes the cluster's context, cluster and user from the
+// default kubeconfig
+func RemoveKubeconfig(ctx context.Context, clusterName string) error {
+	path := KubeconfigPath()
+	config, err := readKubeconfig(path)
+	if err != nil {
+		return err
+	}
+	if !config.Remove(kubeContext(clusterName)) {
+		return fmt.Errorf("%s has no context %s", path, kubeContext(clusterName))
+	}
+	return writeKubeconfig(path, config)
+}
+
+// mergeKubeconfig merges the cluster's kubeconfig into the default one and
+// returns the result along with the cluster's context name
+func mergeKubeconfig(ctx context.Context, clusterName string) (Kubeconfig, string, error) {
+	data, err := GetKubeconfig(ctx, clusterName, false)
+	if err != nil {
+		return Kubeconfig{}, "", err
+	}
+	cluster, err := ParseKubeconfig([]byte(data))
+	if err != nil {
+		return Kubeconfig{}, "", err
+	}
+
+	path := KubeconfigPath()
+	config, err := readKubeconfig(path)
+	if err != nil {
+		return Kubeconfig{}, "", err
+	}
+	config.Merge(cluster)
+	return config, cluster.CurrentContext, writeKubeconfig(path, config)
+}
+
+// readKubeconfig reads a kubeconfig file, where a missing file is empty
+func readKubeconfig(path string) (Kubeconfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return Kubeconfig{}, fmt.Errorf("failed to read kubeconfig: %w", err)
+	}
+	return ParseKubeconfig(data)
+}
+
+// writeKubeconfig replaces a kubeconfig file so readers never see half of it
+func writeKubeconfig(path string, config Kubeconfig) error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to encode kubeconfig: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".ki-kubeconfig-*")
+	if err != nil {
+		return fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+	return nil
+}
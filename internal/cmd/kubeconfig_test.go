@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testKubeconfig = `apiVersion: v1
+clusters:
+- cluster:
+    server: https://127.0.0.1:6443
+  name: kind-dev
+- cluster:
+    server: https://prod.example.com
+  name: prod
+contexts:
+- context:
+    cluster: kind-dev
+    user: kind-dev
+  name: kind-dev
+- context:
+    cluster: prod
+    user: admin
+  name: prod
+current-context: prod
+kind: Config
+preferences: {}
+users:
+- name: kind-dev
+  user:
+    client-certificate-data: b2xk
+- name: admin
+  user:
+    token: secret
+`
+
+func TestKubeconfigMerge(t *testing.T) {
+	config, err := ParseKubeconfig([]byte(testKubeconfig))
+	if err != nil {
+		t.Fatalf("ParseKubeconfig() error = %v", err)
+	}
+
+	added, _ := ParseKubeconfig([]byte(`apiVersion: v1
+clusters:
+- cluster:
+    server: https://127.0.0.1:7443
+  name: kind-dev
+contexts:
+- context:
+    cluster: kind-dev
+    user: kind-dev
+  name: kind-dev
+current-context: kind-dev
+kind: Config
+users:
+- name: kind-dev
+  user:
+    client-certificate-data: bmV3
+`))
+	config.Merge(added)
+
+	if len(config.Clusters) != 2 || len(config.Contexts) != 2 || len(config.Users) != 2 {
+		t.Fatalf("Merge() should replace the entries of the same name, got %+v", config)
+	}
+	if server := config.Clusters[0].Extra["cluster"].(map[string]any)["server"]; server != "https://127.0.0.1:7443" {
+		t.Errorf("Merge() kept the old server %v", server)
+	}
+	if config.CurrentContext != "prod" {
+		t.Errorf("Merge() should keep the current context, got %q", config.CurrentContext)
+	}
+}
+
+func TestKubeconfigRemove(t *testing.T) {
+	config, _ := ParseKubeconfig([]byte(testKubeconfig))
+	config.CurrentContext = "kind-dev"
+
+	if !config.Remove("kind-dev") {
+		t.Fatal("Remove() should report the removed entries")
+	}
+	if config.HasContext("kind-dev") || len(config.Clusters) != 1 || len(config.Users) != 1 || config.CurrentContext != "" {
+		t.Errorf("Remove() left %+v", config)
+	}
+	if !config.HasContext("prod") {
+		t.Error("Remove() should keep other contexts")
+	}
+	if config.Remove("kind-dev") {
+		t.Error("Remove() of a missing context should report nothing removed")
+	}
+}
+
+func TestRemoveKubeconfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(testKubeconfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("KUBECONFIG", path+string(os.PathListSeparator)+"/other/config")
+
+	if KubeconfigPath() != path {
+		t.Fatalf("KubeconfigPath() = %q, expected the first file in KUBECONFIG", KubeconfigPath())
+	}
+	if err := RemoveKubeconfig(context.Background(), "dev"); err != nil {
+		t.Fatalf("RemoveKubeconfig() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "kind-dev") || !strings.Contains(string(data), "token: secret") || !strings.Contains(string(data), "current-context: prod") {
+		t.Errorf("RemoveKubeconfig() wrote:\n%s", data)
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("the kubeconfig should stay private, mode %v", info.Mode().Perm())
+	}
+
+	if err := RemoveKubeconfig(context.Background(), "dev"); err == nil {
+		t.Error("RemoveKubeconfig() of a cluster that is not in the kubeconfig should fail")
+	}
+}
+
+// fakeKind puts a kind script running body first on PATH
+func fakeKind(t *testing.T, body string) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "kind"), []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// kindWarning is what kind prints on stderr for podman and nerdctl
+const kindWarning = "enabling experimental podman provider"
+
+func TestGetKubeconfigIgnoresStderr(t *testing.T) {
+	fakeKind(t, "echo '"+kindWarning+"' >&2\ncat <<'EOF'\n"+testKubeconfig+"EOF")
+
+	data, err := GetKubeconfig(context.Background(), "dev", false)
+	if err != nil {
+		t.Fatalf("GetKubeconfig() error = %v", err)
+	}
+	if data != testKubeconfig {
+		t.Errorf("GetKubeconfig() = %q, expected only the kubeconfig kind printed on stdout", data)
+	}
+
+	fakeKind(t, "echo '"+kindWarning+"' >&2\necho 'ERROR: unknown cluster \"dev\"' >&2\nexit 1")
+	if _, err := GetKubeconfig(context.Background(), "dev", false); err == nil || !strings.Contains(err.Error(), `unknown cluster "dev"`) {
+		t.Errorf("GetKubeconfig() error = %v, expected kind's error", err)
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+)
+
+// kubeconfigs caches the kubeconfig file of each cluster, as KIND reports it.
+// kubectl gets it explicitly, so clusters created with --kubeconfig or whose
+// context was renamed are reached all the same.
+var kubeconfigs = struct {
+	sync.Mutex
+	dir   string
+	paths map[string]string
+}{paths: map[string]string{}}
+
+// clusterKubeconfig returns the path of a file holding the cluster's kubeconfig
+func clusterKubeconfig(ctx context.Context, clusterName string) (string, error) {
+	kubeconfigs.Lock()
+	path, ok := kubeconfigs.paths[clusterName]
+	kubeconfigs.Unlock()
+	if ok {
+		return path, nil
+	}
+
+	data, err := GetKubeconfig(ctx, clusterName, false)
+	if err != nil {
+		return "", err
+	}
+	// Every kubectl call of the cluster reads this file, so do not cache text
+	// that is not a kubeconfig
+	config, err := ParseKubeconfig([]byte(data))
+	if err != nil {
+		return "", err
+	}
+	if len(config.Clusters) == 0 {
+		return "", fmt.Errorf("kind returned no kubeconfig for %s", clusterName)
+	}
+
+	kubeconfigs.Lock()
+	defer kubeconfigs.Unlock()
+	if kubeconfigs.dir == "" {
+		dir, err := os.MkdirTemp("", "ki-kubeconfig-")
+		if err != nil {
+			return "", fmt.Errorf("failed to write kubeconfig: %w", err)
+		}
+		kubeconfigs.dir = dir
+	}
+	path = filepath.Join(kubeconfigs.dir, clusterName)
+	// Kubeconfigs hold client keys
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+	kubeconfigs.paths[clusterName] = path
+	return path, nil
+}
+
+// forgetKubeconfig drops the cached kubeconfig of a cluster, which changes
+// when the cluster is recreated
+func forgetKubeconfig(clusterName string) {
+	kubeconfigs.Lock()
+	defer kubeconfigs.Unlock()
+	if path, ok := kubeconfigs.paths[clusterName]; ok {
+		os.Remove(path)
+		delete(kubeconfigs.paths, clusterName)
+	}
+}
+
+// RemoveKubeconfigs deletes the cached kubeconfig files
+func RemoveKubeconfigs() {
+	kubeconfigs.Lock()
+	defer kubeconfigs.Unlock()
+	if kubeconfigs.dir != "" {
+		os.RemoveAll(kubeconfigs.dir)
+	}
+	kubeconfigs.dir = ""
+	kubeconfigs.paths = map[string]string{}
+}
+
+// kubectlArgs points kubectl arguments at a kubeconfig file
+func kubectlArgs(kubeconfig string, args ...string) []string {
+	return append([]string{"--kubeconfig", kubeconfig}, args...)
+}
+
+// kubectl creates a kubectl command bound to ctx against a cluster
+func kubectl(ctx context.Context, clusterName string, args ...string) (*exec.Cmd, error) {
+	kubeconfig, err := clusterKubeconfig(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	return command(ctx, "kubectl", kubectlArgs(kubeconfig, args...)...), nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// cacheKubeconfig makes clusterKubeconfig return path for a cluster without asking KIND
+func cacheKubeconfig(t *testing.T, clusterName, path string) {
+	t.Helper()
+	kubeconfigs.Lock()
+	kubeconfigs.paths[clusterName] = path
+	kubeconfigs.Unlock()
+	t.Cleanup(func() {
+		kubeconfigs.Lock()
+		delete(kubeconfigs.paths, clusterName)
+		kubeconfigs.Unlock()
+	})
+}
+
+func TestKubectl(t *testing.T) {
+	cacheKubeconfig(t, "dev", "/tmp/dev")
+
+	c, err := kubectl(context.Background(), "dev", "get", "nodes")
+	if err != nil {
+		t.Fatalf("kubectl() error = %v", err)
+	}
+	if got := strings.Join(c.Args, " "); got != "kubectl --kubeconfig /tmp/dev get nodes" {
+		t.Errorf("kubectl() = %q", got)
+	}
+}
+
+func TestForgetKubeconfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev")
+	if err := os.WriteFile(path, []byte("apiVersion: v1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cacheKubeconfig(t, "dev", path)
+
+	forgetKubeconfig("dev")
+	kubeconfigs.Lock()
+	_, cached := kubeconfigs.paths["dev"]
+	kubeconfigs.Unlock()
+	if cached {
+		t.Error("forgetKubeconfig() kept the cached path")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("forgetKubeconfig() kept the file, stat error = %v", err)
+	}
+}
+
+func TestClusterKubeconfigIgnoresKindWarnings(t *testing.T) {
+	t.Cleanup(func() { forgetKubeconfig("noisy") })
+	fakeKind(t, "echo '"+kindWarning+"' >&2\ncat <<'EOF'\n"+testKubeconfig+"EOF")
+
+	path, err := clusterKubeconfig(context.Background(), "noisy")
+	if err != nil {
+		t.Fatalf("clusterKubeconfig() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := ParseKubeconfig(data)
+	if err != nil || strings.Contains(string(data), kindWarning) || !config.HasContext("kind-dev") {
+		t.Errorf("clusterKubeconfig() wrote an invalid kubeconfig (%v):\n%s", err, data)
+	}
+}
+
+func TestClusterKubeconfigRejectsNonKubeconfig(t *testing.T) {
+	t.Cleanup(func() { forgetKubeconfig("broken") })
+	fakeKind(t, "echo '"+kindWarning+"'\necho 'not: [a kubeconfig'")
+
+	if path, err := clusterKubeconfig(context.Background(), "broken"); err == nil {
+		t.Errorf("clusterKubeconfig() = %q, expected an error for output that is not a kubeconfig", path)
+	}
+	kubeconfigs.Lock()
+	_, cached := kubeconfigs.paths["broken"]
+	kubeconfigs.Unlock()
+	if cached {
+		t.Error("clusterKubeconfig() cached output that is not a kubeconfig")
+	}
+}
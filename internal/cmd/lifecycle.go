@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+)
+
+// RoleLoadBalancer is the role of the proxy KIND puts in front of HA control planes
+const RoleLoadBalancer = "external-load-balancer"
+
+// readyPollInterval is how often StartCluster probes the API server
+const readyPollInterval = 2 * time.Second
+
+// CanStop reports whether a cluster in the given state has containers to stop
+func CanStop(status string) bool {
+	return status == StatusRunning || status == StatusDegraded || status == StatusUnreachable
+}
+
+// CanStart reports whether a cluster in the given state has containers to start
+func CanStart(status string) bool {
+	return status == StatusStopped || status == StatusDegraded
+}
+
+// CanRestart reports whether a cluster in the given state can be restarted
+func CanRestart(status string) bool {
+	return status != StatusUnknown && status != ""
+}
+
+// containerGroup is a set of node containers that are stopped or started together
+type containerGroup struct {
+	name  string
+	names []string
+}
+
+// stopOrder groups the containers in the order they are stopped: workers first so
+// their pods are not rescheduled, then the control planes, then the load balancer.
+// Starting uses the reverse order.
+func stopOrder(containers []Container) []containerGroup {
+	groups := []containerGroup{
+		{name: "workers"},
+		{name: "control-plane nodes"},
+		{name: "load balancer"},
+	}
+	for _, c := range containers {
+		switch c.Role {
+		case RoleControlPlane:
+			groups[1].names = append(groups[1].names, c.Name)
+		case RoleLoadBalancer:
+			groups[2].names = append(groups[2].names, c.Name)
+		default:
+			groups[0].names = append(groups[0].names, c.Name)
+		}
+	}
+
+	ordered := make([]containerGroup, 0, len(groups))
+	for _, g := range groups {
+		if len(g.names) > 0 {
+			ordered = append(ordered, g)
+		}
+	}
+	return ordered
+}
+
+// StopCluster stops the node containers of a cluster without deleting it
+func StopCluster(ctx context.Context, name string, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, StopTimeout)
+	defer cancel()
+	defer forgetCluster(name)
+
+	if err := stopContainers(ctx, name, out); err != nil {
+		return fmt.Errorf("failed to stop cluster: %w", err)
+	}
+	return nil
+}
+
+// StartCluster starts the node containers of a stopped cluster and waits for its API server
+func StartCluster(ctx context.Context, name string, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, StartTimeout)
+	defer cancel()
+	defer forgetCluster(name)
+
+	if err := startContainers(ctx, name, out); err != nil {
+		return fmt.Errorf("failed to start cluster: %w", err)
+	}
+	return nil
+}
+
+// RestartCluster stops and then starts the node containers of a cluster
+func RestartCluster(ctx context.Context, name string, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, StopTimeout+StartTimeout)
+	defer cancel()
+	defer forgetCluster(name)
+
+	if err := stopContainers(ctx, name, out); err != nil {
+		return fmt.Errorf("failed to restart cluster: %w", err)
+	}
+	if err := startContainers(ctx, name, out); err != nil {
+		return fmt.Errorf("failed to restart cluster: %w", err)
+	}
+	return nil
+}
+
+func stopContainers(ctx context.Context, name string, out io.Writer) error {
+	containers, err := GetNodeContainers(ctx, name)
+	if err != nil {
+		return err
+	}
+	if len(containers) == 0 {
+		return fmt.Errorf("no node containers found for cluster %s", name)
+	}
+
+	for _, g := range stopOrder(containers) {
+		args := append([]string{"stop"}, g.names...)
+		if err := runStep(ctx, out, "Stopping "+g.name, ActiveProvider, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startContainers(ctx context.Context, name string, out io.Writer) error {
+	containers, err := GetNodeContainers(ctx, name)
+	if err != nil {
+		return err
+	}
+	if len(containers) == 0 {
+		return fmt.Errorf("no node containers found for cluster %s", name)
+	}
+
+	groups := stopOrder(containers)
+	for i := len(groups) - 1; i >= 0; i-- {
+		args := append([]string{"start"}, groups[i].names...)
+		if err := runStep(ctx, out, "Starting "+groups[i].name, ActiveProvider, args...); err != nil {
+			return err
+		}
+	}
+
+	return waitForAPIServer(ctx, name, out)
+}
+
+// waitForAPIServer polls the API server until it is ready or ctx is done
+func waitForAPIServer(ctx context.Context, name string, out io.Writer) error {
+	step(out, "•", "Waiting for the API server ...")
+	for !APIServerReady(ctx, name) {
+		select {
+		case <-ctx.Done():
+			step(out, "✗", "Waiting for the API server")
+			return fmt.Errorf("API server not ready: %w", ctx.Err())
+		case <-time.After(readyPollInterval):
+		}
+	}
+	step(out, "✓", "Waiting for the API server")
+	return nil
+}
+
+// runStep runs a command as a named step, reporting it with the same markers KIND
+// uses so the operation view can follow along
+func runStep(ctx context.Context, out io.Writer, name, command string, args ...string) error {
+	step(out, "•", name+" ...")
+	output, err := run(ctx, nil, command, args...)
+	if err != nil {
+		step(out, "✗", name)
+		return fmt.Errorf("%s: %w\n%s", name, err, string(output))
+	}
+	step(out, "✓", name)
+	return nil
+}
+
+func step(out io.Writer, marker, text string) {
+	if out != nil {
+		fmt.Fprintf(out, " %s %s\n", marker, text)
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStopOrder(t *testing.T) {
+	containers := []Container{
+		{Name: "ha-control-plane", Role: RoleControlPlane},
+		{Name: "ha-external-load-balancer", Role: RoleLoadBalancer},
+		{Name: "ha-worker", Role: RoleWorker},
+		{Name: "ha-control-plane2", Role: RoleControlPlane},
+		{Name: "ha-worker2", Role: RoleWorker},
+	}
+
+	groups := stopOrder(containers)
+	expected := []containerGroup{
+		{name: "workers", names: []string{"ha-worker", "ha-worker2"}},
+		{name: "control-plane nodes", names: []string{"ha-control-plane", "ha-control-plane2"}},
+		{name: "load balancer", names: []string{"ha-external-load-balancer"}},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("stopOrder() = %+v, expected %+v", groups, expected)
+	}
+
+	// Empty groups are skipped for single node clusters
+	groups = stopOrder([]Container{{Name: "kind-control-plane", Role: RoleControlPlane}})
+	if len(groups) != 1 || groups[0].name != "control-plane nodes" {
+		t.Errorf("stopOrder() for a single node = %+v", groups)
+	}
+}
+
+func TestLifecycleActions(t *testing.T) {
+	tests := []struct {
+		status                        string
+		canStop, canStart, canRestart bool
+	}{
+		{StatusRunning, true, false, true},
+		{StatusStopped, false, true, true},
+		{StatusDegraded, true, true, true},
+		{StatusUnreachable, true, false, true},
+		{StatusUnknown, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if CanStop(tt.status) != tt.canStop {
+				t.Errorf("CanStop(%s) = %v", tt.status, !tt.canStop)
+			}
+			if CanStart(tt.status) != tt.canStart {
+				t.Errorf("CanStart(%s) = %v", tt.status, !tt.canStart)
+			}
+			if CanRestart(tt.status) != tt.canRestart {
+				t.Errorf("CanRestart(%s) = %v", tt.status, !tt.canRestart)
+			}
+		})
+	}
+}
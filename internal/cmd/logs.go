@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// NodeLogUnits are the systemd units of a node container whose journal can be read
+var NodeLogUnits = []string{"kubelet", "containerd"}
+
+// LogSource identifies a log: a pod container when Pod is set, otherwise a node unit
+type LogSource struct {
+	Cluster   string
+	Namespace string
+	Pod       string
+	Container string // empty uses the pod's default container
+	Node      string
+	Unit      string
+}
+
+// IsNode reports whether the source is the journal of a node container
+func (s LogSource) IsNode() bool {
+	return s.Pod == ""
+}
+
+// String describes the source, e.g. "kube-system/coredns-abc [coredns]"
+func (s LogSource) String() string {
+	if s.IsNode() {
+		return fmt.Sprintf("%s [%s]", s.Node, s.Unit)
+	}
+	if s.Container == "" {
+		return s.Namespace + "/" + s.Pod
+	}
+	return fmt.Sprintf("%s/%s [%s]", s.Namespace, s.Pod, s.Container)
+}
+
+// LogOptions selects which part of a log is read
+type LogOptions struct {
+	Follow   bool
+	Previous bool          // pods only: read the previous instance of a restarted container
+	Since    time.Duration // only read entries newer than this, 0 reads everything
+	Tail     int           // only read the last Tail lines, negative reads everything
+}
+
+// StreamLogs copies a pod or node log to out until it ends or ctx is cancelled.
+// Following a log is not bounded by a timeout.
+func StreamLogs(ctx context.Context, source LogSource, opts LogOptions, out io.Writer) error {
+	var name string
+	var args []string
+	if source.IsNode() {
+		name, args = ActiveProvider, nodeLogArgs(source, opts)
+	} else {
+		kubeconfig, err := clusterKubeconfig(ctx, source.Cluster)
+		if err != nil {
+			return fmt.Errorf("failed to read logs of %s: %w", source, err)
+		}
+		name, args = "kubectl", podLogArgs(kubeconfig, source, opts)
+	}
+
+	cmd := command(ctx, name, args...)
+	cmd.Stdout = out
+	cmd.Stderr = out
+	if err := contextError(ctx, cmd.Run()); err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("failed to read logs of %s: %w", source, err)
+	}
+	return nil
+}
+
+// podLogArgs builds the kubectl logs arguments for a pod container
+func podLogArgs(kubeconfig string, source LogSource, opts LogOptions) []string {
+	args := kubectlArgs(kubeconfig, "logs", "--namespace", source.Namespace, source.Pod)
+	if source.Container != "" {
+		args = append(args, "--container", source.Container)
+	}
+	if opts.Follow {
+		args = append(args, "--follow")
+	}
+	if opts.Previous {
+		args = append(args, "--previous")
+	}
+	if opts.Since > 0 {
+		args = append(args, "--since", opts.Since.String())
+	}
+	args = append(args, "--tail", strconv.Itoa(opts.Tail))
+	return args
+}
+
+// nodeLogArgs builds the arguments reading a unit's journal inside a node container
+func nodeLogArgs(source LogSource, opts LogOptions) []string {
+	args := []string{"exec", source.Node, "journalctl", "--unit", source.Unit, "--no-pager"}
+	if opts.Follow {
+		args = append(args, "--follow")
+	}
+	if opts.Since > 0 {
+		// journalctl reads "-5m0s" as five minutes ago
+		args = append(args, "--since", "-"+opts.Since.String())
+	}
+	if opts.Tail >= 0 {
+		args = append(args, "--lines", strconv.Itoa(opts.Tail))
+	} else {
+		args = append(args, "--lines", "all")
+	}
+	return args
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogSourceString(t *testing.T) {
+	tests := []struct {
+		source   LogSource
+		expected string
+	}{
+		{LogSource{Cluster: "dev", Namespace: "kube-system", Pod: "coredns-abc", Container: "coredns"}, "kube-system/coredns-abc [coredns]"},
+		{LogSource{Cluster: "dev", Namespace: "default", Pod: "web"}, "default/web"},
+		{LogSource{Cluster: "dev", Node: "dev-worker", Unit: "kubelet"}, "dev-worker [kubelet]"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.source.String(); got != tt.expected {
+			t.Errorf("String() = %q, expected %q", got, tt.expected)
+		}
+	}
+}
+
+func TestPodLogArgs(t *testing.T) {
+	source := LogSource{Cluster: "dev", Namespace: "default", Pod: "web-1", Container: "sidecar"}
+
+	tests := []struct {
+		name     string
+		opts     LogOptions
+		expected string
+	}{
+		{"follow tail", LogOptions{Follow: true, Tail: 100},
+			"--kubeconfig /tmp/dev logs --namespace default web-1 --container sidecar --follow --tail 100"},
+		{"previous since", LogOptions{Previous: true, Since: 5 * time.Minute, Tail: -1},
+			"--kubeconfig /tmp/dev logs --namespace default web-1 --container sidecar --previous --since 5m0s --tail -1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Join(podLogArgs("/tmp/dev", source, tt.opts), " "); got != tt.expected {
+				t.Errorf("podLogArgs() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNodeLogArgs(t *testing.T) {
+	source := LogSource{Cluster: "dev", Node: "dev-control-plane", Unit: "containerd"}
+
+	tests := []struct {
+		name     string
+		opts     LogOptions
+		expected string
+	}{
+		{"follow tail", LogOptions{Follow: true, Tail: 100},
+			"exec dev-control-plane journalctl --unit containerd --no-pager --follow --lines 100"},
+		{"since everything", LogOptions{Since: time.Hour, Tail: -1},
+			"exec dev-control-plane journalctl --unit containerd --no-pager --since -1h0m0s --lines all"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Join(nodeLogArgs(source, tt.opts), " "); got != tt.expected {
+				t.Errorf("nodeLogArgs() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// NodeDetail is everything the node detail view shows about one node
+type NodeDetail struct {
+	Node Node  `json:"node" yaml:"node"`
+	Pods []Pod `json:"pods" yaml:"pods"`
+
+	// Resources requested by the pods scheduled on the node, in cores and bytes
+	RequestedCPU    float64 `json:"requestedCPU" yaml:"requestedCPU"`
+	RequestedMemory float64 `json:"requestedMemory" yaml:"requestedMemory"`
+
+	Stats    *ContainerStats `json:"stats,omitempty" yaml:"stats,omitempty"`
+	Services []ServiceState  `json:"services,omitempty" yaml:"services,omitempty"`
+
+	// Errors lists the parts that could not be read; the rest is still shown
+	Errors []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// ContainerStats is the resource usage of a node container as the runtime reports it
+type ContainerStats struct {
+	CPU           string `json:"cpu" yaml:"cpu"`
+	Memory        string `json:"memory" yaml:"memory"`
+	MemoryPercent string `json:"memoryPercent" yaml:"memoryPercent"`
+}
+
+// ServiceState is the systemd state of a service inside a node container
+type ServiceState struct {
+	Name  string `json:"name" yaml:"name"`
+	State string `json:"state" yaml:"state"`
+}
+
+// Active reports whether the service is running
+func (s ServiceState) Active() bool {
+	return s.State == "active"
+}
+
+// nodeStatsFormat prints CPU, memory usage and memory percentage; docker,
+// podman and nerdctl all understand it
+const nodeStatsFormat = `{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}`
+
+// GetNodeDetail gathers a node's state, the pods scheduled on it, its
+// container's resource usage and the state of its kubelet and containerd.
+// Only a failure to read the node itself is an error; the other parts are
+// reported in Errors.
+func GetNodeDetail(ctx context.Context, clusterName, nodeName string) (*NodeDetail, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	node, err := getNode(ctx, clusterName, nodeName)
+	if err != nil {
+		return nil, err
+	}
+	detail := &NodeDetail{Node: node}
+
+	if pods, err := getNodePods(ctx, clusterName, nodeName); err != nil {
+		detail.Errors = append(detail.Errors, err.Error())
+	} else {
+		detail.Pods = pods
+		for _, pod := range pods {
+			detail.RequestedCPU += pod.CPURequest
+			detail.RequestedMemory += pod.MemoryRequest
+		}
+	}
+
+	if stats, err := getNodeStats(ctx, nodeName); err != nil {
+		detail.Errors = append(detail.Errors, err.Error())
+	} else {
+		detail.Stats = stats
+	}
+
+	if services, err := getNodeServices(ctx, nodeName); err != nil {
+		detail.Errors = append(detail.Errors, err.Error())
+	} else {
+		detail.Services = services
+	}
+
+	return detail, nil
+}
+
+func getNode(ctx context.Context, clusterName, nodeName string) (Node, error) {
+	cmd, err := kubectl(ctx, clusterName, "get", "nodes",
+		"--field-selector", "metadata.name="+nodeName, "-o", "json")
+	if err != nil {
+		return Node{}, fmt.Errorf("failed to get node: %w", err)
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return Node{}, fmt.Errorf("failed to get node: %w", commandError(ctx, err))
+	}
+
+	nodes, err := ParseNodes(output, time.Now())
+	if err != nil {
+		return Node{}, err
+	}
+	if len(nodes) == 0 {
+		return Node{}, fmt.Errorf("node %s not found", nodeName)
+	}
+	return nodes[0], nil
+}
+
+// getNodePods lists the pods on a node that still hold their resource requests
+func getNodePods(ctx context.Context, clusterName, nodeName string) ([]Pod, error) {
+	cmd, err := kubectl(ctx, clusterName, "get", "pods", "--all-namespaces",
+		"--field-selector", "spec.nodeName="+nodeName+",status.phase!=Succeeded,status.phase!=Failed",
+		"-o", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", commandError(ctx, err))
+	}
+	return ParsePods(output, time.Now())
+}
+
+func getNodeStats(ctx context.Context, nodeName string) (*ContainerStats, error) {
+	cmd := command(ctx, ActiveProvider, "stats", "--no-stream", "--format", nodeStatsFormat, nodeName)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container stats: %w", commandError(ctx, err))
+	}
+	return ParseContainerStats(string(output))
+}
+
+// ParseContainerStats parses the tab separated line printed with nodeStatsFormat
+func ParseContainerStats(output string) (*ContainerStats, error) {
+	fields := strings.Split(strings.TrimSpace(output), "\t")
+	if len(fields) != 3 {
+		return nil, fmt.Errorf("unexpected container stats %q", strings.TrimSpace(output))
+	}
+	return &ContainerStats{
+		CPU:           strings.TrimSpace(fields[0]),
+		Memory:        strings.TrimSpace(fields[1]),
+		MemoryPercent: strings.TrimSpace(fields[2]),
+	}, nil
+}
+
+func getNodeServices(ctx context.Context, nodeName string) ([]ServiceState, error) {
+	args := append([]string{"exec", nodeName, "systemctl", "is-active"}, NodeLogUnits...)
+	cmd := command(ctx, ActiveProvider, args...)
+	output, err := cmd.Output()
+	// systemctl is-active exits non-zero when a unit is not active, but still
+	// prints every state
+	var exitErr *exec.ExitError
+	if err != nil && (!errors.As(err, &exitErr) || ctx.Err() != nil) {
+		return nil, fmt.Errorf("failed to get service states: %w", commandError(ctx, err))
+	}
+
+	services := ParseServiceStates(NodeLogUnits, string(output))
+	if services == nil {
+		if err != nil {
+			return nil, fmt.Errorf("failed to get service states: %w", commandError(ctx, err))
+		}
+		return nil, fmt.Errorf("unexpected service states %q", strings.TrimSpace(string(output)))
+	}
+	return services, nil
+}
+
+// ParseServiceStates pairs units with the states systemctl is-active printed
+// for them, one per line. It returns nil when a state is missing.
+func ParseServiceStates(units []string, output string) []ServiceState {
+	lines := strings.Fields(output)
+	if len(lines) < len(units) {
+		return nil
+	}
+	services := make([]ServiceState, len(units))
+	for i, unit := range units {
+		services[i] = ServiceState{Name: unit, State: lines[i]}
+	}
+	return services
+}
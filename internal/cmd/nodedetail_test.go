@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseContainerStats(t *testing.T) {
+	stats, err := ParseContainerStats("12.34%\t1.2GiB / 15.5GiB\t7.74%\n")
+	if err != nil {
+		t.Fatalf("ParseContainerStats() error = %v", err)
+	}
+	expected := &ContainerStats{CPU: "12.34%", Memory: "1.2GiB / 15.5GiB", MemoryPercent: "7.74%"}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("ParseContainerStats() = %+v, expected %+v", stats, expected)
+	}
+
+	if _, err := ParseContainerStats(""); err == nil {
+		t.Error("ParseContainerStats() of empty output should fail")
+	}
+}
+
+func TestParseServiceStates(t *testing.T) {
+	units := []string{"kubelet", "containerd"}
+
+	got := ParseServiceStates(units, "activating\nactive\n")
+	expected := []ServiceState{{Name: "kubelet", State: "activating"}, {Name: "containerd", State: "active"}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ParseServiceStates() = %+v, expected %+v", got, expected)
+	}
+	if got[0].Active() || !got[1].Active() {
+		t.Errorf("Active() = %v, %v, expected false, true", got[0].Active(), got[1].Active())
+	}
+
+	if got := ParseServiceStates(units, "active\n"); got != nil {
+		t.Errorf("ParseServiceStates() with a missing state = %+v, expected nil", got)
+	}
+}
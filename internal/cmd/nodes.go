@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Node represents a Kubernetes node
+type Node struct {
+	Name       string `json:"name" yaml:"name"`
+	Role       string `json:"role" yaml:"role"`
+	Status     string `json:"status" yaml:"status"`
+	Age        string `json:"age" yaml:"age"`
+	Version    string `json:"version" yaml:"version"`
+	InternalIP string `json:"internalIP" yaml:"internalIP"`
+
+	Conditions       []NodeCondition   `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	Capacity         map[string]string `json:"capacity,omitempty" yaml:"capacity,omitempty"`
+	Allocatable      map[string]string `json:"allocatable,omitempty" yaml:"allocatable,omitempty"`
+	Taints           []Taint           `json:"taints,omitempty" yaml:"taints,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	ContainerRuntime string            `json:"containerRuntime,omitempty" yaml:"containerRuntime,omitempty"`
+	KernelVersion    string            `json:"kernelVersion,omitempty" yaml:"kernelVersion,omitempty"`
+	OSImage          string            `json:"osImage,omitempty" yaml:"osImage,omitempty"`
+	Created          time.Time         `json:"created" yaml:"created"`
+}
+
+// NodeCondition is one of the conditions the kubelet reports for its node
+type NodeCondition struct {
+	Type    string `json:"type" yaml:"type"`
+	Status  string `json:"status" yaml:"status"`
+	Reason  string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// Healthy reports whether the condition is in its good state: Ready is true
+// and every pressure or unavailability condition is false
+func (c NodeCondition) Healthy() bool {
+	if c.Type == "Ready" {
+		return c.Status == "True"
+	}
+	return c.Status == "False"
+}
+
+// Taint keeps pods that do not tolerate it off a node
+type Taint struct {
+	Key    string `json:"key" yaml:"key"`
+	Value  string `json:"value,omitempty" yaml:"value,omitempty"`
+	Effect string `json:"effect" yaml:"effect"`
+}
+
+// String formats a taint the way kubectl taint takes it, e.g. key=value:NoSchedule
+func (t Taint) String() string {
+	if t.Value == "" {
+		return t.Key + ":" + t.Effect
+	}
+	return t.Key + "=" + t.Value + ":" + t.Effect
+}
+
+// nodeRoleLabel prefixes the labels naming the roles of a node
+const nodeRoleLabel = "node-role.kubernetes.io/"
+
+// nodeList is the part of kubectl's node list JSON that ki reads
+type nodeList struct {
+	Items []struct {
+		Metadata struct {
+			Name              string            `json:"name"`
+			Labels            map[string]string `json:"labels"`
+			CreationTimestamp time.Time         `json:"creationTimestamp"`
+		} `json:"metadata"`
+		Spec struct {
+			Unschedulable bool    `json:"unschedulable"`
+			Taints        []Taint `json:"taints"`
+		} `json:"spec"`
+		Status struct {
+			Conditions  []NodeCondition   `json:"conditions"`
+			Capacity    map[string]string `json:"capacity"`
+			Allocatable map[string]string `json:"allocatable"`
+			Addresses   []struct {
+				Type    string `json:"type"`
+				Address string `json:"address"`
+			} `json:"addresses"`
+			NodeInfo struct {
+				KubeletVersion          string `json:"kubeletVersion"`
+				ContainerRuntimeVersion string `json:"containerRuntimeVersion"`
+				KernelVersion           string `json:"kernelVersion"`
+				OSImage                 string `json:"osImage"`
+			} `json:"nodeInfo"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// GetClusterNodes retrieves nodes for a specific cluster
+func GetClusterNodes(ctx context.Context, clusterName string) ([]Node, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	cmd, err := kubectl(ctx, clusterName, "get", "nodes", "-o", "json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nodes: %w", err)
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		// The cluster may have been recreated with new credentials since
+		forgetKubeconfig(clusterName)
+		return nil, fmt.Errorf("failed to get nodes: %w", commandError(ctx, err))
+	}
+
+	return ParseNodes(output, time.Now())
+}
+
+// ParseNodes parses kubectl get nodes -o json output, computing ages relative to now
+func ParseNodes(data []byte, now time.Time) ([]Node, error) {
+	var list nodeList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse nodes: %w", err)
+	}
+
+	nodes := make([]Node, 0, len(list.Items))
+	for _, item := range list.Items {
+		node := Node{
+			Name:             item.Metadata.Name,
+			Role:             nodeRole(item.Metadata.Labels),
+			Status:           nodeStatus(item.Status.Conditions, item.Spec.Unschedulable),
+			Age:              FormatAge(now.Sub(item.Metadata.CreationTimestamp)),
+			Version:          item.Status.NodeInfo.KubeletVersion,
+			Conditions:       item.Status.Conditions,
+			Capacity:         item.Status.Capacity,
+			Allocatable:      item.Status.Allocatable,
+			Taints:           item.Spec.Taints,
+			Labels:           item.Metadata.Labels,
+			ContainerRuntime: item.Status.NodeInfo.ContainerRuntimeVersion,
+			KernelVersion:    item.Status.NodeInfo.KernelVersion,
+			OSImage:          item.Status.NodeInfo.OSImage,
+			Created:          item.Metadata.CreationTimestamp,
+		}
+		for _, address := range item.Status.Addresses {
+			if address.Type == "InternalIP" {
+				node.InternalIP = address.Address
+				break
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// nodeRole joins the roles a node is labelled with. The legacy master role
+// counts as control-plane, and nodes without a role are workers.
+func nodeRole(labels map[string]string) string {
+	seen := make(map[string]bool)
+	roles := make([]string, 0)
+	for label := range labels {
+		role, found := strings.CutPrefix(label, nodeRoleLabel)
+		if !found || role == "" {
+			continue
+		}
+		if role == "master" {
+			role = RoleControlPlane
+		}
+		if !seen[role] {
+			seen[role] = true
+			roles = append(roles, role)
+		}
+	}
+	if len(roles) == 0 {
+		return RoleWorker
+	}
+	sort.Strings(roles)
+	return strings.Join(roles, ",")
+}
+
+// nodeStatus mirrors the STATUS column of kubectl get nodes
+func nodeStatus(conditions []NodeCondition, unschedulable bool) string {
+	status := "Unknown"
+	for _, c := range conditions {
+		if c.Type != "Ready" {
+			continue
+		}
+		switch c.Status {
+		case "True":
+			status = "Ready"
+		case "False":
+			status = "NotReady"
+		}
+	}
+	if unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return status
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+const nodeListJSON = `{
+  "items": [
+    {
+      "metadata": {
+        "name": "dev-control-plane",
+        "creationTimestamp": "2024-05-01T10:00:00Z",
+        "labels": {
+          "kubernetes.io/hostname": "dev-control-plane",
+          "node-role.kubernetes.io/control-plane": ""
+        }
+      },
+      "spec": {
+        "taints": [{"key": "node-role.kubernetes.io/control-plane", "effect": "NoSchedule"}]
+      },
+      "status": {
+        "conditions": [
+          {"type": "MemoryPressure", "status": "False", "reason": "KubeletHasSufficientMemory"},
+          {"type": "Ready", "status": "True", "reason": "KubeletReady", "message": "kubelet is posting ready status"}
+        ],
+        "capacity": {"cpu": "8", "memory": "16310128Ki", "pods": "110"},
+        "allocatable": {"cpu": "8", "memory": "16310128Ki", "pods": "110"},
+        "addresses": [
+          {"type": "InternalIP", "address": "172.18.0.2"},
+          {"type": "Hostname", "address": "dev-control-plane"}
+        ],
+        "nodeInfo": {
+          "kubeletVersion": "v1.30.0",
+          "containerRuntimeVersion": "containerd://1.7.15",
+          "kernelVersion": "6.6.16-linuxkit",
+          "osImage": "Debian GNU/Linux 12 (bookworm)"
+        }
+      }
+    },
+    {
+      "metadata": {
+        "name": "dev-worker",
+        "creationTimestamp": "2024-05-03T09:30:00Z",
+        "labels": {"node-role.kubernetes.io/ingress": "", "node-role.kubernetes.io/edge": "true"}
+      },
+      "spec": {"unschedulable": true},
+      "status": {
+        "conditions": [{"type": "Ready", "status": "False", "reason": "KubeletNotReady"}],
+        "addresses": [{"type": "InternalIP", "address": "172.18.0.3"}],
+        "nodeInfo": {"kubeletVersion": "v1.30.0"}
+      }
+    },
+    {
+      "metadata": {"name": "dev-worker2", "creationTimestamp": "2024-05-03T09:59:30Z"},
+      "spec": {},
+      "status": {}
+    }
+  ]
+}`
+
+func TestParseNodes(t *testing.T) {
+	now := time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC)
+	nodes, err := ParseNodes([]byte(nodeListJSON), now)
+	if err != nil {
+		t.Fatalf("ParseNodes() error = %v", err)
+	}
+
+	expected := []struct {
+		name, role, status, age, version, ip string
+	}{
+		{"dev-control-plane", "control-plane", "Ready", "2d", "v1.30.0", "172.18.0.2"},
+		{"dev-worker", "edge,ingress", "NotReady,SchedulingDisabled", "30m", "v1.30.0", "172.18.0.3"},
+		{"dev-worker2", "worker", "Unknown", "30s", "", ""},
+	}
+	if len(nodes) != len(expected) {
+		t.Fatalf("ParseNodes() returned %d nodes, expected %d", len(nodes), len(expected))
+	}
+	for i, want := range expected {
+		n := nodes[i]
+		if n.Name != want.name || n.Role != want.role || n.Status != want.status || n.Age != want.age || n.Version != want.version || n.InternalIP != want.ip {
+			t.Errorf("Node[%d] = %+v, expected %+v", i, n, want)
+		}
+	}
+
+	cp := nodes[0]
+	if cp.ContainerRuntime != "containerd://1.7.15" || cp.KernelVersion != "6.6.16-linuxkit" || cp.OSImage != "Debian GNU/Linux 12 (bookworm)" {
+		t.Errorf("Node[0] runtime info = %q, %q, %q", cp.ContainerRuntime, cp.KernelVersion, cp.OSImage)
+	}
+	if cp.Capacity["cpu"] != "8" || cp.Allocatable["pods"] != "110" {
+		t.Errorf("Node[0] capacity = %v, allocatable = %v", cp.Capacity, cp.Allocatable)
+	}
+	if len(cp.Taints) != 1 || cp.Taints[0].String() != "node-role.kubernetes.io/control-plane:NoSchedule" {
+		t.Errorf("Node[0].Taints = %v", cp.Taints)
+	}
+	if len(cp.Conditions) != 2 || !cp.Conditions[0].Healthy() || !cp.Conditions[1].Healthy() {
+		t.Errorf("Node[0].Conditions = %+v, expected two healthy conditions", cp.Conditions)
+	}
+	if cp.Labels["kubernetes.io/hostname"] != "dev-control-plane" {
+		t.Errorf("Node[0].Labels = %v", cp.Labels)
+	}
+
+	if _, err := ParseNodes([]byte("not json"), now); err == nil {
+		t.Error("ParseNodes() should fail on invalid JSON")
+	}
+}
+
+func TestNodeRole(t *testing.T) {
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		expected string
+	}{
+		{"control-plane role", map[string]string{"node-role.kubernetes.io/control-plane": ""}, "control-plane"},
+		{"legacy master role", map[string]string{"node-role.kubernetes.io/master": ""}, "control-plane"},
+		{"both", map[string]string{"node-role.kubernetes.io/master": "", "node-role.kubernetes.io/control-plane": ""}, "control-plane"},
+		{"no role", map[string]string{"kubernetes.io/os": "linux"}, "worker"},
+		{"custom role", map[string]string{"node-role.kubernetes.io/gpu": "true"}, "gpu"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nodeRole(tt.labels); got != tt.expected {
+				t.Errorf("nodeRole(%v) = %q, expected %q", tt.labels, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTaintString(t *testing.T) {
+	if got := (Taint{Key: "gpu", Value: "true", Effect: "NoExecute"}).String(); got != "gpu=true:NoExecute" {
+		t.Errorf("String() = %q", got)
+	}
+}
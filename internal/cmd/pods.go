@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Pod is a summary of a Kubernetes pod as shown by kubectl get pods
+type Pod struct {
+	Namespace  string    `json:"namespace" yaml:"namespace"`
+	Name       string    `json:"name" yaml:"name"`
+	Ready      string    `json:"ready" yaml:"ready"`
+	Status     string    `json:"status" yaml:"status"`
+	Restarts   int       `json:"restarts" yaml:"restarts"`
+	Age        string    `json:"age" yaml:"age"`
+	Node       string    `json:"node" yaml:"node"`
+	Containers []string  `json:"containers" yaml:"containers"`
+	Created    time.Time `json:"created" yaml:"created"`
+
+	// Resources requested by the pod's containers, in cores and bytes
+	CPURequest    float64 `json:"cpuRequest,omitempty" yaml:"cpuRequest,omitempty"`
+	MemoryRequest float64 `json:"memoryRequest,omitempty" yaml:"memoryRequest,omitempty"`
+}
+
+// podList is the part of kubectl's pod list JSON that ki reads
+type podList struct {
+	Items []struct {
+		Metadata struct {
+			Name              string     `json:"name"`
+			Namespace         string     `json:"namespace"`
+			CreationTimestamp time.Time  `json:"creationTimestamp"`
+			DeletionTimestamp *time.Time `json:"deletionTimestamp"`
+		} `json:"metadata"`
+		Spec struct {
+			NodeName   string `json:"nodeName"`
+			Containers []struct {
+				Name      string `json:"name"`
+				Resources struct {
+					Requests map[string]string `json:"requests"`
+				} `json:"resources"`
+			} `json:"containers"`
+		} `json:"spec"`
+		Status struct {
+			Phase             string            `json:"phase"`
+			Reason            string            `json:"reason"`
+			ContainerStatuses []containerStatus `json:"containerStatuses"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+type containerStatus struct {
+	Ready        bool `json:"ready"`
+	RestartCount int  `json:"restartCount"`
+	State        struct {
+		Waiting *struct {
+			Reason string `json:"reason"`
+		} `json:"waiting"`
+		Terminated *struct {
+			Reason string `json:"reason"`
+		} `json:"terminated"`
+	} `json:"state"`
+}
+
+// GetPods lists the pods of a cluster, in all namespaces when namespace is empty
+func GetPods(ctx context.Context, clusterName, namespace string) ([]Pod, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	args := []string{"get", "pods", "-o", "json"}
+	if namespace == "" {
+		args = append(args, "--all-namespaces")
+	} else {
+		args = append(args, "--namespace", namespace)
+	}
+
+	cmd, err := kubectl(ctx, clusterName, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", err)
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pods: %w", commandError(ctx, err))
+	}
+
+	return ParsePods(output, time.Now())
+}
+
+// ParsePods parses kubectl get pods -o json output, computing ages relative to now
+func ParsePods(data []byte, now time.Time) ([]Pod, error) {
+	var list podList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse pods: %w", err)
+	}
+
+	pods := make([]Pod, 0, len(list.Items))
+	for _, item := range list.Items {
+		ready, restarts := 0, 0
+		for _, cs := range item.Status.ContainerStatuses {
+			if cs.Ready {
+				ready++
+			}
+			restarts += cs.RestartCount
+		}
+
+		containers := make([]string, len(item.Spec.Containers))
+		var cpu, memory float64
+		for i, c := range item.Spec.Containers {
+			containers[i] = c.Name
+			// The API server only accepts valid quantities
+			cores, _ := ParseQuantity(c.Resources.Requests["cpu"])
+			bytes, _ := ParseQuantity(c.Resources.Requests["memory"])
+			cpu += cores
+			memory += bytes
+		}
+
+		pods = append(pods, Pod{
+			Namespace:  item.Metadata.Namespace,
+			Name:       item.Metadata.Name,
+			Ready:      fmt.Sprintf("%d/%d", ready, len(item.Spec.Containers)),
+			Status:     podStatus(item.Status.Phase, item.Status.Reason, item.Metadata.DeletionTimestamp != nil, item.Status.ContainerStatuses),
+			Restarts:   restarts,
+			Age:        FormatAge(now.Sub(item.Metadata.CreationTimestamp)),
+			Node:       item.Spec.NodeName,
+			Containers: containers,
+			Created:    item.Metadata.CreationTimestamp,
+
+			CPURequest:    cpu,
+			MemoryRequest: memory,
+		})
+	}
+	return pods, nil
+}
+
+// podStatus mirrors the STATUS column of kubectl get pods: a waiting or
+// terminated container reason wins over the pod phase
+func podStatus(phase, reason string, deleting bool, statuses []containerStatus) string {
+	if deleting {
+		return "Terminating"
+	}
+	for _, cs := range statuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+			return cs.State.Waiting.Reason
+		}
+		if cs.State.Terminated != nil && cs.State.Terminated.Reason != "" && phase != "Succeeded" {
+			return cs.State.Terminated.Reason
+		}
+	}
+	if reason != "" {
+		return reason
+	}
+	if phase == "" {
+		return "Unknown"
+	}
+	return phase
+}
+
+// FormatAge formats a duration the way kubectl prints ages, e.g. 45s, 3m, 5h, 12d
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "0s"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
+// PodNamespaces returns the sorted, distinct namespaces of pods
+func PodNamespaces(pods []Pod) []string {
+	seen := make(map[string]bool)
+	namespaces := make([]string, 0)
+	for _, p := range pods {
+		if !seen[p.Namespace] {
+			seen[p.Namespace] = true
+			namespaces = append(namespaces, p.Namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
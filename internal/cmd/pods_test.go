@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const podListJSON = `{
+  "items": [
+    {
+      "metadata": {"name": "coredns-abc", "namespace": "kube-system", "creationTimestamp": "2024-05-01T10:00:00Z"},
+      "spec": {"nodeName": "dev-control-plane", "containers": [{}]},
+      "status": {"phase": "Running", "containerStatuses": [{"ready": true, "restartCount": 2, "state": {"running": {}}}]}
+    },
+    {
+      "metadata": {"name": "web-1", "namespace": "default", "creationTimestamp": "2024-05-03T09:30:00Z"},
+      "spec": {"nodeName": "dev-worker", "containers": [
+        {"name": "web", "resources": {"requests": {"cpu": "250m", "memory": "128Mi"}}},
+        {"name": "sidecar", "resources": {"requests": {"cpu": "50m"}}}
+      ]},
+      "status": {"phase": "Running", "containerStatuses": [
+        {"ready": true, "restartCount": 0, "state": {"running": {}}},
+        {"ready": false, "restartCount": 5, "state": {"waiting": {"reason": "CrashLoopBackOff"}}}
+      ]}
+    },
+    {
+      "metadata": {"name": "old", "namespace": "default", "creationTimestamp": "2024-05-03T09:59:30Z", "deletionTimestamp": "2024-05-03T10:00:00Z"},
+      "spec": {"nodeName": "dev-worker", "containers": [{}]},
+      "status": {"phase": "Running"}
+    },
+    {
+      "metadata": {"name": "pending", "namespace": "apps", "creationTimestamp": "2024-05-03T09:58:00Z"},
+      "spec": {"containers": [{}]},
+      "status": {"phase": "Pending"}
+    }
+  ]
+}`
+
+func TestParsePods(t *testing.T) {
+	now := time.Date(2024, 5, 3, 10, 0, 0, 0, time.UTC)
+	pods, err := ParsePods([]byte(podListJSON), now)
+	if err != nil {
+		t.Fatalf("ParsePods() error = %v", err)
+	}
+
+	expected := []struct {
+		name, ready, status, age, node string
+		restarts                       int
+	}{
+		{"coredns-abc", "1/1", "Running", "2d", "dev-control-plane", 2},
+		{"web-1", "1/2", "CrashLoopBackOff", "30m", "dev-worker", 5},
+		{"old", "0/1", "Terminating", "30s", "dev-worker", 0},
+		{"pending", "0/1", "Pending", "2m", "", 0},
+	}
+	if len(pods) != len(expected) {
+		t.Fatalf("ParsePods() returned %d pods, expected %d", len(pods), len(expected))
+	}
+	for i, want := range expected {
+		p := pods[i]
+		if p.Name != want.name || p.Ready != want.ready || p.Status != want.status || p.Age != want.age || p.Node != want.node || p.Restarts != want.restarts {
+			t.Errorf("Pod[%d] = %+v, expected %+v", i, p, want)
+		}
+	}
+
+	if got := strings.Join(pods[1].Containers, ","); got != "web,sidecar" {
+		t.Errorf("Pod[1].Containers = %q, expected web,sidecar", got)
+	}
+	if pods[1].CPURequest != 0.3 || pods[1].MemoryRequest != 128<<20 {
+		t.Errorf("Pod[1] requests = %v cores, %v bytes, expected 0.3 and 128Mi", pods[1].CPURequest, pods[1].MemoryRequest)
+	}
+
+	if _, err := ParsePods([]byte("not json"), now); err == nil {
+		t.Error("ParsePods() should fail on invalid JSON")
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		expected string
+	}{
+		{-time.Second, "0s"},
+		{45 * time.Second, "45s"},
+		{3 * time.Minute, "3m"},
+		{5 * time.Hour, "5h"},
+		{47 * time.Hour, "47h"},
+		{12 * 24 * time.Hour, "12d"},
+	}
+
+	for _, tt := range tests {
+		if result := FormatAge(tt.d); result != tt.expected {
+			t.Errorf("FormatAge(%v) = %s, expected %s", tt.d, result, tt.expected)
+		}
+	}
+}
+
+func TestPodNamespaces(t *testing.T) {
+	pods := []Pod{{Namespace: "kube-system"}, {Namespace: "default"}, {Namespace: "kube-system"}}
+	namespaces := PodNamespaces(pods)
+	if len(namespaces) != 2 || namespaces[0] != "default" || namespaces[1] != "kube-system" {
+		t.Errorf("PodNamespaces() = %v", namespaces)
+	}
+}
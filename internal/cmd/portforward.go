@@ -0,0 +1,440 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Port forward states
+const (
+	ForwardStarting     = "starting"     // kubectl port-forward has not connected yet
+	ForwardActive       = "active"       // connections are forwarded
+	ForwardReconnecting = "reconnecting" // kubectl exited, e.g. because the pod restarted
+	ForwardStopped      = "stopped"
+)
+
+// forwardRetryDelay is how long a forward waits before restarting kubectl
+const forwardRetryDelay = 2 * time.Second
+
+// forwardPollInterval is how often a local connection checks whether kubectl
+// is listening yet
+const forwardPollInterval = 50 * time.Millisecond
+
+// forwardBackendWait bounds how long a local connection waits for kubectl to
+// listen before it is dropped; tests shorten it
+var forwardBackendWait = 10 * time.Second
+
+// forwardingLine is how kubectl port-forward reports the local port it listens on
+var forwardingLine = regexp.MustCompile(`^Forwarding from 127\.0\.0\.1:(\d+) ->`)
+
+// PortForwardSpec describes a forward from a local port to a service or pod port
+type PortForwardSpec struct {
+	Cluster    string `json:"cluster" yaml:"cluster"`
+	Namespace  string `json:"namespace" yaml:"namespace"`
+	Kind       string `json:"kind" yaml:"kind"` // "svc" or "pod"
+	Name       string `json:"name" yaml:"name"`
+	LocalPort  int    `json:"localPort" yaml:"localPort"` // 0 picks a free port
+	RemotePort int    `json:"remotePort" yaml:"remotePort"`
+}
+
+// Target returns the kubectl resource, e.g. "svc/web"
+func (s PortForwardSpec) Target() string {
+	return s.Kind + "/" + s.Name
+}
+
+// String describes the forward, e.g. "localhost:8080 → default/svc/web:80"
+func (s PortForwardSpec) String() string {
+	return fmt.Sprintf("localhost:%d → %s/%s:%d", s.LocalPort, s.Namespace, s.Target(), s.RemotePort)
+}
+
+// ParseForwardTarget parses "[namespace/](svc|pod)/NAME [LOCAL:]REMOTE" for a
+// cluster. Without a local port the remote port is used locally too, and an
+// empty local port as in ":80" picks a free one.
+func ParseForwardTarget(cluster, input string) (PortForwardSpec, error) {
+	fields := strings.Fields(input)
+	if len(fields) != 2 {
+		return PortForwardSpec{}, fmt.Errorf("expected a target and ports, e.g. default/svc/web 8080:80")
+	}
+
+	spec := PortForwardSpec{Cluster: cluster, Namespace: "default"}
+	parts := strings.Split(fields[0], "/")
+	if len(parts) == 3 {
+		spec.Namespace, parts = parts[0], parts[1:]
+	}
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return PortForwardSpec{}, fmt.Errorf("invalid target %q, expected [namespace/]svc/NAME or [namespace/]pod/NAME", fields[0])
+	}
+	switch parts[0] {
+	case "svc", "service":
+		spec.Kind = "svc"
+	case "pod", "po":
+		spec.Kind = "pod"
+	default:
+		return PortForwardSpec{}, fmt.Errorf("cannot forward to %q, only to services and pods", parts[0])
+	}
+	spec.Name = parts[1]
+
+	local, remote, found := strings.Cut(fields[1], ":")
+	if !found {
+		local, remote = fields[1], fields[1]
+	}
+	var err error
+	if spec.LocalPort, err = parsePort(local, true); err != nil {
+		return PortForwardSpec{}, err
+	}
+	if spec.RemotePort, err = parsePort(remote, false); err != nil {
+		return PortForwardSpec{}, err
+	}
+	return spec, nil
+}
+
+// parsePort parses a port number; a local port may be empty or 0 to pick a free one
+func parsePort(s string, allowZero bool) (int, error) {
+	if s == "" && allowZero {
+		return 0, nil
+	}
+	port, err := strconv.Atoi(s)
+	if err != nil || port < 0 || port > 65535 || (port == 0 && !allowZero) {
+		return 0, fmt.Errorf("invalid port %q", s)
+	}
+	return port, nil
+}
+
+// PortForward is a running forward. ki listens on the local port itself and
+// relays each connection to kubectl port-forward, which lets it count the bytes
+// transferred and keep the local port while kubectl reconnects.
+type PortForward struct {
+	ID      int
+	Spec    PortForwardSpec
+	Started time.Time
+
+	mu      sync.Mutex
+	status  string
+	err     error
+	backend string // address kubectl port-forward listens on
+
+	bytesIn     atomic.Int64 // received from the cluster
+	bytesOut    atomic.Int64 // sent to the cluster
+	connections atomic.Int64 // currently open
+	dropped     atomic.Int64 // closed without reaching kubectl
+
+	listener net.Listener
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+// PortForwardInfo is a snapshot of a forward for display
+type PortForwardInfo struct {
+	ID          int             `json:"id" yaml:"id"`
+	Spec        PortForwardSpec `json:"spec" yaml:"spec"`
+	Status      string          `json:"status" yaml:"status"`
+	Error       string          `json:"error,omitempty" yaml:"error,omitempty"`
+	BytesIn     int64           `json:"bytesIn" yaml:"bytesIn"`
+	BytesOut    int64           `json:"bytesOut" yaml:"bytesOut"`
+	Connections int64           `json:"connections" yaml:"connections"`
+	Dropped     int64           `json:"dropped" yaml:"dropped"`
+	Started     time.Time       `json:"started" yaml:"started"`
+}
+
+// Info returns a snapshot of the forward
+func (f *PortForward) Info() PortForwardInfo {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info := PortForwardInfo{
+		ID:          f.ID,
+		Spec:        f.Spec,
+		Status:      f.status,
+		BytesIn:     f.bytesIn.Load(),
+		BytesOut:    f.bytesOut.Load(),
+		Connections: f.connections.Load(),
+		Dropped:     f.dropped.Load(),
+		Started:     f.Started,
+	}
+	if f.err != nil {
+		info.Error = f.err.Error()
+	}
+	return info
+}
+
+func (f *PortForward) setState(status, backend string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.status == ForwardStopped {
+		return
+	}
+	f.status, f.backend, f.err = status, backend, err
+}
+
+// drop counts a local connection that could not be relayed and keeps the reason
+func (f *PortForward) drop(err error) {
+	f.dropped.Add(1)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.status != ForwardStopped {
+		f.err = err
+	}
+}
+
+// stop closes the local port and ends kubectl
+func (f *PortForward) stop() {
+	f.setState(ForwardStopped, "", nil)
+	f.cancel()
+	f.listener.Close()
+	<-f.done
+}
+
+// keepConnected runs kubectl port-forward until ctx is cancelled, restarting it
+// whenever it exits
+func (f *PortForward) keepConnected(ctx context.Context, connect func(context.Context, *PortForward) error) {
+	defer close(f.done)
+	for {
+		err := connect(ctx, f)
+		if ctx.Err() != nil {
+			return
+		}
+		f.setState(ForwardReconnecting, "", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(forwardRetryDelay):
+		}
+	}
+}
+
+// connectKubectl runs kubectl port-forward on a free local port and waits for it to exit
+func connectKubectl(ctx context.Context, f *PortForward) error {
+	kubeconfig, err := clusterKubeconfig(ctx, f.Spec.Cluster)
+	if err != nil {
+		return err
+	}
+	cmd := command(ctx, "kubectl", forwardArgs(kubeconfig, f.Spec)...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start kubectl port-forward: %w", err)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		if m := forwardingLine.FindStringSubmatch(scanner.Text()); m != nil {
+			f.setState(ForwardActive, "127.0.0.1:"+m[1], nil)
+		}
+	}
+
+	err = cmd.Wait()
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return errors.New(lastLine(msg))
+	}
+	if err == nil {
+		return errors.New("kubectl port-forward exited")
+	}
+	return err
+}
+
+// forwardArgs builds the kubectl arguments forwarding a random local port to the target
+func forwardArgs(kubeconfig string, spec PortForwardSpec) []string {
+	return kubectlArgs(kubeconfig, "port-forward", "--namespace", spec.Namespace,
+		"--address", "127.0.0.1", spec.Target(), fmt.Sprintf(":%d", spec.RemotePort))
+}
+
+// serve accepts local connections until the listener is closed
+func (f *PortForward) serve(ctx context.Context) {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.relay(ctx, conn)
+	}
+}
+
+// waitForBackend returns the address kubectl listens on, waiting for it while
+// kubectl starts or reconnects. It returns "" when forwardBackendWait passes
+// or ctx is done first.
+func (f *PortForward) waitForBackend(ctx context.Context) string {
+	deadline := time.After(forwardBackendWait)
+	for {
+		f.mu.Lock()
+		backend := f.backend
+		f.mu.Unlock()
+		if backend != "" {
+			return backend
+		}
+		select {
+		case <-ctx.Done():
+			return ""
+		case <-deadline:
+			return ""
+		case <-time.After(forwardPollInterval):
+		}
+	}
+}
+
+// relay copies a local connection to kubectl and back, counting the bytes
+func (f *PortForward) relay(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	backend := f.waitForBackend(ctx)
+	if backend == "" {
+		if ctx.Err() == nil {
+			f.drop(fmt.Errorf("dropped a connection: kubectl port-forward not ready after %s", forwardBackendWait))
+		}
+		return
+	}
+
+	remote, err := net.Dial("tcp", backend)
+	if err != nil {
+		f.drop(fmt.Errorf("dropped a connection: %w", err))
+		return
+	}
+	defer remote.Close()
+
+	f.connections.Add(1)
+	defer f.connections.Add(-1)
+
+	done := make(chan struct{})
+	go func() {
+		io.Copy(&countingWriter{w: remote, n: &f.bytesOut}, conn)
+		closeWrite(remote)
+		close(done)
+	}()
+	io.Copy(&countingWriter{w: conn, n: &f.bytesIn}, remote)
+	closeWrite(conn)
+	<-done
+}
+
+// closeWrite signals the end of the stream while still reading the other direction
+func closeWrite(conn net.Conn) {
+	if tcp, ok := conn.(*net.TCPConn); ok {
+		tcp.CloseWrite()
+	}
+}
+
+type countingWriter struct {
+	w io.Writer
+	n *atomic.Int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n.Add(int64(n))
+	return n, err
+}
+
+// PortForwards keeps track of the forwards running while ki is open
+type PortForwards struct {
+	mu       sync.Mutex
+	forwards []*PortForward
+	nextID   int
+
+	// connect runs one kubectl port-forward session; tests replace it
+	connect func(context.Context, *PortForward) error
+}
+
+// NewPortForwards creates an empty set of forwards
+func NewPortForwards() *PortForwards {
+	return &PortForwards{connect: connectKubectl}
+}
+
+// Forwards are the port forwards of this ki process
+var Forwards = NewPortForwards()
+
+// Start claims the local port and starts forwarding it in the background. It
+// fails when the port is taken, by another forward or any other program.
+func (p *PortForwards) Start(spec PortForwardSpec) (PortForwardInfo, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, f := range p.forwards {
+		if spec.LocalPort != 0 && f.Spec.LocalPort == spec.LocalPort {
+			return PortForwardInfo{}, fmt.Errorf("local port %d is already forwarded to %s", spec.LocalPort, f.Spec.Target())
+		}
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", spec.LocalPort))
+	if err != nil {
+		return PortForwardInfo{}, fmt.Errorf("local port %d is already in use", spec.LocalPort)
+	}
+	spec.LocalPort = listener.Addr().(*net.TCPAddr).Port
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.nextID++
+	f := &PortForward{
+		ID:       p.nextID,
+		Spec:     spec,
+		Started:  time.Now(),
+		status:   ForwardStarting,
+		listener: listener,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	p.forwards = append(p.forwards, f)
+
+	go f.serve(ctx)
+	go f.keepConnected(ctx, p.connect)
+	return f.Info(), nil
+}
+
+// Stop ends a forward and frees its local port
+func (p *PortForwards) Stop(id int) (PortForwardInfo, error) {
+	p.mu.Lock()
+	var forward *PortForward
+	for i, f := range p.forwards {
+		if f.ID == id {
+			forward = f
+			p.forwards = append(p.forwards[:i], p.forwards[i+1:]...)
+			break
+		}
+	}
+	p.mu.Unlock()
+
+	if forward == nil {
+		return PortForwardInfo{}, fmt.Errorf("port forward %d not found", id)
+	}
+	forward.stop()
+	return forward.Info(), nil
+}
+
+// StopAll ends every forward
+func (p *PortForwards) StopAll() {
+	p.mu.Lock()
+	forwards := p.forwards
+	p.forwards = nil
+	p.mu.Unlock()
+
+	for _, f := range forwards {
+		f.stop()
+	}
+}
+
+// List returns a snapshot of every forward, oldest first
+func (p *PortForwards) List() []PortForwardInfo {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	infos := make([]PortForwardInfo, len(p.forwards))
+	for i, f := range p.forwards {
+		infos[i] = f.Info()
+	}
+	return infos
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return lines[len(lines)-1]
+}
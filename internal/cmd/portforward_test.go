@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseForwardTarget(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected PortForwardSpec
+		wantErr  bool
+	}{
+		{"svc/web 8080:80", PortForwardSpec{Cluster: "dev", Namespace: "default", Kind: "svc", Name: "web", LocalPort: 8080, RemotePort: 80}, false},
+		{"monitoring/service/grafana 3000", PortForwardSpec{Cluster: "dev", Namespace: "monitoring", Kind: "svc", Name: "grafana", LocalPort: 3000, RemotePort: 3000}, false},
+		{"kube-system/pod/coredns-abc :9153", PortForwardSpec{Cluster: "dev", Namespace: "kube-system", Kind: "pod", Name: "coredns-abc", LocalPort: 0, RemotePort: 9153}, false},
+		{"svc/web", PortForwardSpec{}, true},
+		{"deployment/web 80", PortForwardSpec{}, true},
+		{"svc/ 80", PortForwardSpec{}, true},
+		{"svc/web 8080:0", PortForwardSpec{}, true},
+		{"svc/web 70000", PortForwardSpec{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			spec, err := ParseForwardTarget("dev", tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseForwardTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if spec != tt.expected {
+				t.Errorf("ParseForwardTarget() = %+v, expected %+v", spec, tt.expected)
+			}
+		})
+	}
+}
+
+func TestForwardArgs(t *testing.T) {
+	spec := PortForwardSpec{Cluster: "dev", Namespace: "default", Kind: "svc", Name: "web", LocalPort: 8080, RemotePort: 80}
+	expected := "--kubeconfig /tmp/dev port-forward --namespace default --address 127.0.0.1 svc/web :80"
+	if got := strings.Join(forwardArgs("/tmp/dev", spec), " "); got != expected {
+		t.Errorf("forwardArgs() = %q, expected %q", got, expected)
+	}
+	if got := spec.String(); got != "localhost:8080 → default/svc/web:80" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+// echoServer stands in for kubectl port-forward
+func echoServer(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func newTestForwards(t *testing.T) *PortForwards {
+	backend := echoServer(t)
+	forwards := &PortForwards{connect: func(ctx context.Context, f *PortForward) error {
+		f.setState(ForwardActive, backend, nil)
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	t.Cleanup(forwards.StopAll)
+	return forwards
+}
+
+func waitForStatus(t *testing.T, forwards *PortForwards, status string) PortForwardInfo {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if list := forwards.List(); len(list) > 0 && list[0].Status == status {
+			return list[0]
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("forward did not become %s: %+v", status, forwards.List())
+	return PortForwardInfo{}
+}
+
+func TestPortForwardsRelay(t *testing.T) {
+	forwards := newTestForwards(t)
+
+	info, err := forwards.Start(PortForwardSpec{Cluster: "dev", Namespace: "default", Kind: "svc", Name: "web", RemotePort: 80})
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if info.Spec.LocalPort == 0 {
+		t.Fatal("Start() should pick a free local port")
+	}
+	waitForStatus(t, forwards, ForwardActive)
+
+	conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", info.Spec.LocalPort))
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	fmt.Fprint(conn, "ping")
+	reply := make([]byte, 4)
+	if _, err := io.ReadFull(conn, reply); err != nil || string(reply) != "ping" {
+		t.Fatalf("Expected the echo through the forward, got %q, %v", reply, err)
+	}
+	conn.Close()
+
+	info = waitForStatus(t, forwards, ForwardActive)
+	if info.BytesOut != 4 || info.BytesIn != 4 {
+		t.Errorf("Expected 4 bytes each way, got out=%d in=%d", info.BytesOut, info.BytesIn)
+	}
+}
+
+func TestPortForwardsConflicts(t *testing.T) {
+	forwards := newTestForwards(t)
+
+	info, err := forwards.Start(PortForwardSpec{Namespace: "default", Kind: "svc", Name: "web", RemotePort: 80})
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	port := info.Spec.LocalPort
+
+	_, err = forwards.Start(PortForwardSpec{Namespace: "default", Kind: "svc", Name: "api", LocalPort: port, RemotePort: 80})
+	if err == nil || !strings.Contains(err.Error(), "already forwarded to svc/web") {
+		t.Errorf("Expected a conflict with the first forward, got %v", err)
+	}
+
+	// A port taken by another program
+	other, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	taken := other.Addr().(*net.TCPAddr).Port
+	_, err = forwards.Start(PortForwardSpec{Namespace: "default", Kind: "svc", Name: "api", LocalPort: taken, RemotePort: 80})
+	if err == nil || !strings.Contains(err.Error(), "already in use") {
+		t.Errorf("Expected the port to be in use, got %v", err)
+	}
+
+	// Stopping frees the port
+	if _, err := forwards.Stop(info.ID); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if len(forwards.List()) != 0 {
+		t.Errorf("Expected no forwards after Stop(), got %+v", forwards.List())
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		t.Errorf("Stop() should free local port %d: %v", port, err)
+	} else {
+		listener.Close()
+	}
+	if _, err := forwards.Stop(info.ID); err == nil {
+		t.Error("Stopping a forward twice should fail")
+	}
+}
+
+func TestPortForwardsReconnect(t *testing.T) {
+	attempts := make(chan struct{}, 10)
+	forwards := &PortForwards{connect: func(ctx context.Context, f *PortForward) error {
+		attempts <- struct{}{}
+		return fmt.Errorf("pod web not found")
+	}}
+	defer forwards.StopAll()
+
+	if _, err := forwards.Start(PortForwardSpec{Namespace: "default", Kind: "pod", Name: "web", RemotePort: 80}); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	info := waitForStatus(t, forwards, ForwardReconnecting)
+	if info.Error != "pod web not found" {
+		t.Errorf("Expected the kubectl error, got %q", info.Error)
+	}
+
+	forwards.StopAll()
+	if len(forwards.List()) != 0 {
+		t.Error("StopAll() should remove every forward")
+	}
+}
+
+func TestPortForwardsWaitForBackend(t *testing.T) {
+	backend := echoServer(t)
+	forwards := &PortForwards{connect: func(ctx context.Context, f *PortForward) error {
+		// kubectl takes a moment to report the port it listens on
+		time.Sleep(200 * time.Millisecond)
+		f.setState(ForwardActive, backend, nil)
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	defer forwards.StopAll()
+
+	info, err := forwards.Start(PortForwardSpec{Namespace: "default", Kind: "svc", Name: "web", RemotePort: 80})
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	// Connect before kubectl is ready
+	conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", info.Spec.LocalPort))
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+	fmt.Fprint(conn, "ping")
+	reply := make([]byte, 4)
+	if _, err := io.ReadFull(conn, reply); err != nil || string(reply) != "ping" {
+		t.Fatalf("Expected the echo once kubectl is ready, got %q, %v", reply, err)
+	}
+	if info := forwards.List()[0]; info.Dropped != 0 {
+		t.Errorf("Expected no dropped connections, got %d", info.Dropped)
+	}
+}
+
+func TestPortForwardsDropWithoutBackend(t *testing.T) {
+	original := forwardBackendWait
+	forwardBackendWait = 100 * time.Millisecond
+	defer func() { forwardBackendWait = original }()
+
+	forwards := &PortForwards{connect: func(ctx context.Context, f *PortForward) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	defer forwards.StopAll()
+
+	info, err := forwards.Start(PortForwardSpec{Namespace: "default", Kind: "svc", Name: "web", RemotePort: 80})
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	conn, err := net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", info.Spec.LocalPort))
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Read(make([]byte, 1)); err == nil {
+		t.Error("Expected the connection to be closed without a backend")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for forwards.List()[0].Dropped == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	info = forwards.List()[0]
+	if info.Dropped != 1 || !strings.Contains(info.Error, "not ready") {
+		t.Errorf("Expected the dropped connection to be recorded, got %+v", info)
+	}
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Container runtimes KIND can run nodes in
+const (
+	ProviderDocker  = "docker"
+	ProviderPodman  = "podman"
+	ProviderNerdctl = "nerdctl"
+)
+
+// ProviderEnv is the variable KIND reads to pick a non-docker provider
+const ProviderEnv = "KIND_EXPERIMENTAL_PROVIDER"
+
+// Providers lists the supported providers in detection order
+var Providers = []string{ProviderDocker, ProviderPodman, ProviderNerdctl}
+
+// DetectProvider returns the provider named by KIND_EXPERIMENTAL_PROVIDER, or
+// the first supported runtime found on the PATH, falling back to docker
+func DetectProvider(getenv func(string) string, lookPath func(string) (string, error)) string {
+	if p := strings.TrimSpace(getenv(ProviderEnv)); p != "" {
+		if err := validateProvider(p); err == nil {
+			return p
+		}
+	}
+	for _, p := range Providers {
+		if _, err := lookPath(p); err == nil {
+			return p
+		}
+	}
+	return ProviderDocker
+}
+
+// SetProvider selects the provider used for every KIND and container command
+func SetProvider(name string) error {
+	if err := validateProvider(name); err != nil {
+		return err
+	}
+	ActiveProvider = name
+	return nil
+}
+
+func validateProvider(name string) error {
+	for _, p := range Providers {
+		if p == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown provider %q, expected one of %s", name, strings.Join(Providers, ", "))
+}
+
+// kindCommand creates a kind command that uses the active provider
+func kindCommand(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := command(ctx, "kind", args...)
+	cmd.Env = kindEnv(os.Environ())
+	return cmd
+}
+
+// kindEnv returns environ with the provider variable set to the active provider
+func kindEnv(environ []string) []string {
+	env := make([]string, 0, len(environ)+1)
+	for _, kv := range environ {
+		if !strings.HasPrefix(kv, ProviderEnv+"=") {
+			env = append(env, kv)
+		}
+	}
+	return append(env, ProviderEnv+"="+ActiveProvider)
+}
+
+// loadImageViaArchive saves an image from the active runtime and loads the archive
+// into the cluster, for providers where kind load docker-image is not available
+func loadImageViaArchive(ctx context.Context, imageName, clusterName string, nodes []string, out io.Writer) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "ki-image-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	archive := filepath.Join(dir, "image.tar")
+	if output, err := run(ctx, out, ActiveProvider, "save", "-o", archive, imageName); err != nil {
+		return output, err
+	}
+	return loadImageArchive(ctx, archive, clusterName, nodes, out)
+}
+
+// loadImageArchive loads an image tarball into the cluster
+func loadImageArchive(ctx context.Context, archive, clusterName string, nodes []string, out io.Writer) ([]byte, error) {
+	args := append([]string{"load", "image-archive", archive}, loadTargetArgs(clusterName, nodes)...)
+	return runKind(ctx, out, args...)
+}
+
+// loadTargetArgs selects the cluster and nodes of kind load. No nodes loads
+// into every node of the cluster.
+func loadTargetArgs(clusterName string, nodes []string) []string {
+	var args []string
+	if clusterName != "" {
+		args = append(args, "--name", clusterName)
+	}
+	if len(nodes) > 0 {
+		args = append(args, "--nodes", strings.Join(nodes, ","))
+	}
+	return args
+}
+
+// ActiveProvider is the provider used for KIND and container commands
+var ActiveProvider = DetectProvider(os.Getenv, exec.LookPath)
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDetectProvider(t *testing.T) {
+	tests := []struct {
+		name      string
+		env       string
+		installed []string
+		expected  string
+	}{
+		{"explicit podman", "podman", []string{"docker", "podman"}, ProviderPodman},
+		{"explicit nerdctl", "nerdctl", nil, ProviderNerdctl},
+		{"invalid env falls back to detection", "lxc", []string{"podman"}, ProviderPodman},
+		{"docker preferred when several are installed", "", []string{"nerdctl", "docker"}, ProviderDocker},
+		{"only podman installed", "", []string{"podman"}, ProviderPodman},
+		{"nothing installed", "", nil, ProviderDocker},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string {
+				if key == ProviderEnv {
+					return tt.env
+				}
+				return ""
+			}
+			lookPath := func(file string) (string, error) {
+				for _, installed := range tt.installed {
+					if installed == file {
+						return "/usr/bin/" + file, nil
+					}
+				}
+				return "", errors.New("not found")
+			}
+
+			if result := DetectProvider(getenv, lookPath); result != tt.expected {
+				t.Errorf("DetectProvider() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetProvider(t *testing.T) {
+	original := ActiveProvider
+	defer func() { ActiveProvider = original }()
+
+	if err := SetProvider("podman"); err != nil || ActiveProvider != ProviderPodman {
+		t.Errorf("SetProvider(podman) = %v, active %s", err, ActiveProvider)
+	}
+	if err := SetProvider("lxc"); err == nil {
+		t.Error("SetProvider() should reject unknown providers")
+	}
+	if ActiveProvider != ProviderPodman {
+		t.Error("SetProvider() should keep the previous provider on error")
+	}
+}
+
+func TestKindEnv(t *testing.T) {
+	original := ActiveProvider
+	defer func() { ActiveProvider = original }()
+	ActiveProvider = ProviderNerdctl
+
+	env := kindEnv([]string{"PATH=/usr/bin", ProviderEnv + "=docker"})
+	count := 0
+	for _, kv := range env {
+		if strings.HasPrefix(kv, ProviderEnv+"=") {
+			count++
+			if kv != ProviderEnv+"=nerdctl" {
+				t.Errorf("kindEnv() set %s, expected the active provider", kv)
+			}
+		}
+	}
+	if count != 1 {
+		t.Errorf("kindEnv() should set %s exactly once, got %d", ProviderEnv, count)
+	}
+	if env[0] != "PATH=/usr/bin" {
+		t.Error("kindEnv() should keep the rest of the environment")
+	}
+}
+
+func TestContainerFormat(t *testing.T) {
+	if !strings.Contains(containerFormat(ProviderDocker), `.Label "io.x-k8s.kind.role"`) {
+		t.Error("docker format should print the role label")
+	}
+	if !strings.Contains(containerFormat(ProviderPodman), `index .Labels`) {
+		t.Error("podman format should index the labels map")
+	}
+	if strings.Contains(containerFormat(ProviderNerdctl), ".State") {
+		t.Error("nerdctl has no State field")
+	}
+}
+
+func TestLoadTargetArgs(t *testing.T) {
+	tests := []struct {
+		cluster  string
+		nodes    []string
+		expected string
+	}{
+		{"", nil, ""},
+		{"dev", nil, "--name dev"},
+		{"dev", []string{"dev-worker"}, "--name dev --nodes dev-worker"},
+		{"", []string{"kind-worker", "kind-worker2"}, "--nodes kind-worker,kind-worker2"},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(loadTargetArgs(tt.cluster, tt.nodes), " "); got != tt.expected {
+			t.Errorf("loadTargetArgs(%q, %v) = %q, expected %q", tt.cluster, tt.nodes, got, tt.expected)
+		}
+	}
+}
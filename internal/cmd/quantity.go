@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// quantitySuffixes are the multipliers of Kubernetes resource quantities,
+// binary ones first so "Mi" is not read as "M"
+var quantitySuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40}, {"Pi", 1 << 50}, {"Ei", 1 << 60},
+	{"n", 1e-9}, {"u", 1e-6}, {"m", 1e-3},
+	{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12}, {"P", 1e15}, {"E", 1e18},
+}
+
+// ParseQuantity parses a Kubernetes resource quantity such as 250m, 1.5 or
+// 128Mi into its value in base units: cores for CPU, bytes for memory
+func ParseQuantity(s string) (float64, error) {
+	number, multiplier := strings.TrimSpace(s), 1.0
+	for _, q := range quantitySuffixes {
+		if n, found := strings.CutSuffix(number, q.suffix); found {
+			number, multiplier = n, q.multiplier
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q", s)
+	}
+	return value * multiplier, nil
+}
+
+// FormatCPU formats cores the way kubectl does: millicores below one core
+func FormatCPU(cores float64) string {
+	if cores < 1 {
+		return fmt.Sprintf("%dm", int64(cores*1000+0.5))
+	}
+	return strconv.FormatFloat(cores, 'f', -1, 64)
+}
+
+// FormatBytes formats a size with the largest binary unit that keeps it above one
+func FormatBytes(bytes float64) string {
+	if bytes < 1<<10 {
+		return fmt.Sprintf("%d", int64(bytes))
+	}
+	unit := ""
+	for _, u := range []string{"Ki", "Mi", "Gi", "Ti", "Pi"} {
+		if bytes < 1<<10 {
+			break
+		}
+		bytes /= 1 << 10
+		unit = u
+	}
+	return strings.TrimSuffix(strconv.FormatFloat(bytes, 'f', 1, 64), ".0") + unit
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import "testing"
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+		wantErr  bool
+	}{
+		{"250m", 0.25, false},
+		{"2", 2, false},
+		{"1.5", 1.5, false},
+		{"128Mi", 128 << 20, false},
+		{"1Gi", 1 << 30, false},
+		{"16310128Ki", 16310128 << 10, false},
+		{"500M", 500e6, false},
+		{"1e3", 1000, false},
+		{"", 0, true},
+		{"lots", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseQuantity(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseQuantity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseQuantity() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatQuantities(t *testing.T) {
+	cpu := map[float64]string{0.25: "250m", 0.95: "950m", 1: "1", 8: "8", 1.5: "1.5"}
+	for cores, expected := range cpu {
+		if got := FormatCPU(cores); got != expected {
+			t.Errorf("FormatCPU(%v) = %q, expected %q", cores, got, expected)
+		}
+	}
+
+	bytes := map[float64]string{512: "512", 128 << 20: "128Mi", 1.5 * (1 << 30): "1.5Gi", 16310128 << 10: "15.6Gi"}
+	for b, expected := range bytes {
+		if got := FormatBytes(b); got != expected {
+			t.Errorf("FormatBytes(%v) = %q, expected %q", b, got, expected)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Local registry settings, following KIND's local registry guide. One registry
+// container is shared by every cluster it is attached to.
+const (
+	RegistryName = "kind-registry"
+	RegistryPort = 5001 // the port the registry is published on at 127.0.0.1
+
+	registryImage       = "registry:2"
+	registryNetwork     = "kind" // the network KIND puts node containers on
+	registryConfigMap   = "local-registry-hosting"
+	registryCertsDir    = "/etc/containerd/certs.d"
+	containerdConfig    = "/etc/containerd/config.toml"
+	registryHostingHelp = "https://kind.sigs.k8s.io/docs/user/local-registry/"
+)
+
+// RegistryContainerdPatch makes containerd read the per-registry hosts.toml
+// files AttachRegistry writes into each node
+const RegistryContainerdPatch = `[plugins."io.containerd.grpc.v1.cri".registry]
+  config_path = "` + registryCertsDir + `"`
+
+// RegistryHost is the address images are pushed to and pulled from, on the
+// host and inside the cluster alike
+var RegistryHost = fmt.Sprintf("localhost:%d", RegistryPort)
+
+// Registry describes the state of the shared registry container
+type Registry struct {
+	Name    string `json:"name" yaml:"name"`
+	Host    string `json:"host" yaml:"host"`
+	Exists  bool   `json:"exists" yaml:"exists"`
+	Running bool   `json:"running" yaml:"running"`
+}
+
+// GetRegistry inspects the registry container; a missing container is not an error
+func GetRegistry(ctx context.Context) Registry {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	registry := Registry{Name: RegistryName, Host: RegistryHost}
+	output, err := command(ctx, ActiveProvider, "inspect", "--format", "{{.State.Running}}", RegistryName).Output()
+	if err != nil {
+		return registry
+	}
+	registry.Exists = true
+	registry.Running = strings.TrimSpace(string(output)) == "true"
+	return registry
+}
+
+// CreateRegistry creates the registry container, or starts it when it is stopped
+func CreateRegistry(ctx context.Context, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, RegistryTimeout)
+	defer cancel()
+
+	if err := ensureRegistry(ctx, out); err != nil {
+		return fmt.Errorf("failed to create registry: %w", err)
+	}
+	return nil
+}
+
+func ensureRegistry(ctx context.Context, out io.Writer) error {
+	registry := GetRegistry(ctx)
+	switch {
+	case !registry.Exists:
+		return runStep(ctx, out, "Creating registry "+RegistryName, ActiveProvider, registryRunArgs()...)
+	case !registry.Running:
+		return runStep(ctx, out, "Starting registry "+RegistryName, ActiveProvider, "start", RegistryName)
+	}
+	step(out, "✓", "Registry "+RegistryName+" is running")
+	return nil
+}
+
+// registryRunArgs builds the arguments starting the registry container
+func registryRunArgs() []string {
+	return []string{"run", "-d", "--restart=always",
+		"-p", fmt.Sprintf("127.0.0.1:%d:5000", RegistryPort),
+		"--network", "bridge", "--name", RegistryName, registryImage}
+}
+
+// AttachRegistry makes the registry available to a cluster: it creates the
+// registry if needed, points containerd on every node at it, connects it to the
+// KIND network and documents it in the local-registry-hosting ConfigMap.
+// containerd only reads the node configuration when it runs with
+// RegistryContainerdPatch, which clusters created with a registry have; other
+// clusters are refused before anything is changed.
+func AttachRegistry(ctx context.Context, clusterName string, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, RegistryTimeout)
+	defer cancel()
+
+	if err := attachRegistry(ctx, clusterName, out); err != nil {
+		return fmt.Errorf("failed to attach registry to %s: %w", clusterName, err)
+	}
+	return nil
+}
+
+func attachRegistry(ctx context.Context, clusterName string, out io.Writer) error {
+	nodes, err := registryNodes(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if err := checkRegistryConfigPath(ctx, node); err != nil {
+			return err
+		}
+	}
+
+	if err := ensureRegistry(ctx, out); err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if err := runStep(ctx, out, "Configuring registry on "+node, ActiveProvider, registryHostsArgs(node)...); err != nil {
+			return err
+		}
+	}
+
+	if !registryConnected(ctx) {
+		if err := runStep(ctx, out, "Connecting registry to the "+registryNetwork+" network", ActiveProvider,
+			"network", "connect", registryNetwork, RegistryName); err != nil {
+			return err
+		}
+	}
+
+	kubeconfig, err := clusterKubeconfig(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	return runStepInput(ctx, out, "Publishing registry in "+registryConfigMap, registryHostingManifest(),
+		"kubectl", kubectlArgs(kubeconfig, "apply", "-f", "-")...)
+}
+
+// DetachRegistry removes the registry configuration from a cluster. The
+// registry keeps running for the other clusters using it.
+func DetachRegistry(ctx context.Context, clusterName string, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, RegistryTimeout)
+	defer cancel()
+
+	if err := detachRegistry(ctx, clusterName, out); err != nil {
+		return fmt.Errorf("failed to detach registry from %s: %w", clusterName, err)
+	}
+	return nil
+}
+
+func detachRegistry(ctx context.Context, clusterName string, out io.Writer) error {
+	nodes, err := registryNodes(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	for _, node := range nodes {
+		if err := runStep(ctx, out, "Removing registry from "+node, ActiveProvider,
+			"exec", node, "rm", "-rf", registryHostsDir()); err != nil {
+			return err
+		}
+	}
+
+	kubeconfig, err := clusterKubeconfig(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	return runStep(ctx, out, "Removing "+registryConfigMap, "kubectl", kubectlArgs(kubeconfig,
+		"delete", "configmap", registryConfigMap, "--namespace", "kube-public", "--ignore-not-found")...)
+}
+
+// ClusterRegistry returns the registry host a cluster advertises in its
+// local-registry-hosting ConfigMap, or "" when it has none
+func ClusterRegistry(ctx context.Context, clusterName string) string {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	cmd, err := kubectl(ctx, clusterName, "get", "configmap", registryConfigMap,
+		"--namespace", "kube-public", "--ignore-not-found",
+		"--output", `jsonpath={.data.localRegistryHosting\.v1}`)
+	if err != nil {
+		return ""
+	}
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return ParseRegistryHosting(string(output))
+}
+
+// ParseRegistryHosting returns the host of a localRegistryHosting.v1 document
+func ParseRegistryHosting(doc string) string {
+	for _, line := range strings.Split(doc, "\n") {
+		if value, found := strings.CutPrefix(strings.TrimSpace(line), "host:"); found {
+			return strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	return ""
+}
+
+// registryNodes returns the node containers of a cluster that run containerd
+func registryNodes(ctx context.Context, clusterName string) ([]string, error) {
+	containers, err := GetNodeContainers(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	var nodes []string
+	for _, c := range containers {
+		if c.Role != RoleLoadBalancer {
+			nodes = append(nodes, c.Name)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no node containers found for cluster %s", clusterName)
+	}
+	return nodes, nil
+}
+
+// checkRegistryConfigPath makes sure containerd on a node reads the hosts.toml
+// files in registryCertsDir, without which pulls would never reach the registry
+func checkRegistryConfigPath(ctx context.Context, node string) error {
+	output, err := command(ctx, ActiveProvider, "exec", node, "cat", containerdConfig).Output()
+	if err != nil {
+		return fmt.Errorf("failed to read the containerd config of %s: %w", node, commandError(ctx, err))
+	}
+	if !ContainerdReadsCertsDir(string(output)) {
+		return fmt.Errorf("containerd on %s does not read %s; recreate the cluster with the registry to use it",
+			node, registryCertsDir)
+	}
+	return nil
+}
+
+// ContainerdReadsCertsDir reports whether a containerd config sets the
+// registry config_path to registryCertsDir
+func ContainerdReadsCertsDir(config string) bool {
+	for _, line := range strings.Split(config, "\n") {
+		key, value, found := strings.Cut(line, "=")
+		if found && strings.TrimSpace(key) == "config_path" &&
+			strings.Trim(strings.TrimSpace(value), `"'`) == registryCertsDir {
+			return true
+		}
+	}
+	return false
+}
+
+// registryConnected reports whether the registry container is on the KIND network
+func registryConnected(ctx context.Context) bool {
+	output, err := command(ctx, ActiveProvider, "inspect", "--format",
+		"{{range $name, $_ := .NetworkSettings.Networks}}{{$name}} {{end}}", RegistryName).Output()
+	if err != nil {
+		return false
+	}
+	for _, network := range strings.Fields(string(output)) {
+		if network == registryNetwork {
+			return true
+		}
+	}
+	return false
+}
+
+// registryHostsDir is the containerd configuration directory for RegistryHost
+func registryHostsDir() string {
+	return path.Join(registryCertsDir, RegistryHost)
+}
+
+// registryHostsArgs builds the arguments writing a hosts.toml into a node that
+// sends pulls of RegistryHost to the registry container
+func registryHostsArgs(node string) []string {
+	hosts := fmt.Sprintf("[host.\"http://%s:5000\"]\n", RegistryName)
+	return []string{"exec", node, "sh", "-c", `mkdir -p "$1" && printf '%s' "$2" > "$1/hosts.toml"`,
+		"sh", registryHostsDir(), hosts}
+}
+
+// registryHostingManifest is the ConfigMap documenting the registry for tools
+// running in the cluster, see KEP-1755
+func registryHostingManifest() string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: %s
+  namespace: kube-public
+data:
+  localRegistryHosting.v1: |
+    host: "%s"
+    help: "%s"
+`, registryConfigMap, RegistryHost, registryHostingHelp)
+}
+
+// runStepInput runs a command reading input on stdin as a named step
+func runStepInput(ctx context.Context, out io.Writer, stepName, input, name string, args ...string) error {
+	step(out, "•", stepName+" ...")
+	cmd := command(ctx, name, args...)
+	cmd.Stdin = strings.NewReader(input)
+	output, err := runCommand(ctx, cmd, nil)
+	if err != nil {
+		step(out, "✗", stepName)
+		return fmt.Errorf("%s: %w\n%s", stepName, err, string(output))
+	}
+	step(out, "✓", stepName)
+	return nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistryArgs(t *testing.T) {
+	run := strings.Join(registryRunArgs(), " ")
+	expected := "run -d --restart=always -p 127.0.0.1:5001:5000 --network bridge --name kind-registry registry:2"
+	if run != expected {
+		t.Errorf("registryRunArgs() = %q, expected %q", run, expected)
+	}
+
+	args := registryHostsArgs("dev-worker")
+	if args[0] != "exec" || args[1] != "dev-worker" {
+		t.Errorf("registryHostsArgs() should exec in the node, got %v", args)
+	}
+	if dir := args[len(args)-2]; dir != "/etc/containerd/certs.d/localhost:5001" {
+		t.Errorf("registryHostsArgs() dir = %q", dir)
+	}
+	if hosts := args[len(args)-1]; hosts != "[host.\"http://kind-registry:5000\"]\n" {
+		t.Errorf("registryHostsArgs() hosts.toml = %q", hosts)
+	}
+}
+
+func TestParseRegistryHosting(t *testing.T) {
+	tests := []struct {
+		doc      string
+		expected string
+	}{
+		{doc: "host: \"localhost:5001\"\nhelp: \"https://kind.sigs.k8s.io/docs/user/local-registry/\"\n", expected: "localhost:5001"},
+		{doc: "  host: localhost:5002", expected: "localhost:5002"},
+		{doc: "", expected: ""},
+	}
+	for _, tt := range tests {
+		if got := ParseRegistryHosting(tt.doc); got != tt.expected {
+			t.Errorf("ParseRegistryHosting(%q) = %q, expected %q", tt.doc, got, tt.expected)
+		}
+	}
+
+	if got := ParseRegistryHosting(registryHostingManifest()); got != RegistryHost {
+		t.Errorf("ParseRegistryHosting() of the published manifest = %q, expected %q", got, RegistryHost)
+	}
+}
+
+func TestRenderClusterConfigRegistry(t *testing.T) {
+	config := NewClusterConfig("dev", 1, 0, "")
+	config.ContainerdConfigPatches = []string{"[plugins]\n"}
+	config.Registry = true
+
+	out, err := RenderClusterConfig(config)
+	if err != nil {
+		t.Fatalf("RenderClusterConfig() error = %v", err)
+	}
+	result := string(out)
+
+	if !strings.Contains(result, `config_path = "/etc/containerd/certs.d"`) {
+		t.Errorf("RenderClusterConfig() should point containerd at the registry config.\nGot:\n%s", result)
+	}
+	if strings.Contains(result, "registry: true") {
+		t.Errorf("RenderClusterConfig() should not pass the registry setting to KIND.\nGot:\n%s", result)
+	}
+	if len(config.ContainerdConfigPatches) != 1 {
+		t.Errorf("RenderClusterConfig() should not modify the config, got patches %v", config.ContainerdConfigPatches)
+	}
+}
+
+func TestContainerdReadsCertsDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		expected bool
+	}{
+		{"patched", "[plugins.\"io.containerd.grpc.v1.cri\".registry]\n  config_path = \"/etc/containerd/certs.d\"\n", true},
+		{"containerd 2", "[plugins.'io.containerd.cri.v1.images'.registry]\nconfig_path='/etc/containerd/certs.d'\n", true},
+		{"other path", "  config_path = \"/etc/docker/certs.d\"\n", false},
+		{"unpatched", "version = 2\n[plugins.\"io.containerd.grpc.v1.cri\"]\n  sandbox_image = \"registry.k8s.io/pause:3.9\"\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ContainerdReadsCertsDir(tt.config); got != tt.expected {
+				t.Errorf("ContainerdReadsCertsDir() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+
+	if !ContainerdReadsCertsDir(RegistryContainerdPatch) {
+		t.Error("ContainerdReadsCertsDir() should accept RegistryContainerdPatch")
+	}
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"context"
+	"os/exec"
+)
+
+// podShell starts bash when the image has it and falls back to sh
+const podShell = "command -v bash >/dev/null 2>&1 && exec bash || exec sh"
+
+// NodeShellCommand returns the command opening an interactive shell in a node container
+func NodeShellCommand(node string) *exec.Cmd {
+	return exec.Command(ActiveProvider, "exec", "-it", node, "bash")
+}
+
+// PodShellCommand returns the command opening an interactive shell in a pod
+// container, or in the pod's default container when container is empty
+func PodShellCommand(ctx context.Context, cluster, namespace, pod, container string) (*exec.Cmd, error) {
+	kubeconfig, err := clusterKubeconfig(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	args := kubectlArgs(kubeconfig, "exec", "-it", "--namespace", namespace, pod)
+	if container != "" {
+		args = append(args, "--container", container)
+	}
+	args = append(args, "--", "sh", "-c", podShell)
+	return exec.Command("kubectl", args...), nil
+}
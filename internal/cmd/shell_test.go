@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNodeShellCommand(t *testing.T) {
+	original := ActiveProvider
+	defer func() { ActiveProvider = original }()
+	ActiveProvider = ProviderPodman
+
+	c := NodeShellCommand("dev-control-plane")
+	if got := strings.Join(c.Args, " "); got != "podman exec -it dev-control-plane bash" {
+		t.Errorf("NodeShellCommand() = %q", got)
+	}
+}
+
+func TestPodShellCommand(t *testing.T) {
+	cacheKubeconfig(t, "dev", "/tmp/dev")
+
+	c, err := PodShellCommand(context.Background(), "dev", "default", "web-1", "sidecar")
+	if err != nil {
+		t.Fatalf("PodShellCommand() error = %v", err)
+	}
+	expected := "kubectl --kubeconfig /tmp/dev exec -it --namespace default web-1 --container sidecar -- sh -c " + podShell
+	if got := strings.Join(c.Args, " "); got != expected {
+		t.Errorf("PodShellCommand() = %q, expected %q", got, expected)
+	}
+
+	c, _ = PodShellCommand(context.Background(), "dev", "default", "web-1", "")
+	if strings.Contains(strings.Join(c.Args, " "), "--container") {
+		t.Errorf("PodShellCommand() without a container should use the default one, got %v", c.Args)
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Cluster states derived from the node containers and the API server
+const (
+	StatusRunning     = "running"     // every node container is up and the API server is ready
+	StatusStopped     = "stopped"     // no node container is running
+	StatusDegraded    = "degraded"    // some node containers are not running
+	StatusUnreachable = "unreachable" // the containers are up but the API server is not ready
+	StatusUnknown     = "unknown"     // the container runtime could not be queried
+)
+
+// HealthTimeout bounds the API server readiness probe
+const HealthTimeout = 5 * time.Second
+
+// kindClusterLabel is set by KIND on every node container of a cluster
+const kindClusterLabel = "io.x-k8s.kind.cluster"
+
+// kindRoleLabel is set by KIND on every node container to the node's role
+const kindRoleLabel = "io.x-k8s.kind.role"
+
+// Container is a node container of a KIND cluster
+type Container struct {
+	Name  string `json:"name" yaml:"name"`
+	Role  string `json:"role" yaml:"role"`
+	State string `json:"state" yaml:"state"`
+}
+
+// Running reports whether the container is up
+func (c Container) Running() bool {
+	return c.State == "running"
+}
+
+// GetNodeContainers lists the node containers of a cluster, including stopped ones
+func GetNodeContainers(ctx context.Context, clusterName string) ([]Container, error) {
+	ctx, cancel := context.WithTimeout(ctx, QueryTimeout)
+	defer cancel()
+
+	cmd := command(ctx, ActiveProvider, "ps", "-a",
+		"--filter", "label="+kindClusterLabel+"="+clusterName,
+		"--format", containerFormat(ActiveProvider))
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list node containers: %w", contextError(ctx, err))
+	}
+
+	return ParseContainers(string(output)), nil
+}
+
+// containerFormat returns the ps template printing name, state and KIND role for a provider
+func containerFormat(provider string) string {
+	switch provider {
+	case ProviderPodman:
+		return `{{.Names}}\t{{.State}}\t{{index .Labels "io.x-k8s.kind.role"}}`
+	case ProviderNerdctl:
+		// nerdctl has no State field, its Status reads like "Up 2 minutes"
+		return `{{.Names}}\t{{.Status}}`
+	default:
+		return `{{.Names}}\t{{.State}}\t{{.Label "io.x-k8s.kind.role"}}`
+	}
+}
+
+// ParseContainers parses tab separated name, state and role lines. The role is
+// taken from the container name when the runtime did not print it.
+func ParseContainers(output string) []Container {
+	containers := make([]Container, 0)
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) < 2 || fields[0] == "" {
+			continue
+		}
+
+		container := Container{Name: fields[0], State: normalizeState(fields[1])}
+		if len(fields) > 2 {
+			container.Role = strings.TrimSpace(fields[2])
+		}
+		if container.Role == "" {
+			container.Role = roleFromName(container.Name)
+		}
+		containers = append(containers, container)
+	}
+	return containers
+}
+
+// normalizeState maps runtime specific states such as "Up 2 minutes" to a single word
+func normalizeState(state string) string {
+	state = strings.ToLower(strings.TrimSpace(state))
+	switch {
+	case strings.HasPrefix(state, "up"):
+		return "running"
+	case strings.HasPrefix(state, "exited"):
+		return "exited"
+	}
+	if fields := strings.Fields(state); len(fields) > 0 {
+		return fields[0]
+	}
+	return state
+}
+
+// roleFromName infers the KIND role from a node container name
+func roleFromName(name string) string {
+	switch {
+	case strings.Contains(name, "-"+RoleLoadBalancer):
+		return RoleLoadBalancer
+	case strings.Contains(name, "-"+RoleControlPlane):
+		return RoleControlPlane
+	default:
+		return RoleWorker
+	}
+}
+
+// APIServerReady probes the readiness endpoint of the cluster's API server
+func APIServerReady(ctx context.Context, clusterName string) bool {
+	ctx, cancel := context.WithTimeout(ctx, HealthTimeout)
+	defer cancel()
+
+	cmd, err := kubectl(ctx, clusterName, "get", "--raw", "/readyz",
+		"--request-timeout", HealthTimeout.String())
+	if err != nil {
+		return false
+	}
+	output, err := cmd.Output()
+	return err == nil && strings.TrimSpace(string(output)) == "ok"
+}
+
+// DeriveStatus combines the node container states and API server health into a cluster state
+func DeriveStatus(containers []Container, apiReady bool) string {
+	if len(containers) == 0 {
+		return StatusUnknown
+	}
+
+	running := 0
+	for _, c := range containers {
+		if c.Running() {
+			running++
+		}
+	}
+
+	switch {
+	case running == 0:
+		return StatusStopped
+	case running < len(containers):
+		return StatusDegraded
+	case !apiReady:
+		return StatusUnreachable
+	default:
+		return StatusRunning
+	}
+}
+
+// clusterStatus queries the node containers and, when they are all up, the API server
+func clusterStatus(ctx context.Context, clusterName string) ([]Container, string, error) {
+	containers, err := GetNodeContainers(ctx, clusterName)
+	if err != nil {
+		return nil, StatusUnknown, err
+	}
+
+	// Only probe the API server when it can possibly answer
+	apiReady := false
+	if DeriveStatus(containers, true) == StatusRunning {
+		apiReady = APIServerReady(ctx, clusterName)
+	}
+	return containers, DeriveStatus(containers, apiReady), nil
+}
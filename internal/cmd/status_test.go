@@ -0,0 +1,76 @@
+package cmd
+
+import "testing"
+
+func TestParseContainers(t *testing.T) {
+	output := "dev-worker\trunning\tworker\n" +
+		"dev-control-plane\texited\tcontrol-plane\n" +
+		"\n" +
+		"incomplete\n"
+
+	containers := ParseContainers(output)
+	if len(containers) != 2 {
+		t.Fatalf("ParseContainers() returned %d containers, expected 2", len(containers))
+	}
+
+	expected := []Container{
+		{Name: "dev-worker", Role: "worker", State: "running"},
+		{Name: "dev-control-plane", Role: "control-plane", State: "exited"},
+	}
+	for i, c := range containers {
+		if c != expected[i] {
+			t.Errorf("Container[%d] = %+v, expected %+v", i, c, expected[i])
+		}
+	}
+}
+
+func TestParseContainersWithoutRoles(t *testing.T) {
+	// nerdctl prints a status sentence and no role
+	output := "dev-control-plane\tUp 5 minutes\n" +
+		"dev-worker2\tExited (137) 2 hours ago\n" +
+		"dev-external-load-balancer\tCreated\n"
+
+	expected := []Container{
+		{Name: "dev-control-plane", Role: RoleControlPlane, State: "running"},
+		{Name: "dev-worker2", Role: RoleWorker, State: "exited"},
+		{Name: "dev-external-load-balancer", Role: RoleLoadBalancer, State: "created"},
+	}
+
+	containers := ParseContainers(output)
+	if len(containers) != len(expected) {
+		t.Fatalf("ParseContainers() returned %d containers, expected %d", len(containers), len(expected))
+	}
+	for i, c := range containers {
+		if c != expected[i] {
+			t.Errorf("Container[%d] = %+v, expected %+v", i, c, expected[i])
+		}
+	}
+}
+
+func TestDeriveStatus(t *testing.T) {
+	running := Container{Name: "a", State: "running"}
+	exited := Container{Name: "b", State: "exited"}
+	paused := Container{Name: "c", State: "paused"}
+
+	tests := []struct {
+		name       string
+		containers []Container
+		apiReady   bool
+		expected   string
+	}{
+		{"no containers", nil, true, StatusUnknown},
+		{"all running and ready", []Container{running, running}, true, StatusRunning},
+		{"all running but api not ready", []Container{running, running}, false, StatusUnreachable},
+		{"all stopped", []Container{exited, exited}, false, StatusStopped},
+		{"some stopped", []Container{running, exited}, true, StatusDegraded},
+		{"paused counts as not running", []Container{running, paused}, true, StatusDegraded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := DeriveStatus(tt.containers, tt.apiReady); result != tt.expected {
+				t.Errorf("DeriveStatus() = %s, expected %s", result, tt.expected)
+			}
+		})
+	}
+}
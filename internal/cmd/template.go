@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const templateExt = ".yaml"
+
+var templateNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// Template is a reusable, named cluster shape
+type Template struct {
+	Name        string        `yaml:"-"`
+	Description string        `yaml:"description,omitempty"`
+	Cluster     ClusterConfig `yaml:"cluster"`
+}
+
+// Summary describes the node layout of the template
+func (t Template) Summary() string {
+	controlPlanes := t.Cluster.CountNodes(RoleControlPlane)
+	if controlPlanes == 0 {
+		controlPlanes = 1
+	}
+	summary := fmt.Sprintf("%d control-plane", controlPlanes)
+	if workers := t.Cluster.CountNodes(RoleWorker); workers > 0 {
+		summary += fmt.Sprintf(", %d worker", workers)
+		if workers > 1 {
+			summary += "s"
+		}
+	}
+	return summary
+}
+
+// TemplateStore keeps templates as YAML files in a directory
+type TemplateStore struct {
+	Dir string
+}
+
+// DefaultTemplateDir returns ~/.config/ki/templates, honouring XDG_CONFIG_HOME
+func DefaultTemplateDir() string {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, _ := os.UserHomeDir()
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "ki", "templates")
+}
+
+// BuiltinTemplates returns the templates seeded into a new store
+func BuiltinTemplates() []Template {
+	ingressPatch := `kind: InitConfiguration
+nodeRegistration:
+  kubeletExtraArgs:
+    node-labels: "ingress-ready=true"
+`
+	ha := NewClusterConfig("", 3, 0, "")
+	ha.Nodes[0].KubeadmConfigPatches = []string{ingressPatch}
+	ha.Nodes[0].ExtraPortMappings = []PortMapping{
+		{ContainerPort: 80, HostPort: 80, Protocol: "TCP"},
+		{ContainerPort: 443, HostPort: 443, Protocol: "TCP"},
+	}
+
+	return []Template{
+		{
+			Name:        "single-node",
+			Description: "A single control-plane node",
+			Cluster:     NewClusterConfig("", 1, 0, ""),
+		},
+		{
+			Name:        "workers",
+			Description: "One control-plane node and three workers",
+			Cluster:     NewClusterConfig("", 1, 3, ""),
+		},
+		{
+			Name:        "ha-ingress",
+			Description: "Three control-plane nodes with ingress ports 80/443 on the first",
+			Cluster:     ha,
+		},
+	}
+}
+
+// BrokenTemplatesError lists the template files List skipped because they
+// could not be read or parsed
+type BrokenTemplatesError struct {
+	Errs []error
+}
+
+func (e *BrokenTemplatesError) Error() string {
+	messages := make([]string, len(e.Errs))
+	for i, err := range e.Errs {
+		messages[i] = err.Error()
+	}
+	return "skipped broken templates: " + strings.Join(messages, "; ")
+}
+
+// List returns all templates sorted by name, seeding the builtin ones on first
+// use. Templates that fail to load are skipped; the others are still returned,
+// together with a *BrokenTemplatesError naming the skipped ones.
+func (s TemplateStore) List() ([]Template, error) {
+	if err := s.seed(); err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	templates := make([]Template, 0, len(entries))
+	var broken []error
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != templateExt {
+			continue
+		}
+		t, err := s.Get(strings.TrimSuffix(entry.Name(), templateExt))
+		if err != nil {
+			broken = append(broken, err)
+			continue
+		}
+		templates = append(templates, t)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	if len(broken) > 0 {
+		return templates, &BrokenTemplatesError{Errs: broken}
+	}
+	return templates, nil
+}
+
+// Get loads a single template by name
+func (s TemplateStore) Get(name string) (Template, error) {
+	if err := validateTemplateName(name); err != nil {
+		return Template{}, err
+	}
+	if err := s.seed(); err != nil {
+		return Template{}, err
+	}
+
+	data, err := os.ReadFile(s.path(name))
+	if err != nil {
+		return Template{}, fmt.Errorf("failed to read template %s: %w", name, err)
+	}
+
+	var t Template
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return Template{}, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	t.Name = name
+	return t, nil
+}
+
+// Save writes the template, replacing any existing one with the same name
+func (s TemplateStore) Save(t Template) error {
+	if err := validateTemplateName(t.Name); err != nil {
+		return err
+	}
+	if err := t.Cluster.Validate(); err != nil {
+		return fmt.Errorf("invalid template %s: %w", t.Name, err)
+	}
+
+	data, err := yaml.Marshal(t)
+	if err != nil {
+		return fmt.Errorf("failed to encode template %s: %w", t.Name, err)
+	}
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create template directory: %w", err)
+	}
+	if err := os.WriteFile(s.path(t.Name), data, 0o644); err != nil {
+		return fmt.Errorf("failed to save template %s: %w", t.Name, err)
+	}
+	return nil
+}
+
+// Clone copies an existing template under a new name
+func (s TemplateStore) Clone(name, newName string) (Template, error) {
+	t, err := s.Get(name)
+	if err != nil {
+		return Template{}, err
+	}
+	if _, err := os.Stat(s.path(newName)); err == nil {
+		return Template{}, fmt.Errorf("template %s already exists", newName)
+	}
+
+	t.Name = newName
+	if err := s.Save(t); err != nil {
+		return Template{}, err
+	}
+	return t, nil
+}
+
+// Delete removes a template
+func (s TemplateStore) Delete(name string) error {
+	if err := validateTemplateName(name); err != nil {
+		return err
+	}
+	if err := os.Remove(s.path(name)); err != nil {
+		return fmt.Errorf("failed to delete template %s: %w", name, err)
+	}
+	return nil
+}
+
+// seed writes the builtin templates when the store directory does not exist yet
+func (s TemplateStore) seed() error {
+	if _, err := os.Stat(s.Dir); !errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	for _, t := range BuiltinTemplates() {
+		if err := s.Save(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s TemplateStore) path(name string) string {
+	return filepath.Join(s.Dir, name+templateExt)
+}
+
+func validateTemplateName(name string) error {
+	if !templateNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid template name %q", name)
+	}
+	return nil
+}
+
+// Global template store that can be replaced for testing
+var Templates = TemplateStore{Dir: DefaultTemplateDir()}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTemplateStoreSeedsBuiltins(t *testing.T) {
+	store := TemplateStore{Dir: filepath.Join(t.TempDir(), "templates")}
+
+	templates, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(templates) != len(BuiltinTemplates()) {
+		t.Fatalf("List() returned %d templates, expected %d", len(templates), len(BuiltinTemplates()))
+	}
+
+	ha, err := store.Get("ha-ingress")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if ha.Cluster.CountNodes(RoleControlPlane) != 3 {
+		t.Errorf("Expected 3 control-plane nodes, got %d", ha.Cluster.CountNodes(RoleControlPlane))
+	}
+	if len(ha.Cluster.Nodes[0].ExtraPortMappings) != 2 {
+		t.Errorf("Expected ingress port mappings to survive a round trip, got %+v", ha.Cluster.Nodes[0].ExtraPortMappings)
+	}
+
+	// Deleting every template must not bring the builtins back
+	for _, template := range templates {
+		if err := store.Delete(template.Name); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+	}
+	templates, err = store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(templates) != 0 {
+		t.Errorf("Expected no templates after deleting all, got %d", len(templates))
+	}
+}
+
+func TestTemplateStoreSaveClone(t *testing.T) {
+	store := TemplateStore{Dir: t.TempDir()}
+
+	template := Template{
+		Name:        "dev",
+		Description: "Development cluster",
+		Cluster:     NewClusterConfig("dev", 1, 1, "kindest/node:v1.29.2"),
+	}
+	if err := store.Save(template); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	clone, err := store.Clone("dev", "dev-copy")
+	if err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+	if clone.Description != "Development cluster" || len(clone.Cluster.Nodes) != 2 {
+		t.Errorf("Clone() = %+v", clone)
+	}
+	if _, err := store.Clone("dev", "dev-copy"); err == nil {
+		t.Error("Clone() should refuse to overwrite an existing template")
+	}
+
+	templates, err := store.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(templates) != 2 || templates[0].Name != "dev" || templates[1].Name != "dev-copy" {
+		t.Errorf("List() = %+v", templates)
+	}
+}
+
+func TestTemplateStoreRejectsInvalid(t *testing.T) {
+	store := TemplateStore{Dir: t.TempDir()}
+
+	for _, name := range []string{"", "../escape", "with/slash", ".hidden"} {
+		if err := store.Save(Template{Name: name}); err == nil {
+			t.Errorf("Save() should reject name %q", name)
+		}
+	}
+
+	invalid := Template{Name: "broken", Cluster: NewClusterConfig("", 0, 1, "")}
+	if err := store.Save(invalid); err == nil {
+		t.Error("Save() should reject a config without control-plane nodes")
+	}
+
+	if err := os.WriteFile(filepath.Join(store.Dir, "bad.yaml"), []byte("cluster: ["), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get("bad"); err == nil {
+		t.Error("Get() should fail on malformed YAML")
+	}
+}
+
+func TestTemplateStoreListSkipsBroken(t *testing.T) {
+	store := TemplateStore{Dir: t.TempDir()}
+	if err := store.Save(Template{Name: "good", Cluster: NewClusterConfig("", 1, 1, "")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(store.Dir, "bad.yaml"), []byte("cluster: ["), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	templates, err := store.List()
+	var broken *BrokenTemplatesError
+	if !errors.As(err, &broken) || len(broken.Errs) != 1 || !strings.Contains(err.Error(), "bad") {
+		t.Errorf("List() error = %v, expected bad.yaml to be reported", err)
+	}
+	if len(templates) != 1 || templates[0].Name != "good" {
+		t.Errorf("List() = %+v, expected the good template", templates)
+	}
+}
+
+func TestTemplateSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   ClusterConfig
+		expected string
+	}{
+		{name: "name only", config: ClusterConfig{}, expected: "1 control-plane"},
+		{name: "one worker", config: NewClusterConfig("", 1, 1, ""), expected: "1 control-plane, 1 worker"},
+		{name: "ha", config: NewClusterConfig("", 3, 3, ""), expected: "3 control-plane, 3 workers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Template{Cluster: tt.config}).Summary(); got != tt.expected {
+				t.Errorf("Summary() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// workerWaitTimeout bounds waiting for a joined worker to become Ready and
+// for a removed one to drain
+const workerWaitTimeout = 2 * time.Minute
+
+// AddWorker provisions a worker node container from the image of the cluster's
+// control plane and joins it with a fresh kubeadm token. It returns the name
+// of the new node. This is experimental: KIND itself cannot add nodes to an
+// existing cluster, so the container is set up and joined the way KIND sets up
+// workers, without the node settings of the cluster's config. A node container
+// left behind by a failed join is removed.
+func AddWorker(ctx context.Context, clusterName string, out io.Writer) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, WorkerTimeout)
+	defer cancel()
+	defer forgetCluster(clusterName)
+
+	name, err := addWorker(ctx, clusterName, out)
+	if err != nil {
+		return "", fmt.Errorf("failed to add worker to %s: %w", clusterName, err)
+	}
+	return name, nil
+}
+
+func addWorker(ctx context.Context, clusterName string, out io.Writer) (string, error) {
+	containers, err := GetNodeContainers(ctx, clusterName)
+	if err != nil {
+		return "", err
+	}
+	controlPlane := ""
+	for _, c := range containers {
+		if c.Role == RoleControlPlane && c.Running() {
+			controlPlane = c.Name
+			break
+		}
+	}
+	if controlPlane == "" {
+		return "", fmt.Errorf("no running control-plane node found for cluster %s", clusterName)
+	}
+
+	output, err := command(ctx, ActiveProvider, "inspect", "--format", "{{.Config.Image}}", controlPlane).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read the node image: %w", commandError(ctx, err))
+	}
+	image := strings.TrimSpace(string(output))
+
+	name := nextWorkerName(clusterName, containers)
+	if err := runStep(ctx, out, "Creating node container "+name+" ("+image+")", ActiveProvider,
+		workerRunArgs(clusterName, name, image)...); err != nil {
+		return "", err
+	}
+
+	if err := joinWorker(ctx, clusterName, controlPlane, name, out); err != nil {
+		cleanupCtx, cleanupCancel := context.WithTimeout(context.Background(), DeleteTimeout)
+		defer cleanupCancel()
+		if _, cleanupErr := run(cleanupCtx, nil, ActiveProvider, "rm", "-f", "-v", name); cleanupErr != nil {
+			return "", fmt.Errorf("%w (removing %s also failed: %v)", err, name, cleanupErr)
+		}
+		step(out, "✓", "Removed node container "+name)
+		return "", err
+	}
+	return name, nil
+}
+
+// joinWorker joins a freshly started node container to the cluster and waits for it to be Ready
+func joinWorker(ctx context.Context, clusterName, controlPlane, name string, out io.Writer) error {
+	if err := waitForContainerd(ctx, name, out); err != nil {
+		return err
+	}
+
+	// Clusters using the local registry expect every node to pull from it
+	if ClusterRegistry(ctx, clusterName) == RegistryHost {
+		if err := runStep(ctx, out, "Configuring registry on "+name, ActiveProvider, registryHostsArgs(name)...); err != nil {
+			return err
+		}
+	}
+
+	step(out, "•", "Creating join token ...")
+	output, err := command(ctx, ActiveProvider, "exec", controlPlane,
+		"kubeadm", "token", "create", "--print-join-command", "--ttl", "15m").Output()
+	if err != nil {
+		step(out, "✗", "Creating join token")
+		return fmt.Errorf("failed to create join token: %w", commandError(ctx, err))
+	}
+	join, err := ParseJoinCommand(string(output))
+	if err != nil {
+		step(out, "✗", "Creating join token")
+		return err
+	}
+	step(out, "✓", "Creating join token")
+
+	output, err = command(ctx, ActiveProvider, "inspect", "--format",
+		`{{with index .NetworkSettings.Networks "`+registryNetwork+`"}}{{.IPAddress}}{{end}}`, name).Output()
+	if err != nil {
+		return fmt.Errorf("failed to read the address of %s: %w", name, commandError(ctx, err))
+	}
+	nodeIP := strings.TrimSpace(string(output))
+	if nodeIP == "" {
+		return fmt.Errorf("%s has no address on the %s network", name, registryNetwork)
+	}
+
+	config := workerJoinConfig(join, clusterName, name, nodeIP)
+	if err := runStepInput(ctx, out, "Writing kubeadm config on "+name, config, ActiveProvider,
+		"exec", "-i", name, "cp", "/dev/stdin", workerKubeadmConfig); err != nil {
+		return err
+	}
+	if err := runStep(ctx, out, "Joining "+name+" to the cluster", ActiveProvider, workerJoinArgs(name)...); err != nil {
+		return err
+	}
+
+	kubeconfig, err := clusterKubeconfig(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	return runStep(ctx, out, "Waiting for "+name+" to be ready", "kubectl", kubectlArgs(kubeconfig,
+		"wait", "--for=condition=Ready", "node/"+name, "--timeout="+workerWaitTimeout.String())...)
+}
+
+// waitForContainerd polls a node container until systemd has started containerd
+func waitForContainerd(ctx context.Context, name string, out io.Writer) error {
+	step(out, "•", "Waiting for containerd on "+name+" ...")
+	for {
+		output, _ := command(ctx, ActiveProvider, "exec", name, "systemctl", "is-active", "containerd").Output()
+		if strings.TrimSpace(string(output)) == "active" {
+			step(out, "✓", "Waiting for containerd on "+name)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			step(out, "✗", "Waiting for containerd on "+name)
+			return fmt.Errorf("containerd not running on %s: %w", name, ctx.Err())
+		case <-time.After(readyPollInterval):
+		}
+	}
+}
+
+// nextWorkerName returns the first worker name KIND's scheme leaves free:
+// NAME-worker, NAME-worker2, NAME-worker3 and so on
+func nextWorkerName(clusterName string, containers []Container) string {
+	taken := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		taken[c.Name] = true
+	}
+	name := clusterName + "-worker"
+	for i := 2; taken[name]; i++ {
+		name = clusterName + "-worker" + strconv.Itoa(i)
+	}
+	return name
+}
+
+// workerRunArgs builds the arguments starting a worker node container the
+// way KIND provisions one
+func workerRunArgs(clusterName, name, image string) []string {
+	args := []string{"run", "--detach", "--tty",
+		"--name", name, "--hostname", name,
+		"--label", kindClusterLabel + "=" + clusterName,
+		"--label", kindRoleLabel + "=" + RoleWorker,
+		"--privileged",
+		"--security-opt", "seccomp=unconfined",
+		"--security-opt", "apparmor=unconfined",
+		"--cgroupns=private",
+		"--init=false",
+		"--tmpfs", "/tmp", "--tmpfs", "/run",
+		"--volume", "/var",
+		"--volume", "/lib/modules:/lib/modules:ro",
+		"--env", "container=docker",
+		"--network", registryNetwork,
+		"--restart", "on-failure:1"}
+	// KIND hands the snapshotter override to every node it creates
+	if snapshotter := os.Getenv(containerdSnapshotterEnv); snapshotter != "" {
+		args = append(args, "--env", containerdSnapshotterEnv+"="+snapshotter)
+	}
+	return append(args, image)
+}
+
+// containerdSnapshotterEnv selects the containerd snapshotter of KIND nodes
+const containerdSnapshotterEnv = "KIND_EXPERIMENTAL_CONTAINERD_SNAPSHOTTER"
+
+// workerKubeadmConfig is where KIND keeps the kubeadm config inside a node
+const workerKubeadmConfig = "/kind/kubeadm.conf"
+
+// workerPreflightIgnores are the kubeadm preflight checks a node container
+// cannot pass: the host kernel config is not readable inside it, swap is the
+// host's, and the bridge sysctls depend on host modules
+var workerPreflightIgnores = []string{
+	"SystemVerification",
+	"Swap",
+	"FileContent--proc-sys-net-bridge-bridge-nf-call-iptables",
+}
+
+// JoinCommand is what kubeadm token create --print-join-command prints
+type JoinCommand struct {
+	APIServerEndpoint string
+	Token             string
+	CACertHashes      []string
+}
+
+// ParseJoinCommand reads the API server endpoint, token and CA certificate
+// hashes out of the output of kubeadm token create --print-join-command
+func ParseJoinCommand(output string) (JoinCommand, error) {
+	var join JoinCommand
+	args := strings.Fields(output)
+	if len(args) < 3 || args[0] != "kubeadm" || args[1] != "join" {
+		return join, fmt.Errorf("unexpected join command %q", strings.TrimSpace(output))
+	}
+	join.APIServerEndpoint = args[2]
+	for i := 3; i+1 < len(args); i++ {
+		switch args[i] {
+		case "--token":
+			join.Token = args[i+1]
+		case "--discovery-token-ca-cert-hash":
+			join.CACertHashes = append(join.CACertHashes, args[i+1])
+		}
+	}
+	if join.Token == "" || len(join.CACertHashes) == 0 {
+		return join, fmt.Errorf("join command %q has no token or CA certificate hash", strings.TrimSpace(output))
+	}
+	return join, nil
+}
+
+// workerJoinConfig renders the JoinConfiguration KIND would write for a
+// worker: containerd as the CRI, the node's own address and KIND's provider
+// ID, so the node is set up like the ones KIND created
+func workerJoinConfig(join JoinCommand, clusterName, name, nodeIP string) string {
+	hashes := make([]string, len(join.CACertHashes))
+	for i, hash := range join.CACertHashes {
+		hashes[i] = fmt.Sprintf("    - %q", hash)
+	}
+	return fmt.Sprintf(`apiVersion: kubeadm.k8s.io/v1beta3
+kind: JoinConfiguration
+nodeRegistration:
+  criSocket: unix:///run/containerd/containerd.sock
+  kubeletExtraArgs:
+    node-ip: %q
+    provider-id: %q
+discovery:
+  bootstrapToken:
+    apiServerEndpoint: %q
+    token: %q
+    caCertHashes:
+%s
+`, nodeIP, "kind://"+ActiveProvider+"/"+clusterName+"/"+name, join.APIServerEndpoint, join.Token, strings.Join(hashes, "\n"))
+}
+
+// workerJoinArgs runs kubeadm join on a node with the config written to
+// workerKubeadmConfig, skipping only the preflight checks a container fails
+func workerJoinArgs(name string) []string {
+	return []string{"exec", name, "kubeadm", "join",
+		"--config", workerKubeadmConfig,
+		"--ignore-preflight-errors=" + strings.Join(workerPreflightIgnores, ",")}
+}
+
+// RemoveWorker drains a worker node, deletes its node object and removes its
+// container. Control-plane nodes cannot be removed.
+func RemoveWorker(ctx context.Context, clusterName, node string, out io.Writer) error {
+	ctx, cancel := context.WithTimeout(ctx, WorkerTimeout)
+	defer cancel()
+	defer forgetCluster(clusterName)
+
+	if err := removeWorker(ctx, clusterName, node, out); err != nil {
+		return fmt.Errorf("failed to remove worker %s: %w", node, err)
+	}
+	return nil
+}
+
+func removeWorker(ctx context.Context, clusterName, node string, out io.Writer) error {
+	containers, err := GetNodeContainers(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	if err := checkWorker(containers, clusterName, node); err != nil {
+		return err
+	}
+
+	kubeconfig, err := clusterKubeconfig(ctx, clusterName)
+	if err != nil {
+		return err
+	}
+	if err := runStep(ctx, out, "Draining "+node, "kubectl", kubectlArgs(kubeconfig,
+		"drain", node, "--ignore-daemonsets", "--delete-emptydir-data", "--force",
+		"--timeout="+workerWaitTimeout.String())...); err != nil {
+		return err
+	}
+	if err := runStep(ctx, out, "Deleting node "+node, "kubectl", kubectlArgs(kubeconfig,
+		"delete", "node", node, "--ignore-not-found")...); err != nil {
+		return err
+	}
+	return runStep(ctx, out, "Removing node container "+node, ActiveProvider, "rm", "-f", "-v", node)
+}
+
+// checkWorker makes sure node is a worker container of the cluster
+func checkWorker(containers []Container, clusterName, node string) error {
+	for _, c := range containers {
+		if c.Name != node {
+			continue
+		}
+		if c.Role != RoleWorker {
+			return fmt.Errorf("%s is a %s node, only workers can be removed", node, c.Role)
+		}
+		return nil
+	}
+	return fmt.Errorf("no node container %s found for cluster %s", node, clusterName)
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestNextWorkerName(t *testing.T) {
+	tests := []struct {
+		name       string
+		containers []Container
+		expected   string
+	}{
+		{"single node", []Container{{Name: "dev-control-plane"}}, "dev-worker"},
+		{"one worker", []Container{{Name: "dev-control-plane"}, {Name: "dev-worker"}}, "dev-worker2"},
+		{"gap", []Container{{Name: "dev-worker"}, {Name: "dev-worker3"}}, "dev-worker2"},
+		{"several", []Container{{Name: "dev-worker"}, {Name: "dev-worker2"}, {Name: "dev-worker3"}}, "dev-worker4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextWorkerName("dev", tt.containers); got != tt.expected {
+				t.Errorf("nextWorkerName() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWorkerRunArgs(t *testing.T) {
+	args := workerRunArgs("dev", "dev-worker2", "kindest/node:v1.30.0")
+	joined := strings.Join(args, " ")
+	for _, expected := range []string{
+		"--name dev-worker2 --hostname dev-worker2",
+		"--label io.x-k8s.kind.cluster=dev",
+		"--label io.x-k8s.kind.role=worker",
+		"--privileged",
+		"--cgroupns=private",
+		"--tmpfs /tmp --tmpfs /run --volume /var --volume /lib/modules:/lib/modules:ro",
+		"--env container=docker",
+		"--network kind",
+	} {
+		if !strings.Contains(joined, expected) {
+			t.Errorf("workerRunArgs() = %q, should contain %q", joined, expected)
+		}
+	}
+	if args[0] != "run" || args[len(args)-1] != "kindest/node:v1.30.0" {
+		t.Errorf("workerRunArgs() should run the image last, got %q", joined)
+	}
+}
+
+// These tests only cover the arguments and config ki hands to the runtime and
+// kubeadm; that the node then joins and turns Ready is checked at run time by
+// AddWorker, which waits for the Ready condition, and needs a real cluster.
+
+func TestParseJoinCommand(t *testing.T) {
+	output := "kubeadm join dev-control-plane:6443 --token abc.def --discovery-token-ca-cert-hash sha256:123 \n"
+	join, err := ParseJoinCommand(output)
+	if err != nil {
+		t.Fatalf("ParseJoinCommand() error = %v", err)
+	}
+	expected := JoinCommand{APIServerEndpoint: "dev-control-plane:6443", Token: "abc.def", CACertHashes: []string{"sha256:123"}}
+	if !reflect.DeepEqual(join, expected) {
+		t.Errorf("ParseJoinCommand() = %+v, expected %+v", join, expected)
+	}
+
+	for _, bad := range []string{"failed to create token", "kubeadm join dev-control-plane:6443 --token abc.def"} {
+		if _, err := ParseJoinCommand(bad); err == nil {
+			t.Errorf("ParseJoinCommand(%q) should fail", bad)
+		}
+	}
+}
+
+func TestWorkerJoinConfig(t *testing.T) {
+	join := JoinCommand{APIServerEndpoint: "dev-control-plane:6443", Token: "abc.def", CACertHashes: []string{"sha256:123"}}
+	config := workerJoinConfig(join, "dev", "dev-worker2", "172.18.0.5")
+
+	var doc struct {
+		Kind             string `yaml:"kind"`
+		NodeRegistration struct {
+			CRISocket        string            `yaml:"criSocket"`
+			KubeletExtraArgs map[string]string `yaml:"kubeletExtraArgs"`
+		} `yaml:"nodeRegistration"`
+		Discovery struct {
+			BootstrapToken struct {
+				APIServerEndpoint string   `yaml:"apiServerEndpoint"`
+				Token             string   `yaml:"token"`
+				CACertHashes      []string `yaml:"caCertHashes"`
+			} `yaml:"bootstrapToken"`
+		} `yaml:"discovery"`
+	}
+	if err := yaml.Unmarshal([]byte(config), &doc); err != nil {
+		t.Fatalf("workerJoinConfig() is not valid YAML: %v\n%s", err, config)
+	}
+	if doc.Kind != "JoinConfiguration" || doc.NodeRegistration.CRISocket == "" {
+		t.Errorf("workerJoinConfig() = %s, expected a JoinConfiguration for containerd", config)
+	}
+	if doc.NodeRegistration.KubeletExtraArgs["node-ip"] != "172.18.0.5" ||
+		doc.NodeRegistration.KubeletExtraArgs["provider-id"] != "kind://"+ActiveProvider+"/dev/dev-worker2" {
+		t.Errorf("Unexpected kubelet args %v", doc.NodeRegistration.KubeletExtraArgs)
+	}
+	token := doc.Discovery.BootstrapToken
+	if token.APIServerEndpoint != join.APIServerEndpoint || token.Token != join.Token ||
+		!reflect.DeepEqual(token.CACertHashes, join.CACertHashes) {
+		t.Errorf("Unexpected bootstrap token %+v", token)
+	}
+}
+
+func TestWorkerJoinArgs(t *testing.T) {
+	joined := strings.Join(workerJoinArgs("dev-worker2"), " ")
+	if !strings.Contains(joined, "exec dev-worker2 kubeadm join --config /kind/kubeadm.conf") {
+		t.Errorf("workerJoinArgs() = %q, should join with the written config", joined)
+	}
+	if strings.Contains(joined, "--ignore-preflight-errors=all") || !strings.Contains(joined, "SystemVerification") {
+		t.Errorf("workerJoinArgs() = %q, should only skip the checks a container fails", joined)
+	}
+}
+
+func TestCheckWorker(t *testing.T) {
+	containers := []Container{
+		{Name: "dev-control-plane", Role: RoleControlPlane},
+		{Name: "dev-worker", Role: RoleWorker},
+	}
+	if err := checkWorker(containers, "dev", "dev-worker"); err != nil {
+		t.Errorf("checkWorker() of a worker = %v", err)
+	}
+	for _, node := range []string{"dev-control-plane", "dev-worker9"} {
+		if err := checkWorker(containers, "dev", node); err == nil {
+			t.Errorf("checkWorker(%q) should fail", node)
+		}
+	}
+}
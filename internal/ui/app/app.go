@@ -2,12 +2,15 @@ package app
 
 import (
 	"fmt"
+	"io"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"ki/internal/cmd"
@@ -20,6 +23,54 @@ import (
 // App wraps the models.Model to provide the necessary methods
 type App struct {
 	model models.Model
+	jobs  jobGroup
+}
+
+// jobGroup counts the job commands that are running, so quitting can wait for
+// cancelled jobs to clean up
+type jobGroup struct {
+	mu      sync.Mutex
+	closed  bool
+	running sync.WaitGroup
+	streams map[*commands.Stream]bool
+}
+
+// track returns c counted while it runs, writing to stream. Commands that had
+// not started when the group was closed do not run at all.
+func (g *jobGroup) track(stream *commands.Stream, c tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		g.mu.Lock()
+		if g.closed {
+			g.mu.Unlock()
+			return nil
+		}
+		if g.streams == nil {
+			g.streams = make(map[*commands.Stream]bool)
+		}
+		g.streams[stream] = true
+		g.running.Add(1)
+		g.mu.Unlock()
+
+		defer func() {
+			g.mu.Lock()
+			delete(g.streams, stream)
+			g.mu.Unlock()
+			g.running.Done()
+		}()
+		return c()
+	}
+}
+
+// closeAndWait stops new commands from starting and waits for the running
+// ones. Their output is discarded since the UI no longer reads it.
+func (g *jobGroup) closeAndWait() {
+	g.mu.Lock()
+	g.closed = true
+	for stream := range g.streams {
+		go stream.Drain()
+	}
+	g.mu.Unlock()
+	g.running.Wait()
 }
 
 // NewApp creates a new app instance
@@ -31,6 +82,9 @@ func NewApp() *App {
 		models.NewItem("Load Docker Image", "Load a Docker image into a KIND cluster", "load"),
 		models.NewItem("Build Node Image", "Build a custom KIND node image from source", "build"),
 		models.NewItem("Export Logs", "Export cluster logs for debugging", "logs"),
+		models.NewItem("Jobs", "Follow running and finished operations", "jobs"),
+		models.NewItem("Port Forwards", "Manage local ports forwarded into clusters", "forwards"),
+		models.NewItem("Local Registry", "Create or start the registry shared by clusters", "registry"),
 	}
 
 	// Setup main menu list
@@ -51,6 +105,12 @@ func NewApp() *App {
 	nodeList.SetShowStatusBar(false)
 	nodeList.SetFilteringEnabled(false)
 
+	// Setup template list
+	templateList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	templateList.Title = "Cluster Templates"
+	templateList.SetShowStatusBar(false)
+	templateList.SetFilteringEnabled(false)
+
 	// Setup text input
 	ti := textinput.New()
 	ti.Placeholder = "Enter value..."
@@ -59,22 +119,48 @@ func NewApp() *App {
 	ti.Width = 50
 
 	m := models.Model{
-		CurrentView: models.MainMenuView,
-		MainMenu:    mainList,
-		ClusterList: clusterList,
-		NodeList:    nodeList,
-		TextInput:   ti,
-		Help:        help.New(),
-		Clusters:    []cmd.Cluster{},
-		ShowHelp:    false,
+		CurrentView:  models.MainMenuView,
+		MainMenu:     mainList,
+		ClusterList:  clusterList,
+		NodeList:     nodeList,
+		TemplateList: templateList,
+		TextInput:    ti,
+		ClusterForm:  models.NewClusterForm(),
+		Pods:         models.NewPodBrowser(),
+		Logs:         models.NewLogViewer(),
+		Images:       models.NewImagePicker(),
+		Spinner:      spinner.New(spinner.WithSpinner(spinner.Dot), spinner.WithStyle(styles.Status)),
+		Help:         help.New(),
+		Refresh:      models.AutoRefresh{Interval: models.DefaultRefreshInterval},
+		Clusters:     []cmd.Cluster{},
+		ShowHelp:     false,
 	}
 
 	return &App{model: m}
 }
 
+// Shutdown cancels the jobs that are still running and waits for them to
+// finish, so they can clean up after themselves, e.g. delete a half-created
+// cluster. It reports to out what it waits for. Call it once the program exited.
+func (a *App) Shutdown(out io.Writer) {
+	if running := a.model.Jobs.Running(); running > 0 {
+		fmt.Fprintf(out, "Cancelling %d running job(s) and waiting for them to clean up...\n", running)
+	}
+	for _, job := range a.model.Jobs.All() {
+		job.Abort()
+	}
+	a.jobs.closeAndWait()
+}
+
+// SetRefreshInterval sets how often the cluster and node views reload; 0 turns it off
+func (a *App) SetRefreshInterval(interval time.Duration) {
+	a.model.Refresh.Interval = interval
+}
+
 func (a *App) Init() tea.Cmd {
 	return tea.Batch(
-		commands.GetKindClusters(),
+		a.refreshClusters(false),
+		a.startAutoRefresh(),
 		textinput.Blink,
 	)
 }
@@ -85,12 +171,42 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return a.handleWindowResize(msg)
 	case models.ClustersMsg:
 		return a.handleClustersMsg(msg)
+	case models.ClusterUpdateMsg:
+		return a.handleClusterUpdateMsg(msg)
+	case models.RefreshTickMsg:
+		return a.handleRefreshTick(msg)
 	case models.NodesMsg:
 		return a.handleNodesMsg(msg)
+	case models.NodeDetailMsg:
+		return a.handleNodeDetailMsg(msg)
 	case models.ClusterDetailMsg:
 		return a.handleClusterDetailMsg(msg)
+	case models.TemplatesMsg:
+		return a.handleTemplatesMsg(msg)
 	case models.MessageMsg:
 		return a.handleMessageMsg(msg)
+	case models.ProgressMsg:
+		return a.handleProgressMsg(msg)
+	case models.OperationDoneMsg:
+		return a.handleOperationDoneMsg(msg)
+	case models.PodsMsg:
+		return a.handlePodsMsg(msg)
+	case models.PodsTickMsg:
+		return a.handlePodsTick(msg)
+	case models.ImagesMsg:
+		a.model.Images.SetImages(msg.Images, msg.Err)
+		return a, nil
+	case models.ClusterImagesMsg:
+		a.model.NodeImages.SetImages(msg.Cluster, msg.Images, msg.Err)
+		return a, nil
+	case models.ForwardsTickMsg:
+		return a.handleForwardsTick(msg)
+	case models.LogLinesMsg:
+		return a.handleLogLinesMsg(msg)
+	case models.LogDoneMsg:
+		return a.handleLogDoneMsg(msg)
+	case spinner.TickMsg:
+		return a.handleSpinnerTick(msg)
 	case tea.KeyMsg:
 		return a.handleKeyMsg(msg)
 	}
@@ -105,7 +221,13 @@ func (a *App) View() string {
 	var content string
 
 	// Header
-	header := styles.Title.Render("KIND Interactive")
+	header := styles.Title.Render("KIND Interactive") + " " + styles.Help.Render("["+cmd.ActiveProvider+"]")
+	if running := a.model.Jobs.Running(); running > 0 {
+		header += " " + styles.Status.Render(fmt.Sprintf("%s %d running", a.model.Spinner.View(), running))
+	}
+	if forwards := len(cmd.Forwards.List()); forwards > 0 {
+		header += " " + styles.Help.Render(fmt.Sprintf("⇄ %d forwarded", forwards))
+	}
 
 	// Message display
 	message := ""
@@ -125,14 +247,49 @@ func (a *App) View() string {
 	case models.MainMenuView:
 		content = a.model.MainMenu.View()
 	case models.ClusterListView:
-		content = a.model.ClusterList.View()
+		content = a.model.ClusterList.View() + "\n" + views.RenderUpdated(a.model.Refresh.Clusters, a.model.Refresh.Interval)
 	case models.ClusterDetailView:
-		content = views.RenderClusterDetail(a.model.CurrentCluster)
+		content = views.RenderClusterDetail(a.model.CurrentCluster, a.model.DetailChanges) +
+			"\n" + views.RenderUpdated(a.model.Refresh.Detail, a.model.Refresh.Interval)
 	case models.NodeListView:
-		content = a.model.NodeList.View()
+		content = a.model.NodeList.View() + "\n" + views.RenderUpdated(a.model.Refresh.Nodes, a.model.Refresh.Interval) +
+			"\n" + views.RenderNodeSummary(a.selectedNode())
+	case models.NodeDetailView:
+		content = views.RenderNodeDetail(a.model.NodeDetail, a.model.NodeDetailError) +
+			"\n" + views.RenderUpdated(a.model.Refresh.Node, a.model.Refresh.Interval)
 	case models.DeleteConfirmView:
-		content = views.RenderDeleteConfirmation(a.model.ClusterToDelete, a.model.DeleteConfirmChoice)
-	case models.CreateClusterView, models.LoadImageView, models.BuildImageView, models.ExportLogsView:
+		if a.model.TemplateToDelete != "" {
+			content = views.RenderTemplateDeleteConfirmation(a.model.TemplateToDelete, a.model.DeleteConfirmChoice)
+		} else if a.model.WorkerToRemove != "" {
+			content = views.RenderWorkerRemoveConfirmation(a.model.NodesCluster, a.model.WorkerToRemove, a.model.DeleteConfirmChoice)
+		} else {
+			content = views.RenderDeleteConfirmation(a.model.ClusterToDelete, a.model.DeleteConfirmChoice)
+		}
+	case models.QuitConfirmView:
+		content = views.RenderQuitConfirmation(a.model.Jobs.Running(), a.model.DeleteConfirmChoice)
+	case models.TemplateListView:
+		content = a.model.TemplateList.View()
+	case models.TemplatePreviewView:
+		content = views.RenderTemplatePreview(a.model.CurrentTemplate)
+	case models.OperationView:
+		content = views.RenderOperation(a.model.Jobs.Get(a.model.CurrentJob), a.model.Spinner.View())
+	case models.JobListView:
+		content = views.RenderJobs(a.model.Jobs.All(), a.model.JobCursor, a.model.Spinner.View())
+	case models.PodListView:
+		content = views.RenderPods(a.model.Pods, a.model.Height-12)
+	case models.LogView:
+		content = views.RenderLogs(a.model.Logs)
+	case models.PortForwardListView:
+		content = views.RenderPortForwards(cmd.Forwards.List(), a.model.ForwardCursor)
+	case models.ImagePickerView:
+		content = views.RenderImages(a.model.Images, a.model.Height-12)
+	case models.ClusterImagesView:
+		content = views.RenderClusterImages(a.model.NodeImages, a.model.Height-14)
+	case models.KubeconfigView:
+		content = views.RenderKubeconfigMenu(a.model.Kubeconfig)
+	case models.CreateClusterView:
+		content = views.RenderClusterForm(a.model.ClusterForm, a.model.EditingTemplate)
+	case models.LoadImageView, models.BuildImageView, models.ExportLogsView, models.TemplateNameView, models.PortForwardView, models.KubeconfigExportView:
 		content = fmt.Sprintf(
 			"%s\n\n%s\n\n%s",
 			a.model.InputPrompt,
@@ -161,13 +318,67 @@ func (a *App) View() string {
 	footer := ""
 	switch a.model.CurrentView {
 	case models.MainMenuView:
-		footer = styles.Help.Render("\nc create • ? help • q quit")
+		footer = styles.Help.Render("\nc create • J jobs • ? help • q quit")
 	case models.ClusterListView:
-		footer = styles.Help.Render("\nenter/i info • n nodes • d delete • c create • l load • L logs • r refresh • esc back • ? help • q quit")
-	case models.ClusterDetailView, models.NodeListView:
-		footer = styles.Help.Render("\nesc back • ? help • q quit")
+		footer = styles.Help.Render("\nenter/i info • n nodes • d delete • c create • l load • I images • K kubeconfig • L logs • p pods • F forward" + lifecycleHints() + " • J jobs • r refresh • esc back • ? help • q quit")
+	case models.ClusterDetailView:
+		footer = styles.Help.Render("\np pods • I images • K kubeconfig • g " + registryHint(a.model.CurrentCluster) + " • r refresh • esc back • ? help • q quit")
+	case models.NodeListView:
+		footer = styles.Help.Render("\nenter/i details • L logs • ! shell • + add worker • - remove worker • r refresh • esc back • ? help • q quit")
+	case models.NodeDetailView:
+		footer = styles.Help.Render("\nL logs • ! shell • r refresh • esc back • ? help • q quit")
+	case models.PodListView:
+		if a.model.Pods.Filtering {
+			footer = styles.Help.Render("\nenter apply filter • esc clear filter")
+		} else {
+			footer = styles.Help.Render("\n↑/↓ select • enter logs • ! shell • F forward • N namespace • / filter • o sort • r refresh • esc back • q quit")
+		}
+	case models.ImagePickerView:
+		switch {
+		case a.model.Images.Filtering:
+			footer = styles.Help.Render("\nenter apply filter • esc clear filter")
+		case a.model.Images.PickingNodes:
+			footer = styles.Help.Render("\n↑/↓ select • space mark node • enter/esc done")
+		default:
+			nodes := ""
+			if len(a.model.Images.Nodes) > 1 {
+				nodes = " • n nodes"
+			}
+			footer = styles.Help.Render("\n↑/↓ select • space mark • enter load" + nodes + " • / filter • m enter name • a archive • b build • r refresh • esc back • q quit")
+		}
+	case models.KubeconfigView:
+		footer = styles.Help.Render("\n↑/↓ select • enter run • esc back • q quit")
+	case models.ClusterImagesView:
+		footer = styles.Help.Render("\n↑/↓ select • enter reload image • l load outdated • r refresh • esc back • q quit")
+	case models.LogView:
+		switch a.model.Logs.Prompt {
+		case models.LogPromptSearch:
+			footer = styles.Help.Render("\nenter search • esc cancel")
+		case models.LogPromptSave:
+			footer = styles.Help.Render("\nenter save • esc cancel")
+		default:
+			footer = styles.Help.Render("\n↑/↓/pgup/pgdn scroll • f follow • w wrap • / search • n/N match • tab " + logTargetHint(a.model.Logs) + " • t tail • T since • ctrl+s save • esc back • q quit")
+		}
 	case models.DeleteConfirmView:
 		footer = styles.Help.Render("\n←/→/tab select • enter confirm • y yes • n no • esc cancel • q quit")
+	case models.QuitConfirmView:
+		footer = styles.Help.Render("\n←/→/tab select • enter confirm • y quit • n stay • esc cancel")
+	case models.OperationView:
+		footer = styles.Help.Render("\nx cancel • esc jobs • q quit")
+	case models.JobListView:
+		footer = styles.Help.Render("\n↑/↓ select • enter open • x cancel • esc back • q quit")
+	case models.PortForwardListView:
+		footer = styles.Help.Render("\n↑/↓ select • d stop • esc back • q quit")
+	case models.TemplateListView:
+		footer = styles.Help.Render("\nenter use • v preview • e edit • C clone • d delete • esc back • q quit")
+	case models.TemplatePreviewView:
+		footer = styles.Help.Render("\nenter use • e edit • esc back • q quit")
+	case models.CreateClusterView:
+		if a.model.EditingTemplate != "" {
+			footer = styles.Help.Render("\ntab next • shift+tab prev • enter save • esc cancel • q quit")
+		} else {
+			footer = styles.Help.Render("\ntab next • shift+tab prev • enter create • ctrl+s save template • esc cancel • q quit")
+		}
 	default:
 		footer = styles.Help.Render("\nenter confirm • esc cancel • ? help • q quit")
 	}
@@ -176,6 +387,33 @@ func (a *App) View() string {
 	return strings.Join(parts, "\n")
 }
 
+// lifecycleHints lists the stop/start/restart keys enabled for the selected cluster
+func lifecycleHints() string {
+	hints := ""
+	for _, binding := range []key.Binding{models.Keys.Stop, models.Keys.Start, models.Keys.Restart} {
+		if binding.Enabled() {
+			hints += " • " + binding.Help().Key + " " + strings.TrimSuffix(binding.Help().Desc, " cluster")
+		}
+	}
+	return hints
+}
+
+// registryHint names what the registry key does for the cluster shown in detail
+func registryHint(cluster *cmd.Cluster) string {
+	if cluster != nil && cluster.Registry != "" {
+		return "detach registry"
+	}
+	return "attach registry"
+}
+
+// logTargetHint names what tab switches between in the log view
+func logTargetHint(l models.LogViewer) string {
+	if l.Source.IsNode() {
+		return "unit"
+	}
+	return "container • P previous"
+}
+
 func (a *App) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	a.model.Width = msg.Width
 	a.model.Height = msg.Height
@@ -186,50 +424,288 @@ func (a *App) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 	a.model.ClusterList.SetWidth(msg.Width)
 	a.model.ClusterList.SetHeight(msg.Height - 8)
 	a.model.NodeList.SetWidth(msg.Width)
-	a.model.NodeList.SetHeight(msg.Height - 8)
+	// The rest is left to the summary of the selected node
+	a.model.NodeList.SetHeight((msg.Height - 8) / 2)
+	a.model.TemplateList.SetWidth(msg.Width)
+	a.model.TemplateList.SetHeight(msg.Height - 8)
+	a.model.Logs.SetSize(msg.Width, msg.Height-10)
 	a.model.Help.Width = msg.Width
 
 	return a, nil
 }
 
+// refreshClusters starts a refresh of the cluster list, replacing any running
+// one. Unless forced, clusters enriched less than cmd.EnrichTTL ago are not
+// queried again.
+func (a *App) refreshClusters(force bool) tea.Cmd {
+	if force {
+		return a.reloadClusters(0)
+	}
+	return a.reloadClusters(cmd.EnrichTTL)
+}
+
+// reloadClusters starts a refresh of the cluster list that queries the
+// clusters enriched more than maxAge ago
+func (a *App) reloadClusters(maxAge time.Duration) tea.Cmd {
+	a.model.ClusterSeq++
+	return commands.RefreshClusters(a.model.ClusterSeq, maxAge)
+}
+
+// startAutoRefresh starts a new auto-refresh loop, replacing any running one
+func (a *App) startAutoRefresh() tea.Cmd {
+	a.model.Refresh.Seq++
+	if a.model.Refresh.Interval <= 0 {
+		return nil
+	}
+	return commands.RefreshTick(a.model.Refresh.Seq, a.model.Refresh.Interval)
+}
+
+// handleRefreshTick reloads the open view when it is one that refreshes on its
+// own. The loop keeps ticking elsewhere, e.g. while an input view is open, so
+// refreshing resumes when the user comes back.
+func (a *App) handleRefreshTick(msg models.RefreshTickMsg) (tea.Model, tea.Cmd) {
+	if msg.Seq != a.model.Refresh.Seq {
+		return a, nil
+	}
+	next := commands.RefreshTick(msg.Seq, a.model.Refresh.Interval)
+
+	switch a.model.CurrentView {
+	case models.ClusterListView:
+		// Clusters another view queried within the interval are up to date
+		return a, tea.Batch(next, a.reloadClusters(a.model.Refresh.Interval))
+	case models.NodeListView:
+		return a, tea.Batch(next, commands.GetClusterNodes(a.model.NodesCluster))
+	case models.ClusterDetailView:
+		if a.model.CurrentCluster != nil {
+			return a, tea.Batch(next, commands.GetClusterDetail(a.model.CurrentCluster.Name))
+		}
+	case models.NodeDetailView:
+		return a, tea.Batch(next, commands.GetNodeDetail(a.model.NodesCluster, a.model.NodeName))
+	}
+	return a, next
+}
+
 func (a *App) handleClustersMsg(msg models.ClustersMsg) (tea.Model, tea.Cmd) {
-	a.model.Clusters = []cmd.Cluster(msg)
+	if msg.Seq != a.model.ClusterSeq {
+		return a, nil
+	}
+	a.model.Clusters = msg.Clusters
+	a.model.Refresh.Clusters = time.Now()
+	a.setClusterItems()
+	return a, msg.Next
+}
+
+// handleClusterUpdateMsg replaces one cluster of the list as soon as it is enriched
+func (a *App) handleClusterUpdateMsg(msg models.ClusterUpdateMsg) (tea.Model, tea.Cmd) {
+	if msg.Seq != a.model.ClusterSeq {
+		return a, nil
+	}
+	for i := range a.model.Clusters {
+		if a.model.Clusters[i].Name == msg.Cluster.Name {
+			a.model.Clusters[i] = msg.Cluster
+		}
+	}
+	a.model.Refresh.Clusters = time.Now()
+	a.setClusterItems()
+	return a, msg.Next
+}
 
-	// Update cluster list items
+// setClusterItems rebuilds the cluster list from the known clusters
+func (a *App) setClusterItems() {
 	items := make([]list.Item, len(a.model.Clusters))
 	for i, cluster := range a.model.Clusters {
+		// Stopped clusters have no API server to list nodes, fall back to the containers
 		nodeCount := len(cluster.Nodes)
-		description := fmt.Sprintf("Status: %s | Nodes: %d", cluster.Status, nodeCount)
+		if nodeCount == 0 {
+			nodeCount = len(cluster.Containers)
+		}
+		status := cluster.Status
+		if status == "" {
+			// Not enriched yet
+			status = "loading..."
+		}
+		if status == cmd.StatusDegraded {
+			status += fmt.Sprintf(" (%d/%d up)", runningContainers(cluster), len(cluster.Containers))
+		}
+		description := fmt.Sprintf("Status: %s | Nodes: %d", status, nodeCount)
 		if cluster.KubeVersion != "" {
 			description += fmt.Sprintf(" | K8s: %s", cluster.KubeVersion)
 		}
+		if cluster.Error != "" {
+			description += " | " + cluster.Error
+		}
 
 		items[i] = models.NewItem(cluster.Name, description, "select")
 	}
 	a.model.ClusterList.SetItems(items)
+	a.updateClusterKeys()
+}
 
-	return a, nil
+// runningContainers counts the node containers of a cluster that are up
+func runningContainers(cluster cmd.Cluster) int {
+	running := 0
+	for _, c := range cluster.Containers {
+		if c.Running() {
+			running++
+		}
+	}
+	return running
 }
 
 func (a *App) handleNodesMsg(msg models.NodesMsg) (tea.Model, tea.Cmd) {
 	nodes := []cmd.Node(msg)
+	a.model.NodeChanges = models.NodeStatusChanges(a.model.Nodes, nodes)
+	a.model.Nodes = nodes
+	a.model.Refresh.Nodes = time.Now()
 
 	// Update node list items
 	items := make([]list.Item, len(nodes))
 	for i, node := range nodes {
-		items[i] = models.NewItem(node.Name, fmt.Sprintf("Role: %s | Status: %s | Age: %s | IP: %s", node.Role, node.Status, node.Age, node.InternalIP), "select")
+		description := fmt.Sprintf("Role: %s | Status: %s | Age: %s | IP: %s", node.Role, node.Status, node.Age, node.InternalIP)
+		if node.Version != "" {
+			description += " | " + node.Version
+		}
+		if previous, ok := a.model.NodeChanges[node.Name]; ok {
+			description += " | " + styles.Error.Render("was "+previous)
+		}
+		items[i] = models.NewItem(node.Name, description, "select")
 	}
 	a.model.NodeList.SetItems(items)
 
 	return a, nil
 }
 
+// selectedNode returns the node under the cursor of the node list
+func (a *App) selectedNode() *cmd.Node {
+	item, ok := a.model.NodeList.SelectedItem().(models.Item)
+	if !ok {
+		return nil
+	}
+	for i := range a.model.Nodes {
+		if a.model.Nodes[i].Name == item.Title() {
+			return &a.model.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// handleNodeDetailMsg shows the detail of the open node. A failed refresh
+// keeps the last detail on screen along with the error.
+func (a *App) handleNodeDetailMsg(msg models.NodeDetailMsg) (tea.Model, tea.Cmd) {
+	if msg.Cluster != a.model.NodesCluster || msg.Node != a.model.NodeName {
+		return a, nil
+	}
+	if msg.Err != nil {
+		a.model.NodeDetailError = msg.Err.Error()
+		return a, nil
+	}
+	a.model.NodeDetail = msg.Detail
+	a.model.NodeDetailError = ""
+	a.model.Refresh.Node = time.Now()
+	return a, nil
+}
+
 func (a *App) handleClusterDetailMsg(msg models.ClusterDetailMsg) (tea.Model, tea.Cmd) {
 	cluster := cmd.Cluster(msg)
+	a.model.DetailChanges = nil
+	if previous := a.model.CurrentCluster; previous != nil && previous.Name == cluster.Name {
+		a.model.DetailChanges = models.NodeStatusChanges(previous.Nodes, cluster.Nodes)
+	}
 	a.model.CurrentCluster = &cluster
+	a.model.Refresh.Detail = time.Now()
+	return a, nil
+}
+
+func (a *App) handleTemplatesMsg(msg models.TemplatesMsg) (tea.Model, tea.Cmd) {
+	a.model.Templates = []cmd.Template(msg)
+
+	// The first entry always starts from an empty form
+	items := make([]list.Item, 0, len(a.model.Templates)+1)
+	items = append(items, models.NewItem("Custom", "Start from an empty form", "custom"))
+	for _, template := range a.model.Templates {
+		description := template.Summary()
+		if template.Description != "" {
+			description = template.Description + " | " + description
+		}
+		items = append(items, models.NewItem(template.Name, description, "template"))
+	}
+	a.model.TemplateList.SetItems(items)
+
 	return a, nil
 }
 
+func (a *App) handleProgressMsg(msg models.ProgressMsg) (tea.Model, tea.Cmd) {
+	if job := a.model.Jobs.Get(msg.JobID); job != nil {
+		job.AddLine(msg.Line)
+	}
+	return a, msg.Next
+}
+
+func (a *App) handleOperationDoneMsg(msg models.OperationDoneMsg) (tea.Model, tea.Cmd) {
+	if job := a.model.Jobs.Get(msg.JobID); job != nil {
+		result, ok := msg.Result.(models.MessageMsg)
+		job.Finish(ok && result.MsgType == "error")
+	}
+	return a.Update(msg.Result)
+}
+
+func (a *App) handlePodsMsg(msg models.PodsMsg) (tea.Model, tea.Cmd) {
+	// Drop results of a refresh loop that has since been replaced
+	if msg.Seq != a.model.Pods.Seq || msg.Cluster != a.model.Pods.Cluster {
+		return a, nil
+	}
+	a.model.Pods.SetPods(msg.Pods, msg.Err)
+	if a.model.CurrentView != models.PodListView {
+		return a, nil
+	}
+	return a, commands.PodsTick(msg.Seq)
+}
+
+func (a *App) handlePodsTick(msg models.PodsTickMsg) (tea.Model, tea.Cmd) {
+	if msg.Seq != a.model.Pods.Seq || a.model.CurrentView != models.PodListView {
+		return a, nil
+	}
+	return a, commands.GetPods(a.model.Pods.Cluster, "", msg.Seq)
+}
+
+func (a *App) handleLogLinesMsg(msg models.LogLinesMsg) (tea.Model, tea.Cmd) {
+	if msg.Seq == a.model.Logs.Seq {
+		a.model.Logs.AddLines(msg.Lines)
+	}
+	// Drain replaced streams too so their commands can exit
+	return a, msg.Next
+}
+
+func (a *App) handleLogDoneMsg(msg models.LogDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.Seq != a.model.Logs.Seq {
+		return a, nil
+	}
+	a.model.Logs.Done = true
+	a.model.Logs.Cancel = nil
+	if msg.Result == nil {
+		return a, nil
+	}
+	return a.Update(msg.Result)
+}
+
+func (a *App) handleForwardsTick(msg models.ForwardsTickMsg) (tea.Model, tea.Cmd) {
+	// Redrawing is all a tick does; it stops once the view is left
+	if msg.Seq != a.model.ForwardSeq || a.model.CurrentView != models.PortForwardListView {
+		return a, nil
+	}
+	return a, commands.ForwardsTick(msg.Seq)
+}
+
+func (a *App) handleSpinnerTick(msg spinner.TickMsg) (tea.Model, tea.Cmd) {
+	// Only keep ticking while there is something to animate
+	if a.model.Jobs.Running() == 0 {
+		return a, nil
+	}
+	var cmd tea.Cmd
+	a.model.Spinner, cmd = a.model.Spinner.Update(msg)
+	return a, cmd
+}
+
 func (a *App) handleMessageMsg(msg models.MessageMsg) (tea.Model, tea.Cmd) {
 	a.model.Message = msg.Text
 	a.model.MessageType = msg.MsgType
@@ -245,17 +721,67 @@ func (a *App) handleMessageMsg(msg models.MessageMsg) (tea.Model, tea.Cmd) {
 
 	// Refresh clusters after operations
 	if msg.MsgType == "success" && strings.Contains(msg.Text, "Cluster") {
-		cmds = append(cmds, commands.GetKindClusters())
+		cmds = append(cmds, a.refreshClusters(false))
+	}
+	if msg.MsgType == "success" && strings.HasPrefix(msg.Text, "Registry") && a.model.CurrentCluster != nil {
+		cmds = append(cmds, commands.GetClusterDetail(a.model.CurrentCluster.Name))
+	}
+	if msg.MsgType == "success" && strings.Contains(msg.Text, "loaded") && a.model.NodeImages.Cluster != "" {
+		cmds = append(cmds, commands.GetClusterImages(a.model.NodeImages.Cluster))
+	}
+	if msg.MsgType == "success" && strings.HasPrefix(msg.Text, "Worker") && a.model.NodesCluster != "" {
+		cmds = append(cmds, commands.GetClusterNodes(a.model.NodesCluster))
+	}
+	if msg.MsgType == "success" && strings.Contains(msg.Text, "Template") {
+		cmds = append(cmds, commands.GetTemplates())
 	}
 
 	return a, tea.Batch(cmds...)
 }
 
+// quit exits ki, after asking first when jobs are still running
+func (a *App) quit() (tea.Model, tea.Cmd) {
+	if a.model.Jobs.Running() > 0 && a.model.CurrentView != models.QuitConfirmView {
+		a.model.QuitReturn = a.model.CurrentView
+		a.model.DeleteConfirmChoice = 1 // default to staying
+		a.model.CurrentView = models.QuitConfirmView
+		return a, nil
+	}
+	a.model.Quitting = true
+	return a, tea.Quit
+}
+
+// typingView reports whether a view is a text input or form that keys are typed into
+func typingView(view models.ViewMode) bool {
+	switch view {
+	case models.CreateClusterView, models.LoadImageView, models.BuildImageView, models.ExportLogsView, models.TemplateNameView, models.PortForwardView, models.KubeconfigExportView:
+		return true
+	}
+	return false
+}
+
 func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The pod filter and log prompts take every key while they are being typed
+	if a.model.CurrentView == models.PodListView && a.model.Pods.Filtering {
+		return a.handlePodListKeys(msg)
+	}
+	if a.model.CurrentView == models.ImagePickerView && (a.model.Images.Filtering || a.model.Images.PickingNodes) {
+		return a.handleImagePickerKeys(msg)
+	}
+	if a.model.CurrentView == models.LogView && a.model.Logs.Prompt != models.LogPromptNone {
+		return a.handleLogKeys(msg)
+	}
+	// So do text inputs, except for ctrl+c and esc
+	if typingView(a.model.CurrentView) && msg.String() != "ctrl+c" && !key.Matches(msg, models.Keys.Back) {
+		if a.model.CurrentView == models.CreateClusterView {
+			return a.handleClusterFormKeys(msg)
+		}
+		return a.handleInputKeys(msg)
+	}
+
 	switch {
 	case key.Matches(msg, models.Keys.Quit):
-		a.model.Quitting = true
-		return a, tea.Quit
+		return a.quit()
 
 	case key.Matches(msg, models.Keys.Help):
 		a.model.ShowHelp = !a.model.ShowHelp
@@ -263,13 +789,62 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case key.Matches(msg, models.Keys.Back):
 		if a.model.CurrentView != models.MainMenuView {
-			if a.model.CurrentView == models.DeleteConfirmView {
+			switch {
+			case a.model.CurrentView == models.DeleteConfirmView && a.model.TemplateToDelete != "":
+				// Cancel deletion, go back to template list
+				a.model.CurrentView = models.TemplateListView
+				a.model.TemplateToDelete = ""
+			case a.model.CurrentView == models.DeleteConfirmView && a.model.WorkerToRemove != "":
+				a.model.CurrentView = models.NodeListView
+				a.model.WorkerToRemove = ""
+			case a.model.CurrentView == models.DeleteConfirmView:
 				// Cancel deletion, go back to cluster list
 				a.model.CurrentView = models.ClusterListView
 				a.model.ClusterToDelete = ""
-			} else {
+			case a.model.CurrentView == models.QuitConfirmView:
+				a.model.CurrentView = a.model.QuitReturn
+			case a.model.CurrentView == models.OperationView:
+				a.model.CurrentView = models.JobListView
+			case a.model.CurrentView == models.PodListView:
+				// Leaving the view ends its refresh loop
+				a.model.CurrentView = a.model.Pods.ReturnTo
+				a.model.Pods.Seq++
+			case a.model.CurrentView == models.LogView:
+				return a.closeLogs()
+			case a.model.CurrentView == models.NodeDetailView:
+				a.model.CurrentView = models.NodeListView
+			case a.model.CurrentView == models.ImagePickerView:
+				a.model.CurrentView = a.model.Images.ReturnTo
+				a.model.SelectedCluster = ""
+			case a.model.CurrentView == models.ClusterImagesView:
+				a.model.CurrentView = a.model.NodeImages.ReturnTo
+			case a.model.CurrentView == models.KubeconfigView:
+				a.model.CurrentView = a.model.Kubeconfig.ReturnTo
+			case a.model.CurrentView == models.KubeconfigExportView:
+				a.model.CurrentView = models.KubeconfigView
+				a.model.TextInput.SetValue("")
+				a.model.InputAction = ""
+			case a.model.CurrentView == models.LoadImageView:
+				// Manual entry is opened from the image picker
+				a.model.CurrentView = models.ImagePickerView
+				a.model.TextInput.SetValue("")
+				a.model.InputAction = ""
+			case a.model.CurrentView == models.PortForwardView:
+				a.model.TextInput.SetValue("")
+				a.model.InputAction = ""
+				return a.returnTo(a.model.ForwardReturn)
+			case a.model.CurrentView == models.TemplatePreviewView,
+				a.model.CurrentView == models.TemplateNameView,
+				a.model.CurrentView == models.CreateClusterView && a.model.EditingTemplate != "":
+				a.model.CurrentView = models.TemplateListView
+				a.model.TextInput.SetValue("")
+				a.model.ClusterForm.Reset()
+				a.model.InputAction = ""
+				a.model.EditingTemplate = ""
+			default:
 				a.model.CurrentView = models.MainMenuView
 				a.model.TextInput.SetValue("")
+				a.model.ClusterForm.Reset()
 				a.model.InputAction = ""
 				a.model.SelectedCluster = ""
 			}
@@ -287,9 +862,35 @@ func (a *App) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return a.handleClusterDetailKeys(msg)
 	case models.NodeListView:
 		return a.handleNodeListKeys(msg)
+	case models.NodeDetailView:
+		return a.handleNodeDetailKeys(msg)
 	case models.DeleteConfirmView:
 		return a.handleDeleteConfirmKeys(msg)
-	case models.CreateClusterView, models.LoadImageView, models.BuildImageView, models.ExportLogsView:
+	case models.QuitConfirmView:
+		return a.handleQuitConfirmKeys(msg)
+	case models.OperationView:
+		return a.handleOperationKeys(msg)
+	case models.JobListView:
+		return a.handleJobListKeys(msg)
+	case models.PodListView:
+		return a.handlePodListKeys(msg)
+	case models.LogView:
+		return a.handleLogKeys(msg)
+	case models.PortForwardListView:
+		return a.handlePortForwardListKeys(msg)
+	case models.ImagePickerView:
+		return a.handleImagePickerKeys(msg)
+	case models.ClusterImagesView:
+		return a.handleClusterImagesKeys(msg)
+	case models.KubeconfigView:
+		return a.handleKubeconfigKeys(msg)
+	case models.TemplateListView:
+		return a.handleTemplateListKeys(msg)
+	case models.TemplatePreviewView:
+		return a.handleTemplatePreviewKeys(msg)
+	case models.CreateClusterView:
+		return a.handleClusterFormKeys(msg)
+	case models.LoadImageView, models.BuildImageView, models.ExportLogsView, models.TemplateNameView, models.PortForwardView, models.KubeconfigExportView:
 		return a.handleInputKeys(msg)
 	}
 
@@ -1,10 +1,16 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"ki/internal/cmd"
 	"ki/internal/ui/commands"
 	"ki/internal/ui/models"
 )
@@ -20,21 +26,11 @@ func (a *App) handleMainMenuKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			switch item.Action {
 			case "clusters":
 				a.model.CurrentView = models.ClusterListView
-				return a, commands.GetKindClusters()
+				return a, a.refreshClusters(false)
 			case "create":
-				a.model.CurrentView = models.CreateClusterView
-				a.model.InputPrompt = "Enter cluster name (leave empty for 'kind'):"
-				a.model.InputAction = "create"
-				a.model.TextInput.Placeholder = "cluster-name"
-				a.model.TextInput.Focus()
-				return a, nil
+				return a.openTemplateList()
 			case "load":
-				a.model.CurrentView = models.LoadImageView
-				a.model.InputPrompt = "Enter Docker image name:"
-				a.model.InputAction = "load-image"
-				a.model.TextInput.Placeholder = "nginx:latest"
-				a.model.TextInput.Focus()
-				return a, nil
+				return a.openImagePicker("", models.MainMenuView)
 			case "build":
 				a.model.CurrentView = models.BuildImageView
 				a.model.InputPrompt = "Enter Kubernetes source path (leave empty for default):"
@@ -49,16 +45,22 @@ func (a *App) handleMainMenuKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				a.model.TextInput.Placeholder = "./logs"
 				a.model.TextInput.Focus()
 				return a, nil
+			case "jobs":
+				return a.openJobList()
+			case "forwards":
+				return a.openPortForwards()
+			case "registry":
+				return a.startOperation("registry", "", "Starting local registry", func(ctx context.Context, out io.Writer) tea.Cmd {
+					return commands.CreateRegistry(ctx, out)
+				})
 			}
 		}
 	case key.Matches(msg, models.Keys.Create):
-		a.model.CurrentView = models.CreateClusterView
-		a.model.InputPrompt = "Enter cluster name:"
-		a.model.InputAction = "create"
-		a.model.TextInput.Focus()
-		return a, nil
+		return a.openTemplateList()
+	case key.Matches(msg, models.Keys.Jobs):
+		return a.openJobList()
 	case key.Matches(msg, models.Keys.Refresh):
-		return a, commands.GetKindClusters()
+		return a, a.refreshClusters(true)
 	}
 
 	a.model.MainMenu, cmd = a.model.MainMenu.Update(msg)
@@ -83,6 +85,13 @@ func (a *App) handleClusterListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if item, ok := selectedItem.(models.Item); ok {
 			a.model.CurrentView = models.NodeListView
 			a.model.NodeList.Title = "Nodes - " + item.Title()
+			if a.model.NodesCluster != item.Title() {
+				// Another cluster's nodes are no base for status changes
+				a.model.NodesCluster = item.Title()
+				a.model.Nodes = nil
+				a.model.NodeName = ""
+				a.model.NodeList.SetItems(nil)
+			}
 			return a, commands.GetClusterNodes(item.Title())
 		}
 	case key.Matches(msg, models.Keys.Delete):
@@ -95,21 +104,18 @@ func (a *App) handleClusterListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return a, nil
 		}
 	case key.Matches(msg, models.Keys.Create):
-		a.model.CurrentView = models.CreateClusterView
-		a.model.InputPrompt = "Enter cluster name:"
-		a.model.InputAction = "create"
-		a.model.TextInput.Focus()
-		return a, nil
+		return a.openTemplateList()
 	case key.Matches(msg, models.Keys.Load):
-		selectedItem := a.model.ClusterList.SelectedItem()
-		if item, ok := selectedItem.(models.Item); ok {
-			a.model.SelectedCluster = item.Title()
-			a.model.CurrentView = models.LoadImageView
-			a.model.InputPrompt = "Enter Docker image name to load into '" + item.Title() + "':"
-			a.model.InputAction = "load-image"
-			a.model.TextInput.Placeholder = "nginx:latest"
-			a.model.TextInput.Focus()
-			return a, nil
+		if cluster := a.selectedCluster(); cluster != nil {
+			return a.openImagePicker(cluster.Name, models.ClusterListView)
+		}
+	case key.Matches(msg, models.Keys.Images):
+		if cluster := a.selectedCluster(); cluster != nil {
+			return a.openClusterImages(cluster.Name, models.ClusterListView)
+		}
+	case key.Matches(msg, models.Keys.Kubeconfig):
+		if cluster := a.selectedCluster(); cluster != nil {
+			return a.openKubeconfig(cluster.Name, models.ClusterListView)
 		}
 	case key.Matches(msg, models.Keys.Logs):
 		selectedItem := a.model.ClusterList.SelectedItem()
@@ -122,29 +128,190 @@ func (a *App) handleClusterListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			a.model.TextInput.Focus()
 			return a, nil
 		}
+	case key.Matches(msg, models.Keys.Pods):
+		if cluster := a.selectedCluster(); cluster != nil {
+			return a.openPods(cluster.Name, models.ClusterListView)
+		}
+	case key.Matches(msg, models.Keys.Forward):
+		if cluster := a.selectedCluster(); cluster != nil {
+			return a.openForwardPrompt(cluster.Name, "", models.ClusterListView)
+		}
+	case key.Matches(msg, models.Keys.Stop):
+		if cluster := a.selectedCluster(); cluster != nil {
+			name := cluster.Name
+			return a.startOperation("stop", name, fmt.Sprintf("Stopping cluster '%s'", name), func(ctx context.Context, out io.Writer) tea.Cmd {
+				return commands.StopKindCluster(ctx, name, out)
+			})
+		}
+	case key.Matches(msg, models.Keys.Start):
+		if cluster := a.selectedCluster(); cluster != nil {
+			name := cluster.Name
+			return a.startOperation("start", name, fmt.Sprintf("Starting cluster '%s'", name), func(ctx context.Context, out io.Writer) tea.Cmd {
+				return commands.StartKindCluster(ctx, name, out)
+			})
+		}
+	case key.Matches(msg, models.Keys.Restart):
+		if cluster := a.selectedCluster(); cluster != nil {
+			name := cluster.Name
+			return a.startOperation("restart", name, fmt.Sprintf("Restarting cluster '%s'", name), func(ctx context.Context, out io.Writer) tea.Cmd {
+				return commands.RestartKindCluster(ctx, name, out)
+			})
+		}
+	case key.Matches(msg, models.Keys.Jobs):
+		return a.openJobList()
 	case key.Matches(msg, models.Keys.Refresh):
-		return a, commands.GetKindClusters()
+		// An explicit refresh queries every cluster again
+		return a, a.refreshClusters(true)
 	}
 
 	a.model.ClusterList, cmd = a.model.ClusterList.Update(msg)
 	cmds = append(cmds, cmd)
+	a.updateClusterKeys()
 
 	return a, tea.Batch(cmds...)
 }
 
+// selectedCluster returns the cluster highlighted in the cluster list
+func (a *App) selectedCluster() *cmd.Cluster {
+	item, ok := a.model.ClusterList.SelectedItem().(models.Item)
+	if !ok {
+		return nil
+	}
+	for i := range a.model.Clusters {
+		if a.model.Clusters[i].Name == item.Title() {
+			return &a.model.Clusters[i]
+		}
+	}
+	return nil
+}
+
+// updateClusterKeys enables only the lifecycle keys that apply to the selected cluster
+func (a *App) updateClusterKeys() {
+	status := ""
+	if cluster := a.selectedCluster(); cluster != nil {
+		status = cluster.Status
+	}
+	models.Keys.Stop.SetEnabled(cmd.CanStop(status))
+	models.Keys.Start.SetEnabled(cmd.CanStart(status))
+	models.Keys.Restart.SetEnabled(cmd.CanRestart(status))
+}
+
 func (a *App) handleClusterDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, models.Keys.Pods) && a.model.CurrentCluster != nil {
+		return a.openPods(a.model.CurrentCluster.Name, models.ClusterDetailView)
+	}
+	if key.Matches(msg, models.Keys.Registry) && a.model.CurrentCluster != nil {
+		return a.toggleRegistry(a.model.CurrentCluster)
+	}
+	if key.Matches(msg, models.Keys.Images) && a.model.CurrentCluster != nil {
+		return a.openClusterImages(a.model.CurrentCluster.Name, models.ClusterDetailView)
+	}
+	if key.Matches(msg, models.Keys.Kubeconfig) && a.model.CurrentCluster != nil {
+		return a.openKubeconfig(a.model.CurrentCluster.Name, models.ClusterDetailView)
+	}
+	if key.Matches(msg, models.Keys.Refresh) && a.model.CurrentCluster != nil {
+		return a, commands.GetClusterDetail(a.model.CurrentCluster.Name)
+	}
+
 	var cmd tea.Cmd
 	a.model.ClusterList, cmd = a.model.ClusterList.Update(msg)
 	return a, cmd
 }
 
+// toggleRegistry attaches the local registry to a cluster, or detaches it when
+// the cluster already uses it
+func (a *App) toggleRegistry(cluster *cmd.Cluster) (tea.Model, tea.Cmd) {
+	name := cluster.Name
+	if cluster.Registry != "" {
+		return a.startOperation("registry", name, fmt.Sprintf("Detaching local registry from '%s'", name), func(ctx context.Context, out io.Writer) tea.Cmd {
+			return commands.DetachRegistry(ctx, name, out)
+		})
+	}
+	return a.startOperation("registry", name, fmt.Sprintf("Attaching local registry to '%s'", name), func(ctx context.Context, out io.Writer) tea.Cmd {
+		return commands.AttachRegistry(ctx, name, out)
+	})
+}
+
 func (a *App) handleNodeListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, models.Keys.Enter) || key.Matches(msg, models.Keys.Detail) {
+		if item, ok := a.model.NodeList.SelectedItem().(models.Item); ok {
+			return a.openNodeDetail(item.Title())
+		}
+	}
+	if key.Matches(msg, models.Keys.Logs) {
+		if item, ok := a.model.NodeList.SelectedItem().(models.Item); ok {
+			source := cmd.LogSource{Node: item.Title(), Unit: cmd.NodeLogUnits[0]}
+			return a.openLogs(source, cmd.NodeLogUnits, models.NodeListView)
+		}
+	}
+	if key.Matches(msg, models.Keys.Shell) {
+		if item, ok := a.model.NodeList.SelectedItem().(models.Item); ok {
+			return a, commands.Shell(cmd.NodeShellCommand(item.Title()), "node "+item.Title())
+		}
+	}
+	if key.Matches(msg, models.Keys.Refresh) {
+		return a, commands.GetClusterNodes(a.model.NodesCluster)
+	}
+	if key.Matches(msg, models.Keys.AddWorker) {
+		name := a.model.NodesCluster
+		return a.startOperation("worker", name, fmt.Sprintf("Adding a worker to cluster '%s'", name), func(ctx context.Context, out io.Writer) tea.Cmd {
+			return commands.AddWorker(ctx, name, out)
+		})
+	}
+	if key.Matches(msg, models.Keys.Remove) {
+		if node := a.selectedNode(); node != nil {
+			if node.Role != cmd.RoleWorker {
+				return a, func() tea.Msg {
+					return models.MessageMsg{Text: fmt.Sprintf("%s is a %s node, only workers can be removed", node.Name, node.Role), MsgType: "error"}
+				}
+			}
+			a.model.WorkerToRemove = node.Name
+			a.model.DeleteConfirmChoice = 0
+			a.model.CurrentView = models.DeleteConfirmView
+			return a, nil
+		}
+	}
+
 	var cmd tea.Cmd
 	a.model.NodeList, cmd = a.model.NodeList.Update(msg)
 	return a, cmd
 }
 
+// openNodeDetail shows the detail of a node of the cluster in the node list
+func (a *App) openNodeDetail(node string) (tea.Model, tea.Cmd) {
+	if a.model.NodeName != node {
+		a.model.NodeName = node
+		a.model.NodeDetail = nil
+		a.model.Refresh.Node = time.Time{}
+	}
+	a.model.NodeDetailError = ""
+	a.model.CurrentView = models.NodeDetailView
+	return a, commands.GetNodeDetail(a.model.NodesCluster, node)
+}
+
+func (a *App) handleNodeDetailKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	node := a.model.NodeName
+
+	switch {
+	case key.Matches(msg, models.Keys.Logs):
+		source := cmd.LogSource{Node: node, Unit: cmd.NodeLogUnits[0]}
+		return a.openLogs(source, cmd.NodeLogUnits, models.NodeDetailView)
+	case key.Matches(msg, models.Keys.Shell):
+		return a, commands.Shell(cmd.NodeShellCommand(node), "node "+node)
+	case key.Matches(msg, models.Keys.Refresh):
+		return a, commands.GetNodeDetail(a.model.NodesCluster, node)
+	}
+	return a, nil
+}
+
 func (a *App) handleDeleteConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if a.model.TemplateToDelete != "" {
+		return a.handleTemplateDeleteConfirmKeys(msg)
+	}
+	if a.model.WorkerToRemove != "" {
+		return a.handleWorkerRemoveConfirmKeys(msg)
+	}
+
 	switch {
 	case key.Matches(msg, models.Keys.Left), key.Matches(msg, models.Keys.Right), key.Matches(msg, models.Keys.Tab):
 		// Toggle between Yes (0) and No (1)
@@ -157,8 +324,7 @@ func (a *App) handleDeleteConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			clusterName := a.model.ClusterToDelete
 			a.model.ClusterToDelete = ""
 			a.model.DeleteConfirmChoice = 0
-			a.model.CurrentView = models.ClusterListView
-			return a, commands.DeleteKindCluster(clusterName)
+			return a.deleteCluster(clusterName)
 		} else {
 			// No - cancel deletion
 			a.model.ClusterToDelete = ""
@@ -171,8 +337,7 @@ func (a *App) handleDeleteConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		clusterName := a.model.ClusterToDelete
 		a.model.ClusterToDelete = ""
 		a.model.DeleteConfirmChoice = 0
-		a.model.CurrentView = models.ClusterListView
-		return a, commands.DeleteKindCluster(clusterName)
+		return a.deleteCluster(clusterName)
 	case key.Matches(msg, models.Keys.No):
 		// Direct 'n' key - cancel deletion
 		a.model.ClusterToDelete = ""
@@ -184,10 +349,786 @@ func (a *App) handleDeleteConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
-func (a *App) handleInputKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// startOperation runs a long-running command as a background job, streaming
+// its output into the operation view. Other jobs keep running alongside it.
+func (a *App) startOperation(jobType, cluster, title string, run func(ctx context.Context, out io.Writer) tea.Cmd) (tea.Model, tea.Cmd) {
+	ctx, cancel := context.WithCancel(context.Background())
+	job := a.model.Jobs.Start(jobType, cluster, title)
+	job.Cancel = cancel
+	stream := commands.NewStream(job.ID)
+
+	a.model.CurrentJob = job.ID
+	a.model.CurrentView = models.OperationView
+
+	cmds := []tea.Cmd{stream.Run(a.jobs.track(stream, run(ctx, stream))), stream.Next()}
+	// The spinner is already ticking when another job is running
+	if a.model.Jobs.Running() == 1 {
+		cmds = append(cmds, a.model.Spinner.Tick)
+	}
+	return a, tea.Batch(cmds...)
+}
+
+func (a *App) deleteCluster(name string) (tea.Model, tea.Cmd) {
+	return a.startOperation("delete", name, fmt.Sprintf("Deleting cluster '%s'", name), func(ctx context.Context, out io.Writer) tea.Cmd {
+		return commands.DeleteKindCluster(ctx, name, out)
+	})
+}
+
+func (a *App) handleOperationKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, models.Keys.Cancel) {
+		if job := a.model.Jobs.Get(a.model.CurrentJob); job != nil {
+			job.Abort()
+		}
+	}
+	return a, nil
+}
+
+func (a *App) openJobList() (tea.Model, tea.Cmd) {
+	a.model.JobCursor = 0
+	a.model.CurrentView = models.JobListView
+	return a, nil
+}
+
+func (a *App) handleJobListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	jobs := a.model.Jobs.All()
+	if len(jobs) == 0 {
+		return a, nil
+	}
+	a.model.JobCursor = min(a.model.JobCursor, len(jobs)-1)
+
+	switch {
+	case key.Matches(msg, models.Keys.Up):
+		if a.model.JobCursor > 0 {
+			a.model.JobCursor--
+		}
+	case key.Matches(msg, models.Keys.Down):
+		if a.model.JobCursor < len(jobs)-1 {
+			a.model.JobCursor++
+		}
+	case key.Matches(msg, models.Keys.Enter):
+		a.model.CurrentJob = jobs[a.model.JobCursor].ID
+		a.model.CurrentView = models.OperationView
+	case key.Matches(msg, models.Keys.Cancel):
+		jobs[a.model.JobCursor].Abort()
+	}
+
+	return a, nil
+}
+
+// openImagePicker lists the local images to load into cluster
+func (a *App) openImagePicker(cluster string, returnTo models.ViewMode) (tea.Model, tea.Cmd) {
+	a.model.Images.Open(cluster, returnTo)
+	if c := a.clusterNamed(cluster); c != nil {
+		a.model.Images.SetNodes(c.Nodes)
+	}
+	a.model.SelectedCluster = cluster
+	a.model.CurrentView = models.ImagePickerView
+	return a, commands.GetImages()
+}
+
+// clusterNamed returns a listed cluster by name, where no name is the default cluster
+func (a *App) clusterNamed(name string) *cmd.Cluster {
+	if name == "" {
+		name = "kind"
+	}
+	for i := range a.model.Clusters {
+		if a.model.Clusters[i].Name == name {
+			return &a.model.Clusters[i]
+		}
+	}
+	return nil
+}
+
+func (a *App) handleImagePickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	images := &a.model.Images
+
+	if images.PickingNodes {
+		switch {
+		case msg.String() == "ctrl+c":
+			return a.quit()
+		case key.Matches(msg, models.Keys.Up):
+			images.MoveNodeCursor(-1)
+		case key.Matches(msg, models.Keys.Down):
+			images.MoveNodeCursor(1)
+		case key.Matches(msg, models.Keys.Select):
+			images.ToggleTarget()
+		case key.Matches(msg, models.Keys.Enter), key.Matches(msg, models.Keys.Back), key.Matches(msg, models.Keys.Nodes):
+			images.PickingNodes = false
+		}
+		return a, nil
+	}
+
+	if images.Filtering {
+		switch msg.String() {
+		case "ctrl+c":
+			return a.quit()
+		case "enter":
+			images.Filtering = false
+			images.Filter.Blur()
+		case "esc":
+			images.Filtering = false
+			images.Filter.Blur()
+			images.Filter.SetValue("")
+		default:
+			var cmd tea.Cmd
+			images.Filter, cmd = images.Filter.Update(msg)
+			images.MoveCursor(0)
+			return a, cmd
+		}
+		images.MoveCursor(0)
+		return a, nil
+	}
+
+	switch {
+	case key.Matches(msg, models.Keys.Up):
+		images.MoveCursor(-1)
+	case key.Matches(msg, models.Keys.Down):
+		images.MoveCursor(1)
+	case key.Matches(msg, models.Keys.Select):
+		images.Toggle()
+	case key.Matches(msg, models.Keys.Nodes):
+		if len(images.Nodes) > 1 {
+			images.PickingNodes = true
+		}
+	case key.Matches(msg, models.Keys.Filter):
+		images.Filtering = true
+		return a, images.Filter.Focus()
+	case key.Matches(msg, models.Keys.Refresh):
+		return a, commands.GetImages()
+	case key.Matches(msg, models.Keys.Manual):
+		a.model.CurrentView = models.LoadImageView
+		a.model.InputPrompt = "Enter Docker image names to load into " + loadTarget(images.Cluster) + ":"
+		a.model.InputAction = "load-image"
+		a.model.TextInput.Placeholder = "nginx:latest redis:7"
+		a.model.TextInput.Focus()
+	case key.Matches(msg, models.Keys.Archive):
+		a.model.CurrentView = models.LoadImageView
+		a.model.InputPrompt = "Enter image archives to load into " + loadTarget(images.Cluster) + ":"
+		a.model.InputAction = "load-archive"
+		a.model.TextInput.Placeholder = "./image.tar"
+		a.model.TextInput.Focus()
+	case key.Matches(msg, models.Keys.Build):
+		a.model.CurrentView = models.LoadImageView
+		a.model.InputPrompt = "Enter a build context and tag to build and load into " + loadTarget(images.Cluster) + " (optionally followed by a Dockerfile):"
+		a.model.InputAction = "build-load"
+		a.model.TextInput.Placeholder = ". myapp:dev"
+		a.model.TextInput.Focus()
+	case key.Matches(msg, models.Keys.Enter):
+		if refs := images.Refs(); len(refs) > 0 {
+			return a.loadImages(refs, images.TargetNodes())
+		}
+	}
+	return a, nil
+}
+
+// loadImages loads images into the picker's cluster, or only into nodes, as a
+// background job
+func (a *App) loadImages(images, nodes []string) (tea.Model, tea.Cmd) {
+	if len(images) == 0 {
+		return a, func() tea.Msg {
+			return models.MessageMsg{
+				Text:    "Image name cannot be empty",
+				MsgType: "error",
+			}
+		}
+	}
+
+	clusterName := a.model.SelectedCluster
+	title := fmt.Sprintf("Loading image '%s'", images[0])
+	if len(images) > 1 {
+		title = fmt.Sprintf("Loading %d images", len(images))
+	}
+	return a.startOperation("load", clusterName, title+nodesSuffix(nodes), func(ctx context.Context, out io.Writer) tea.Cmd {
+		return commands.LoadDockerImage(ctx, images, clusterName, nodes, out)
+	})
+}
+
+// openClusterImages compares the images on a cluster's nodes with the local ones
+func (a *App) openClusterImages(cluster string, returnTo models.ViewMode) (tea.Model, tea.Cmd) {
+	a.model.NodeImages.Open(cluster, returnTo)
+	a.model.CurrentView = models.ClusterImagesView
+	return a, commands.GetClusterImages(cluster)
+}
+
+func (a *App) handleClusterImagesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	images := &a.model.NodeImages
+
+	switch {
+	case key.Matches(msg, models.Keys.Up):
+		images.MoveCursor(-1)
+	case key.Matches(msg, models.Keys.Down):
+		images.MoveCursor(1)
+	case key.Matches(msg, models.Keys.Refresh):
+		return a, commands.GetClusterImages(images.Cluster)
+	case key.Matches(msg, models.Keys.Enter):
+		// Reload the image under the cursor from the local store
+		if image := images.Current(); image != nil && image.LocalRef != "" {
+			a.model.SelectedCluster = images.Cluster
+			return a.loadImages([]string{image.LocalRef}, nil)
+		}
+	case key.Matches(msg, models.Keys.Load):
+		if refs := images.Outdated(); len(refs) > 0 {
+			a.model.SelectedCluster = images.Cluster
+			return a.loadImages(refs, nil)
+		}
+		return a, func() tea.Msg {
+			return models.MessageMsg{Text: "Every image is up to date", MsgType: "info"}
+		}
+	}
+	return a, nil
+}
+
+// loadTarget names the cluster images are loaded into in prompts
+func loadTarget(cluster string) string {
+	if cluster == "" {
+		return "the default cluster"
+	}
+	return "'" + cluster + "'"
+}
+
+// loadArchives loads image tarballs into the picker's cluster, or only into
+// nodes, as a background job
+func (a *App) loadArchives(archives, nodes []string) (tea.Model, tea.Cmd) {
+	if len(archives) == 0 {
+		return a, func() tea.Msg {
+			return models.MessageMsg{Text: "Archive path cannot be empty", MsgType: "error"}
+		}
+	}
+
+	clusterName := a.model.SelectedCluster
+	title := fmt.Sprintf("Loading archive '%s'", archives[0])
+	if len(archives) > 1 {
+		title = fmt.Sprintf("Loading %d archives", len(archives))
+	}
+	return a.startOperation("load", clusterName, title+nodesSuffix(nodes), func(ctx context.Context, out io.Writer) tea.Cmd {
+		return commands.LoadImageArchive(ctx, archives, clusterName, nodes, out)
+	})
+}
+
+// nodesSuffix names the nodes of a load in job titles
+func nodesSuffix(nodes []string) string {
+	if len(nodes) == 0 {
+		return ""
+	}
+	return " into " + strings.Join(nodes, ", ")
+}
+
+// openKubeconfig shows the kubeconfig actions of a cluster
+func (a *App) openKubeconfig(cluster string, returnTo models.ViewMode) (tea.Model, tea.Cmd) {
+	a.model.Kubeconfig.Open(cluster, returnTo)
+	a.model.CurrentView = models.KubeconfigView
+	return a, nil
+}
+
+func (a *App) handleKubeconfigKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	menu := &a.model.Kubeconfig
+
+	switch {
+	case key.Matches(msg, models.Keys.Up):
+		menu.MoveCursor(-1)
+	case key.Matches(msg, models.Keys.Down):
+		menu.MoveCursor(1)
+	case key.Matches(msg, models.Keys.Enter):
+		cluster := menu.Cluster
+		switch action := menu.Current().ID; action {
+		case models.KubeconfigUse:
+			return a, commands.UseKubeContext(cluster)
+		case models.KubeconfigMerge:
+			return a, commands.MergeKubeconfig(cluster)
+		case models.KubeconfigRemove:
+			return a, commands.RemoveKubeconfig(cluster)
+		case models.KubeconfigCopy, models.KubeconfigCopyInternal:
+			return a, commands.CopyKubeconfig(cluster, action == models.KubeconfigCopyInternal)
+		case models.KubeconfigExport, models.KubeconfigExportInternal:
+			a.model.CurrentView = models.KubeconfigExportView
+			a.model.InputPrompt = "Enter the file to export the kubeconfig of '" + cluster + "' to:"
+			a.model.InputAction = action
+			a.model.TextInput.Placeholder = "./" + cluster + ".kubeconfig"
+			if action == models.KubeconfigExportInternal {
+				a.model.TextInput.Placeholder = "./" + cluster + "-internal.kubeconfig"
+			}
+			a.model.TextInput.Focus()
+		}
+	}
+	return a, nil
+}
+
+// openPods shows the pods of a cluster and starts refreshing them
+func (a *App) openPods(cluster string, returnTo models.ViewMode) (tea.Model, tea.Cmd) {
+	a.model.Pods.Open(cluster, returnTo)
+	a.model.CurrentView = models.PodListView
+	return a, commands.GetPods(cluster, "", a.model.Pods.Seq)
+}
+
+func (a *App) handlePodListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	pods := &a.model.Pods
+
+	if pods.Filtering {
+		switch msg.String() {
+		case "ctrl+c":
+			return a.quit()
+		case "enter":
+			pods.Filtering = false
+			pods.Filter.Blur()
+		case "esc":
+			pods.Filtering = false
+			pods.Filter.Blur()
+			pods.Filter.SetValue("")
+		default:
+			var cmd tea.Cmd
+			pods.Filter, cmd = pods.Filter.Update(msg)
+			pods.MoveCursor(0)
+			return a, cmd
+		}
+		pods.MoveCursor(0)
+		return a, nil
+	}
+
+	switch {
+	case key.Matches(msg, models.Keys.Enter):
+		if pod := pods.Selected(); pod != nil {
+			source := cmd.LogSource{Cluster: pods.Cluster, Namespace: pod.Namespace, Pod: pod.Name}
+			if len(pod.Containers) > 0 {
+				source.Container = pod.Containers[0]
+			}
+			return a.openLogs(source, pod.Containers, models.PodListView)
+		}
+	case key.Matches(msg, models.Keys.Forward):
+		if pod := pods.Selected(); pod != nil {
+			return a.openForwardPrompt(pods.Cluster, pod.Namespace+"/pod/"+pod.Name+" ", models.PodListView)
+		}
+	case key.Matches(msg, models.Keys.Shell):
+		if pod := pods.Selected(); pod != nil {
+			container := ""
+			if len(pod.Containers) > 0 {
+				container = pod.Containers[0]
+			}
+			return a, commands.PodShell(pods.Cluster, pod.Namespace, pod.Name, container)
+		}
+	case key.Matches(msg, models.Keys.Up):
+		pods.MoveCursor(-1)
+	case key.Matches(msg, models.Keys.Down):
+		pods.MoveCursor(1)
+	case key.Matches(msg, models.Keys.Namespace):
+		pods.NextNamespace()
+	case key.Matches(msg, models.Keys.Sort):
+		pods.NextSort()
+	case key.Matches(msg, models.Keys.Filter):
+		pods.Filtering = true
+		return a, pods.Filter.Focus()
+	case key.Matches(msg, models.Keys.Refresh):
+		// A new sequence replaces the running refresh loop
+		pods.Seq++
+		return a, commands.GetPods(pods.Cluster, "", pods.Seq)
+	}
+
+	return a, nil
+}
+
+// openLogs shows a pod or node log and starts streaming it
+func (a *App) openLogs(source cmd.LogSource, targets []string, returnTo models.ViewMode) (tea.Model, tea.Cmd) {
+	a.model.Logs.Open(source, targets, returnTo)
+	a.model.CurrentView = models.LogView
+	return a, a.streamLogs()
+}
+
+// closeLogs stops the log stream and goes back to the view the log was opened from
+func (a *App) closeLogs() (tea.Model, tea.Cmd) {
+	a.model.Logs.Stop()
+	return a.returnTo(a.model.Logs.ReturnTo)
+}
+
+// returnTo goes back to a view, restarting the pods refresh loop that stops
+// whenever the pods view is left
+func (a *App) returnTo(view models.ViewMode) (tea.Model, tea.Cmd) {
+	a.model.CurrentView = view
+	if view == models.PodListView {
+		a.model.Pods.Seq++
+		return a, commands.GetPods(a.model.Pods.Cluster, "", a.model.Pods.Seq)
+	}
+	return a, nil
+}
+
+// openForwardPrompt asks for the target and ports of a new port forward
+func (a *App) openForwardPrompt(cluster, target string, returnTo models.ViewMode) (tea.Model, tea.Cmd) {
+	a.model.SelectedCluster = cluster
+	a.model.ForwardReturn = returnTo
+	a.model.CurrentView = models.PortForwardView
+	a.model.InputPrompt = "Forward a local port into '" + cluster + "' ([namespace/]svc/NAME or pod/NAME, then [LOCAL:]REMOTE):"
+	a.model.InputAction = "port-forward"
+	a.model.TextInput.Placeholder = "default/svc/web 8080:80"
+	a.model.TextInput.SetValue(target)
+	a.model.TextInput.CursorEnd()
+	a.model.TextInput.Focus()
+	return a, nil
+}
+
+// openPortForwards shows the port forwards and keeps their counters updating
+func (a *App) openPortForwards() (tea.Model, tea.Cmd) {
+	a.model.CurrentView = models.PortForwardListView
+	a.model.ForwardSeq++
+	return a, commands.ForwardsTick(a.model.ForwardSeq)
+}
+
+func (a *App) handlePortForwardListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	forwards := cmd.Forwards.List()
+	if len(forwards) == 0 {
+		return a, nil
+	}
+	a.model.ForwardCursor = min(a.model.ForwardCursor, len(forwards)-1)
+
+	switch {
+	case key.Matches(msg, models.Keys.Up):
+		if a.model.ForwardCursor > 0 {
+			a.model.ForwardCursor--
+		}
+	case key.Matches(msg, models.Keys.Down):
+		if a.model.ForwardCursor < len(forwards)-1 {
+			a.model.ForwardCursor++
+		}
+	case key.Matches(msg, models.Keys.Delete):
+		return a, commands.StopPortForward(forwards[a.model.ForwardCursor].ID)
+	}
+
+	return a, nil
+}
+
+// streamLogs starts a stream for the log viewer's current source and options
+func (a *App) streamLogs() tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.model.Logs.Cancel = cancel
+	stream := commands.NewLogStream(a.model.Logs.Seq)
+	return tea.Batch(
+		stream.Run(commands.StreamLogs(ctx, a.model.Logs.Source, a.model.Logs.Options, stream)),
+		stream.Next(),
+	)
+}
+
+// restartLogs reads the log again after its source or options changed
+func (a *App) restartLogs() (tea.Model, tea.Cmd) {
+	a.model.Logs.Restart()
+	return a, a.streamLogs()
+}
+
+func (a *App) handleLogKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	logs := &a.model.Logs
+
+	if logs.Prompt != models.LogPromptNone {
+		switch msg.String() {
+		case "ctrl+c":
+			return a.quit()
+		case "esc":
+			logs.ClosePrompt()
+		case "enter":
+			prompt, value := logs.Prompt, strings.TrimSpace(logs.Input.Value())
+			logs.ClosePrompt()
+			if prompt == models.LogPromptSave && value != "" {
+				return a, commands.SaveLogs(value, logs.Lines)
+			}
+			if prompt == models.LogPromptSearch {
+				logs.Search(value)
+			}
+		default:
+			var cmd tea.Cmd
+			logs.Input, cmd = logs.Input.Update(msg)
+			return a, cmd
+		}
+		return a, nil
+	}
+
+	switch {
+	case key.Matches(msg, models.Keys.Follow):
+		logs.ToggleFollow()
+		return a.restartLogs()
+	case key.Matches(msg, models.Keys.Tail):
+		logs.NextTail()
+		return a.restartLogs()
+	case key.Matches(msg, models.Keys.Since):
+		logs.NextSince()
+		return a.restartLogs()
+	case key.Matches(msg, models.Keys.Previous):
+		if logs.TogglePrevious() {
+			return a.restartLogs()
+		}
+	case key.Matches(msg, models.Keys.Tab):
+		if logs.NextTarget() {
+			return a.restartLogs()
+		}
+	case key.Matches(msg, models.Keys.Wrap):
+		logs.ToggleWrap()
+	case key.Matches(msg, models.Keys.Filter):
+		logs.OpenPrompt(models.LogPromptSearch)
+		return a, textinput.Blink
+	case key.Matches(msg, models.Keys.Save):
+		logs.OpenPrompt(models.LogPromptSave)
+		return a, textinput.Blink
+	case key.Matches(msg, models.Keys.NextMatch):
+		logs.NextMatch(1)
+	case key.Matches(msg, models.Keys.PrevMatch):
+		logs.NextMatch(-1)
+	default:
+		var cmd tea.Cmd
+		logs.Viewport, cmd = logs.Viewport.Update(msg)
+		return a, cmd
+	}
+
+	return a, nil
+}
+
+func (a *App) openTemplateList() (tea.Model, tea.Cmd) {
+	a.model.CurrentView = models.TemplateListView
+	return a, commands.GetTemplates()
+}
+
+func (a *App) openClusterForm() (tea.Model, tea.Cmd) {
+	a.model.ClusterForm.Reset()
+	a.model.EditingTemplate = ""
+	a.model.CurrentView = models.CreateClusterView
+	return a, textinput.Blink
+}
+
+// selectedTemplate returns the template highlighted in the template list
+func (a *App) selectedTemplate() *cmd.Template {
+	item, ok := a.model.TemplateList.SelectedItem().(models.Item)
+	if !ok || item.Action != "template" {
+		return nil
+	}
+	for i := range a.model.Templates {
+		if a.model.Templates[i].Name == item.Title() {
+			return &a.model.Templates[i]
+		}
+	}
+	return nil
+}
+
+func (a *App) useTemplate(template *cmd.Template) (tea.Model, tea.Cmd) {
+	if err := a.model.ClusterForm.SetConfig(template.Cluster); err != nil {
+		return a, templateFormError(template.Name, err)
+	}
+	a.model.EditingTemplate = ""
+	a.model.CurrentView = models.CreateClusterView
+	return a, textinput.Blink
+}
+
+func (a *App) editTemplate(template *cmd.Template) (tea.Model, tea.Cmd) {
+	if err := a.model.ClusterForm.SetConfig(template.Cluster); err != nil {
+		return a, templateFormError(template.Name, err)
+	}
+	a.model.EditingTemplate = template.Name
+	a.model.CurrentTemplate = template
+	a.model.CurrentView = models.CreateClusterView
+	return a, textinput.Blink
+}
+
+// templateFormError reports a template the create form cannot hold without
+// dropping some of its settings
+func templateFormError(name string, err error) tea.Cmd {
+	return func() tea.Msg {
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Template %s cannot be opened in the form without losing settings: %v", name, err),
+			MsgType: "error",
+		}
+	}
+}
+
+func (a *App) handleTemplateListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
-	var cmds []tea.Cmd
 
+	switch {
+	case key.Matches(msg, models.Keys.Enter):
+		if template := a.selectedTemplate(); template != nil {
+			return a.useTemplate(template)
+		}
+		return a.openClusterForm()
+	case key.Matches(msg, models.Keys.Preview):
+		if template := a.selectedTemplate(); template != nil {
+			a.model.CurrentTemplate = template
+			a.model.CurrentView = models.TemplatePreviewView
+			return a, nil
+		}
+	case key.Matches(msg, models.Keys.Edit):
+		if template := a.selectedTemplate(); template != nil {
+			return a.editTemplate(template)
+		}
+	case key.Matches(msg, models.Keys.Clone):
+		if template := a.selectedTemplate(); template != nil {
+			a.model.CurrentTemplate = template
+			a.model.CurrentView = models.TemplateNameView
+			a.model.InputPrompt = "Enter a name for the copy of '" + template.Name + "':"
+			a.model.InputAction = "clone-template"
+			a.model.TextInput.Placeholder = template.Name + "-copy"
+			a.model.TextInput.Focus()
+			return a, nil
+		}
+	case key.Matches(msg, models.Keys.Delete):
+		if template := a.selectedTemplate(); template != nil {
+			a.model.TemplateToDelete = template.Name
+			a.model.DeleteConfirmChoice = 0
+			a.model.CurrentView = models.DeleteConfirmView
+			return a, nil
+		}
+	case key.Matches(msg, models.Keys.Refresh):
+		return a, commands.GetTemplates()
+	}
+
+	a.model.TemplateList, cmd = a.model.TemplateList.Update(msg)
+	return a, cmd
+}
+
+func (a *App) handleTemplatePreviewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if a.model.CurrentTemplate == nil {
+		return a, nil
+	}
+
+	switch {
+	case key.Matches(msg, models.Keys.Enter):
+		return a.useTemplate(a.model.CurrentTemplate)
+	case key.Matches(msg, models.Keys.Edit):
+		return a.editTemplate(a.model.CurrentTemplate)
+	}
+
+	return a, nil
+}
+
+func (a *App) handleTemplateDeleteConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	name := a.model.TemplateToDelete
+	confirmed := false
+
+	switch {
+	case key.Matches(msg, models.Keys.Left), key.Matches(msg, models.Keys.Right), key.Matches(msg, models.Keys.Tab):
+		a.model.DeleteConfirmChoice = 1 - a.model.DeleteConfirmChoice
+		return a, nil
+	case key.Matches(msg, models.Keys.Enter):
+		confirmed = a.model.DeleteConfirmChoice == 0
+	case key.Matches(msg, models.Keys.Yes):
+		confirmed = true
+	case key.Matches(msg, models.Keys.No):
+		confirmed = false
+	default:
+		return a, nil
+	}
+
+	a.model.TemplateToDelete = ""
+	a.model.DeleteConfirmChoice = 0
+	a.model.CurrentView = models.TemplateListView
+	if confirmed {
+		return a, commands.DeleteTemplate(name)
+	}
+	return a, nil
+}
+
+func (a *App) handleQuitConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	confirmed := false
+
+	switch {
+	case key.Matches(msg, models.Keys.Left), key.Matches(msg, models.Keys.Right), key.Matches(msg, models.Keys.Tab):
+		a.model.DeleteConfirmChoice = 1 - a.model.DeleteConfirmChoice
+		return a, nil
+	case key.Matches(msg, models.Keys.Enter):
+		confirmed = a.model.DeleteConfirmChoice == 0
+	case key.Matches(msg, models.Keys.Yes):
+		confirmed = true
+	case key.Matches(msg, models.Keys.No):
+		confirmed = false
+	default:
+		return a, nil
+	}
+
+	a.model.DeleteConfirmChoice = 0
+	if confirmed {
+		// main cancels the running jobs once the program exited
+		a.model.Quitting = true
+		return a, tea.Quit
+	}
+	a.model.CurrentView = a.model.QuitReturn
+	return a, nil
+}
+
+func (a *App) handleWorkerRemoveConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	cluster, node := a.model.NodesCluster, a.model.WorkerToRemove
+	confirmed := false
+
+	switch {
+	case key.Matches(msg, models.Keys.Left), key.Matches(msg, models.Keys.Right), key.Matches(msg, models.Keys.Tab):
+		a.model.DeleteConfirmChoice = 1 - a.model.DeleteConfirmChoice
+		return a, nil
+	case key.Matches(msg, models.Keys.Enter):
+		confirmed = a.model.DeleteConfirmChoice == 0
+	case key.Matches(msg, models.Keys.Yes):
+		confirmed = true
+	case key.Matches(msg, models.Keys.No):
+		confirmed = false
+	default:
+		return a, nil
+	}
+
+	a.model.WorkerToRemove = ""
+	a.model.DeleteConfirmChoice = 0
+	a.model.CurrentView = models.NodeListView
+	if confirmed {
+		return a.startOperation("worker", cluster, fmt.Sprintf("Removing worker '%s' from cluster '%s'", node, cluster), func(ctx context.Context, out io.Writer) tea.Cmd {
+			return commands.RemoveWorker(ctx, cluster, node, out)
+		})
+	}
+	return a, nil
+}
+
+func (a *App) handleClusterFormKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "tab", "down":
+		a.model.ClusterForm.Next()
+		return a, nil
+	case "shift+tab", "up":
+		a.model.ClusterForm.Prev()
+		return a, nil
+	case "ctrl+s", "enter":
+		config, err := a.model.ClusterForm.Config()
+		if err != nil {
+			return a, func() tea.Msg {
+				return models.MessageMsg{
+					Text:    err.Error(),
+					MsgType: "error",
+				}
+			}
+		}
+
+		// Templates only keep a cluster name when one was typed in
+		template := cmd.Template{Cluster: config}
+		template.Cluster.Name = a.model.ClusterForm.Value(models.FieldName)
+
+		if a.model.EditingTemplate != "" {
+			template.Name = a.model.EditingTemplate
+			if a.model.CurrentTemplate != nil {
+				template.Description = a.model.CurrentTemplate.Description
+			}
+			a.model.ClusterForm.Reset()
+			a.model.EditingTemplate = ""
+			a.model.CurrentView = models.TemplateListView
+			return a, commands.SaveTemplate(template)
+		}
+
+		if msg.String() == "ctrl+s" {
+			a.model.CurrentTemplate = &template
+			a.model.CurrentView = models.TemplateNameView
+			a.model.InputPrompt = "Enter a name for the new template:"
+			a.model.InputAction = "save-template"
+			a.model.TextInput.Placeholder = "my-template"
+			a.model.TextInput.Focus()
+			return a, nil
+		}
+
+		a.model.ClusterForm.Reset()
+		return a.startOperation("create", config.Name, fmt.Sprintf("Creating cluster '%s'", config.Name), func(ctx context.Context, out io.Writer) tea.Cmd {
+			return commands.CreateKindCluster(ctx, config, out)
+		})
+	}
+
+	var cmd tea.Cmd
+	a.model.ClusterForm, cmd = a.model.ClusterForm.Update(msg)
+	return a, cmd
+}
+
+func (a *App) handleInputKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
 		inputValue := strings.TrimSpace(a.model.TextInput.Value())
@@ -195,34 +1136,84 @@ func (a *App) handleInputKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		a.model.CurrentView = models.MainMenuView
 
 		switch a.model.InputAction {
-		case "create":
-			clusterName := inputValue
-			if clusterName == "" {
-				clusterName = "kind"
-			}
-			return a, commands.CreateKindCluster(clusterName)
-
 		case "load-image":
-			if inputValue == "" {
+			return a.loadImages(strings.Fields(inputValue), a.model.Images.TargetNodes())
+
+		case "load-archive":
+			return a.loadArchives(strings.Fields(inputValue), a.model.Images.TargetNodes())
+
+		case "build-load":
+			opts, err := cmd.ParseBuildSpec(inputValue)
+			if err != nil {
+				// Keep the prompt open so the input can be fixed
+				a.model.CurrentView = models.LoadImageView
+				a.model.TextInput.SetValue(inputValue)
 				return a, func() tea.Msg {
-					return models.MessageMsg{
-						Text:    "Image name cannot be empty",
-						MsgType: "error",
-					}
+					return models.MessageMsg{Text: err.Error(), MsgType: "error"}
 				}
 			}
-			return a, commands.LoadDockerImage(inputValue, a.model.SelectedCluster)
+			clusterName, nodes := a.model.SelectedCluster, a.model.Images.TargetNodes()
+			title := fmt.Sprintf("Building and loading image '%s'", opts.Tag) + nodesSuffix(nodes)
+			return a.startOperation("build", clusterName, title, func(ctx context.Context, out io.Writer) tea.Cmd {
+				return commands.BuildAndLoadImage(ctx, opts, clusterName, nodes, out)
+			})
 
 		case "build":
 			sourcePath := inputValue
-			return a, commands.BuildNodeImage(sourcePath)
+			return a.startOperation("build", "", "Building node image", func(ctx context.Context, out io.Writer) tea.Cmd {
+				return commands.BuildNodeImage(ctx, sourcePath, out)
+			})
 
 		case "export-logs":
 			outputPath := inputValue
-			return a, commands.ExportKindLogs(a.model.SelectedCluster, outputPath)
+			clusterName := a.model.SelectedCluster
+			return a.startOperation("logs", clusterName, "Exporting logs", func(ctx context.Context, out io.Writer) tea.Cmd {
+				return commands.ExportKindLogs(ctx, clusterName, outputPath, out)
+			})
+
+		case "port-forward":
+			spec, err := cmd.ParseForwardTarget(a.model.SelectedCluster, inputValue)
+			if err != nil {
+				// Keep the prompt open so the input can be fixed
+				a.model.CurrentView = models.PortForwardView
+				a.model.TextInput.SetValue(inputValue)
+				return a, func() tea.Msg {
+					return models.MessageMsg{Text: err.Error(), MsgType: "error"}
+				}
+			}
+			_, tick := a.openPortForwards()
+			return a, tea.Batch(commands.StartPortForward(spec), tick)
+
+		case models.KubeconfigExport, models.KubeconfigExportInternal:
+			a.model.CurrentView = models.KubeconfigView
+			path := inputValue
+			if path == "" {
+				path = a.model.TextInput.Placeholder
+			}
+			return a, commands.ExportKubeconfig(a.model.Kubeconfig.Cluster, path, a.model.InputAction == models.KubeconfigExportInternal)
+
+		case "clone-template", "save-template":
+			a.model.CurrentView = models.TemplateListView
+			template := a.model.CurrentTemplate
+			a.model.CurrentTemplate = nil
+			if template == nil {
+				return a, nil
+			}
+			name := inputValue
+			if name == "" {
+				name = a.model.TextInput.Placeholder
+			}
+			if a.model.InputAction == "clone-template" {
+				return a, commands.CloneTemplate(template.Name, name)
+			}
+			template.Name = name
+			a.model.ClusterForm.Reset()
+			return a, commands.SaveTemplate(*template)
 		}
 	}
 
+	var cmd tea.Cmd
+	var cmds []tea.Cmd
 	a.model.TextInput, cmd = a.model.TextInput.Update(msg)
 	cmds = append(cmds, cmd)
 
@@ -1,34 +1,73 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"ki/internal/cmd"
 	"ki/internal/ui/models"
 )
 
-// GetKindClusters fetches all KIND clusters
-func GetKindClusters() tea.Cmd {
+// RefreshClusters lists the KIND clusters for the refresh seq. The list comes
+// back at once in its last known state, clusters never seen before with an
+// empty status, and each cluster follows in a ClusterUpdateMsg as soon as it
+// is enriched. Clusters enriched less than maxAge ago are not queried again.
+func RefreshClusters(seq int, maxAge time.Duration) tea.Cmd {
 	return func() tea.Msg {
-		clusters, err := cmd.Commands.GetClusters()
+		ctx := context.Background()
+		names, err := cmd.Commands.GetClusterNames(ctx)
 		if err != nil {
 			return models.MessageMsg{
 				Text:    err.Error(),
 				MsgType: "error",
 			}
 		}
-		return models.ClustersMsg(clusters)
+
+		clusters := make([]cmd.Cluster, len(names))
+		for i, name := range names {
+			if cluster, ok := cmd.Commands.CachedCluster(name); ok {
+				clusters[i] = cluster
+			} else {
+				clusters[i] = cmd.Cluster{Name: name}
+			}
+		}
+
+		// Buffered so the workers never wait on a refresh the UI dropped
+		updates := make(chan cmd.Cluster, len(names))
+		go func() {
+			cmd.Commands.EnrichClusters(ctx, names, maxAge, func(c cmd.Cluster) {
+				updates <- c
+			})
+			close(updates)
+		}()
+		return models.ClustersMsg{Seq: seq, Clusters: clusters, Next: nextClusterUpdate(seq, updates)}
+	}
+}
+
+// nextClusterUpdate waits for the next enriched cluster of the refresh seq.
+// It returns nil once every cluster was delivered.
+func nextClusterUpdate(seq int, updates <-chan cmd.Cluster) tea.Cmd {
+	return func() tea.Msg {
+		cluster, ok := <-updates
+		if !ok {
+			return nil
+		}
+		return models.ClusterUpdateMsg{Seq: seq, Cluster: cluster, Next: nextClusterUpdate(seq, updates)}
 	}
 }
 
 // GetClusterNodes fetches nodes for a specific cluster
 func GetClusterNodes(clusterName string) tea.Cmd {
 	return func() tea.Msg {
-		nodes, err := cmd.Commands.GetClusterNodes(clusterName)
+		nodes, err := cmd.Commands.GetClusterNodes(context.Background(), clusterName)
 		if err != nil {
 			return models.MessageMsg{
 				Text:    err.Error(),
@@ -39,10 +78,19 @@ func GetClusterNodes(clusterName string) tea.Cmd {
 	}
 }
 
+// GetNodeDetail fetches the detail of a node. Errors are carried in the
+// message so the detail view can keep showing what it had.
+func GetNodeDetail(clusterName, nodeName string) tea.Cmd {
+	return func() tea.Msg {
+		detail, err := cmd.Commands.GetNodeDetail(context.Background(), clusterName, nodeName)
+		return models.NodeDetailMsg{Cluster: clusterName, Node: nodeName, Detail: detail, Err: err}
+	}
+}
+
 // GetClusterDetail fetches detailed information about a cluster
 func GetClusterDetail(clusterName string) tea.Cmd {
 	return func() tea.Msg {
-		cluster, err := cmd.Commands.GetClusterDetail(clusterName)
+		cluster, err := cmd.Commands.GetClusterDetail(context.Background(), clusterName)
 		if err != nil {
 			return models.MessageMsg{
 				Text:    err.Error(),
@@ -53,26 +101,51 @@ func GetClusterDetail(clusterName string) tea.Cmd {
 	}
 }
 
-// CreateKindCluster creates a new KIND cluster
-func CreateKindCluster(name string) tea.Cmd {
+// PodsRefreshInterval is how often the pods view reloads while it is open
+const PodsRefreshInterval = 5 * time.Second
+
+// GetPods fetches the pods of a cluster for the refresh loop seq
+func GetPods(clusterName, namespace string, seq int) tea.Cmd {
+	return func() tea.Msg {
+		pods, err := cmd.Commands.GetPods(context.Background(), clusterName, namespace)
+		return models.PodsMsg{Cluster: clusterName, Seq: seq, Pods: pods, Err: err}
+	}
+}
+
+// PodsTick schedules the next reload of the refresh loop seq
+func PodsTick(seq int) tea.Cmd {
+	return tea.Tick(PodsRefreshInterval, func(time.Time) tea.Msg {
+		return models.PodsTickMsg{Seq: seq}
+	})
+}
+
+// RefreshTick schedules the next reload of the auto-refresh loop seq
+func RefreshTick(seq int, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return models.RefreshTickMsg{Seq: seq}
+	})
+}
+
+// CreateKindCluster creates a new KIND cluster from the given config
+func CreateKindCluster(ctx context.Context, config cmd.ClusterConfig, out io.Writer) tea.Cmd {
 	return func() tea.Msg {
-		if err := cmd.Commands.CreateCluster(name); err != nil {
+		if err := cmd.Commands.CreateCluster(ctx, config, out); err != nil {
 			return models.MessageMsg{
 				Text:    err.Error(),
 				MsgType: "error",
 			}
 		}
 		return models.MessageMsg{
-			Text:    fmt.Sprintf("Cluster '%s' created successfully!", name),
+			Text:    fmt.Sprintf("Cluster '%s' created successfully!", config.Name),
 			MsgType: "success",
 		}
 	}
 }
 
 // DeleteKindCluster deletes a KIND cluster
-func DeleteKindCluster(name string) tea.Cmd {
+func DeleteKindCluster(ctx context.Context, name string, out io.Writer) tea.Cmd {
 	return func() tea.Msg {
-		if err := cmd.Commands.DeleteCluster(name); err != nil {
+		if err := cmd.Commands.DeleteCluster(ctx, name, out); err != nil {
 			return models.MessageMsg{
 				Text:    err.Error(),
 				MsgType: "error",
@@ -85,26 +158,212 @@ func DeleteKindCluster(name string) tea.Cmd {
 	}
 }
 
-// LoadDockerImage loads a Docker image into a KIND cluster
-func LoadDockerImage(imageName, clusterName string) tea.Cmd {
+// StopKindCluster stops the node containers of a KIND cluster
+func StopKindCluster(ctx context.Context, name string, out io.Writer) tea.Cmd {
 	return func() tea.Msg {
-		if err := cmd.Commands.LoadDockerImage(imageName, clusterName); err != nil {
+		if err := cmd.Commands.StopCluster(ctx, name, out); err != nil {
 			return models.MessageMsg{
 				Text:    err.Error(),
 				MsgType: "error",
 			}
 		}
 		return models.MessageMsg{
-			Text:    fmt.Sprintf("Image '%s' loaded successfully!", imageName),
+			Text:    fmt.Sprintf("Cluster '%s' stopped successfully!", name),
+			MsgType: "success",
+		}
+	}
+}
+
+// StartKindCluster starts the node containers of a stopped KIND cluster
+func StartKindCluster(ctx context.Context, name string, out io.Writer) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Commands.StartCluster(ctx, name, out); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Cluster '%s' started successfully!", name),
+			MsgType: "success",
+		}
+	}
+}
+
+// RestartKindCluster restarts the node containers of a KIND cluster
+func RestartKindCluster(ctx context.Context, name string, out io.Writer) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Commands.RestartCluster(ctx, name, out); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Cluster '%s' restarted successfully!", name),
+			MsgType: "success",
+		}
+	}
+}
+
+// AddWorker adds a worker node to a KIND cluster
+func AddWorker(ctx context.Context, clusterName string, out io.Writer) tea.Cmd {
+	return func() tea.Msg {
+		node, err := cmd.Commands.AddWorker(ctx, clusterName, out)
+		if err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Worker '%s' added to cluster '%s'", node, clusterName),
+			MsgType: "success",
+		}
+	}
+}
+
+// RemoveWorker drains and removes a worker node of a KIND cluster
+func RemoveWorker(ctx context.Context, clusterName, node string, out io.Writer) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Commands.RemoveWorker(ctx, clusterName, node, out); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Worker '%s' removed from cluster '%s'", node, clusterName),
+			MsgType: "success",
+		}
+	}
+}
+
+// GetImages fetches the images of the local container runtime
+func GetImages() tea.Cmd {
+	return func() tea.Msg {
+		images, err := cmd.Commands.GetImages(context.Background())
+		return models.ImagesMsg{Images: images, Err: err}
+	}
+}
+
+// GetClusterImages compares the images on a cluster's nodes with the local ones
+func GetClusterImages(clusterName string) tea.Cmd {
+	return func() tea.Msg {
+		images, err := cmd.Commands.GetClusterImages(context.Background(), clusterName)
+		return models.ClusterImagesMsg{Cluster: clusterName, Images: images, Err: err}
+	}
+}
+
+// LoadDockerImage loads one or more Docker images into a KIND cluster, or the
+// given nodes of it
+func LoadDockerImage(ctx context.Context, images []string, clusterName string, nodes []string, out io.Writer) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Commands.LoadDockerImage(ctx, images, clusterName, nodes, out); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		text := fmt.Sprintf("%d images loaded successfully!", len(images))
+		if len(images) == 1 {
+			text = fmt.Sprintf("Image '%s' loaded successfully!", images[0])
+		}
+		return models.MessageMsg{
+			Text:    text,
+			MsgType: "success",
+		}
+	}
+}
+
+// LoadImageArchive loads image tarballs into a KIND cluster
+func LoadImageArchive(ctx context.Context, archives []string, clusterName string, nodes []string, out io.Writer) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Commands.LoadImageArchive(ctx, archives, clusterName, nodes, out); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		text := fmt.Sprintf("%d archives loaded successfully!", len(archives))
+		if len(archives) == 1 {
+			text = fmt.Sprintf("Archive '%s' loaded successfully!", archives[0])
+		}
+		return models.MessageMsg{
+			Text:    text,
+			MsgType: "success",
+		}
+	}
+}
+
+// BuildAndLoadImage builds an image from a Dockerfile and loads it into a KIND cluster
+func BuildAndLoadImage(ctx context.Context, opts cmd.BuildOptions, clusterName string, nodes []string, out io.Writer) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Commands.BuildAndLoadImage(ctx, opts, clusterName, nodes, out); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Image '%s' built and loaded successfully!", opts.Tag),
+			MsgType: "success",
+		}
+	}
+}
+
+// CreateRegistry creates or starts the shared local registry
+func CreateRegistry(ctx context.Context, out io.Writer) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Commands.CreateRegistry(ctx, out); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Registry running at %s", cmd.RegistryHost),
+			MsgType: "success",
+		}
+	}
+}
+
+// AttachRegistry makes the shared local registry available to a cluster
+func AttachRegistry(ctx context.Context, clusterName string, out io.Writer) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Commands.AttachRegistry(ctx, clusterName, out); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Registry %s attached to cluster '%s'", cmd.RegistryHost, clusterName),
+			MsgType: "success",
+		}
+	}
+}
+
+// DetachRegistry removes the shared local registry from a cluster
+func DetachRegistry(ctx context.Context, clusterName string, out io.Writer) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Commands.DetachRegistry(ctx, clusterName, out); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Registry detached from cluster '%s'", clusterName),
 			MsgType: "success",
 		}
 	}
 }
 
 // BuildNodeImage builds a KIND node image from source
-func BuildNodeImage(sourcePath string) tea.Cmd {
+func BuildNodeImage(ctx context.Context, sourcePath string, out io.Writer) tea.Cmd {
 	return func() tea.Msg {
-		if err := cmd.Commands.BuildNodeImage(sourcePath); err != nil {
+		if err := cmd.Commands.BuildNodeImage(ctx, sourcePath, out); err != nil {
 			return models.MessageMsg{
 				Text:    err.Error(),
 				MsgType: "error",
@@ -118,7 +377,7 @@ func BuildNodeImage(sourcePath string) tea.Cmd {
 }
 
 // ExportKindLogs exports cluster logs
-func ExportKindLogs(clusterName, outputPath string) tea.Cmd {
+func ExportKindLogs(ctx context.Context, clusterName, outputPath string, out io.Writer) tea.Cmd {
 	return func() tea.Msg {
 		// Expand ~ to home directory
 		if outputPath != "" && strings.HasPrefix(outputPath, "~/") {
@@ -126,7 +385,7 @@ func ExportKindLogs(clusterName, outputPath string) tea.Cmd {
 			outputPath = filepath.Join(home, outputPath[2:])
 		}
 
-		if err := cmd.Commands.ExportLogs(clusterName, outputPath); err != nil {
+		if err := cmd.Commands.ExportLogs(ctx, clusterName, outputPath, out); err != nil {
 			return models.MessageMsg{
 				Text:    err.Error(),
 				MsgType: "error",
@@ -143,4 +402,288 @@ func ExportKindLogs(clusterName, outputPath string) tea.Cmd {
 			MsgType: "success",
 		}
 	}
-}
\ No newline at end of file
+}
+
+// StreamLogs reads a pod or node log into out. It only reports failures; a log
+// that ends or is stopped by cancelling ctx produces no message.
+func StreamLogs(ctx context.Context, source cmd.LogSource, opts cmd.LogOptions, out io.Writer) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Commands.StreamLogs(ctx, source, opts, out); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return nil
+	}
+}
+
+// ForwardsRefreshInterval is how often the port forwards view updates its counters
+const ForwardsRefreshInterval = time.Second
+
+// StartPortForward starts forwarding a local port in the background
+func StartPortForward(spec cmd.PortForwardSpec) tea.Cmd {
+	return func() tea.Msg {
+		info, err := cmd.Forwards.Start(spec)
+		if err != nil {
+			return models.MessageMsg{
+				Text:    fmt.Sprintf("Failed to forward: %v", err),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Forwarding %s", info.Spec),
+			MsgType: "success",
+		}
+	}
+}
+
+// StopPortForward stops a port forward and frees its local port
+func StopPortForward(id int) tea.Cmd {
+	return func() tea.Msg {
+		info, err := cmd.Forwards.Stop(id)
+		if err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Stopped forwarding %s", info.Spec),
+			MsgType: "success",
+		}
+	}
+}
+
+// ForwardsTick schedules the next update of the port forwards view loop seq
+func ForwardsTick(seq int) tea.Cmd {
+	return tea.Tick(ForwardsRefreshInterval, func(time.Time) tea.Msg {
+		return models.ForwardsTickMsg{Seq: seq}
+	})
+}
+
+// Shell suspends the UI and runs an interactive shell; the UI comes back
+// unchanged when the shell exits
+func Shell(c *exec.Cmd, target string) tea.Cmd {
+	return tea.ExecProcess(c, shellDone(target))
+}
+
+// PodShell opens an interactive shell in a pod container once the cluster's
+// kubeconfig is known
+func PodShell(cluster, namespace, pod, container string) tea.Cmd {
+	return func() tea.Msg {
+		target := "pod " + namespace + "/" + pod
+		c, err := cmd.PodShellCommand(context.Background(), cluster, namespace, pod, container)
+		if err != nil {
+			return models.MessageMsg{
+				Text:    fmt.Sprintf("Failed to open a shell in %s: %v", target, err),
+				MsgType: "error",
+			}
+		}
+		return Shell(c, target)()
+	}
+}
+
+// shellDone reports how the shell in target ended. A non-zero exit usually is
+// just the status of the last command typed, so it is not shown as an error.
+func shellDone(target string) tea.ExecCallback {
+	return func(err error) tea.Msg {
+		var exitErr *exec.ExitError
+		switch {
+		case err == nil:
+			return nil
+		case errors.As(err, &exitErr):
+			return models.MessageMsg{
+				Text:    fmt.Sprintf("Shell in %s exited with status %d", target, exitErr.ExitCode()),
+				MsgType: "info",
+			}
+		default:
+			return models.MessageMsg{
+				Text:    fmt.Sprintf("Failed to open a shell in %s: %v", target, err),
+				MsgType: "error",
+			}
+		}
+	}
+}
+
+// SaveLogs writes the lines of a log to path
+func SaveLogs(path string, lines []string) tea.Cmd {
+	return func() tea.Msg {
+		if strings.HasPrefix(path, "~/") {
+			home, _ := os.UserHomeDir()
+			path = filepath.Join(home, path[2:])
+		}
+
+		data := strings.Join(lines, "\n") + "\n"
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			return models.MessageMsg{
+				Text:    fmt.Sprintf("Failed to save logs: %v", err),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Saved %d log lines to %s", len(lines), path),
+			MsgType: "success",
+		}
+	}
+}
+
+// GetTemplates fetches all cluster templates
+func GetTemplates() tea.Cmd {
+	return func() tea.Msg {
+		templates, err := cmd.Templates.List()
+		var broken *cmd.BrokenTemplatesError
+		if errors.As(err, &broken) {
+			// Show the templates that loaded and say which did not
+			return tea.Batch(
+				func() tea.Msg { return models.TemplatesMsg(templates) },
+				func() tea.Msg { return models.MessageMsg{Text: err.Error(), MsgType: "error"} },
+			)()
+		}
+		if err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.TemplatesMsg(templates)
+	}
+}
+
+// SaveTemplate writes a cluster template to disk
+func SaveTemplate(template cmd.Template) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Templates.Save(template); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Template '%s' saved successfully!", template.Name),
+			MsgType: "success",
+		}
+	}
+}
+
+// CloneTemplate copies a cluster template under a new name
+func CloneTemplate(name, newName string) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := cmd.Templates.Clone(name, newName); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Template '%s' cloned to '%s' successfully!", name, newName),
+			MsgType: "success",
+		}
+	}
+}
+
+// DeleteTemplate removes a cluster template from disk
+func DeleteTemplate(name string) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Templates.Delete(name); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Template '%s' deleted successfully!", name),
+			MsgType: "success",
+		}
+	}
+}
+
+// ExportKubeconfig writes the kubeconfig of a cluster to path
+func ExportKubeconfig(clusterName, path string, internal bool) tea.Cmd {
+	return func() tea.Msg {
+		if strings.HasPrefix(path, "~/") {
+			home, _ := os.UserHomeDir()
+			path = filepath.Join(home, path[2:])
+		}
+
+		if err := cmd.Commands.ExportKubeconfig(context.Background(), clusterName, path, internal); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Kubeconfig of '%s' exported to %s", clusterName, path),
+			MsgType: "success",
+		}
+	}
+}
+
+// MergeKubeconfig adds the kubeconfig of a cluster to the default kubeconfig
+func MergeKubeconfig(clusterName string) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Commands.MergeKubeconfig(context.Background(), clusterName); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Kubeconfig of '%s' merged into %s", clusterName, cmd.KubeconfigPath()),
+			MsgType: "success",
+		}
+	}
+}
+
+// RemoveKubeconfig removes the kubeconfig of a cluster from the default kubeconfig
+func RemoveKubeconfig(clusterName string) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Commands.RemoveKubeconfig(context.Background(), clusterName); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Kubeconfig of '%s' removed from %s", clusterName, cmd.KubeconfigPath()),
+			MsgType: "success",
+		}
+	}
+}
+
+// UseKubeContext makes the context of a cluster the current one
+func UseKubeContext(clusterName string) tea.Cmd {
+	return func() tea.Msg {
+		if err := cmd.Commands.UseKubeContext(context.Background(), clusterName); err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("kubectl now uses '%s' by default", clusterName),
+			MsgType: "success",
+		}
+	}
+}
+
+// CopyKubeconfig copies the kubeconfig of a cluster to the clipboard
+func CopyKubeconfig(clusterName string, internal bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		kubeconfig, err := cmd.Commands.GetKubeconfig(ctx, clusterName, internal)
+		if err == nil {
+			err = cmd.Commands.CopyToClipboard(ctx, kubeconfig)
+		}
+		if err != nil {
+			return models.MessageMsg{
+				Text:    err.Error(),
+				MsgType: "error",
+			}
+		}
+		return models.MessageMsg{
+			Text:    fmt.Sprintf("Kubeconfig of '%s' copied to the clipboard", clusterName),
+			MsgType: "success",
+		}
+	}
+}
@@ -1,12 +1,16 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"ki/internal/cmd"
@@ -16,132 +20,331 @@ import (
 // MockCommands implements cmd.CommandInterface for testing
 type MockCommands struct {
 	GetClustersFunc      func() ([]cmd.Cluster, error)
+	GetClusterNamesFunc  func() ([]string, error)
+	CachedClusterFunc    func(string) (cmd.Cluster, bool)
+	EnrichClustersFunc   func([]string, time.Duration, func(cmd.Cluster))
 	GetClusterNodesFunc  func(string) ([]cmd.Node, error)
 	GetClusterDetailFunc func(string) (cmd.Cluster, error)
-	CreateClusterFunc    func(string) error
-	DeleteClusterFunc    func(string) error
-	LoadDockerImageFunc  func(string, string) error
-	BuildNodeImageFunc   func(string) error
-	ExportLogsFunc       func(string, string) error
+	GetPodsFunc          func(string, string) ([]cmd.Pod, error)
+	GetNodeDetailFunc    func(string, string) (*cmd.NodeDetail, error)
+	CreateClusterFunc    func(cmd.ClusterConfig, io.Writer) error
+	DeleteClusterFunc    func(string, io.Writer) error
+	StopClusterFunc      func(string, io.Writer) error
+	StartClusterFunc     func(string, io.Writer) error
+	RestartClusterFunc   func(string, io.Writer) error
+	AddWorkerFunc        func(string, io.Writer) (string, error)
+	RemoveWorkerFunc     func(string, string, io.Writer) error
+	GetImagesFunc        func() ([]cmd.Image, error)
+	GetClusterImagesFunc func(string) ([]cmd.ClusterImage, error)
+	LoadDockerImageFunc  func([]string, string, []string, io.Writer) error
+	LoadArchiveFunc      func([]string, string, []string, io.Writer) error
+	BuildAndLoadFunc     func(cmd.BuildOptions, string, []string, io.Writer) error
+	BuildNodeImageFunc   func(string, io.Writer) error
+	ExportLogsFunc       func(string, string, io.Writer) error
+	StreamLogsFunc       func(cmd.LogSource, cmd.LogOptions, io.Writer) error
+	CreateRegistryFunc   func(io.Writer) error
+	AttachRegistryFunc   func(string, io.Writer) error
+	DetachRegistryFunc   func(string, io.Writer) error
+	GetKubeconfigFunc    func(string, bool) (string, error)
+	ExportKubeconfigFunc func(string, string, bool) error
+	MergeKubeconfigFunc  func(string) error
+	RemoveKubeconfigFunc func(string) error
+	UseKubeContextFunc   func(string) error
+	CopyToClipboardFunc  func(string) error
 }
 
-func (m *MockCommands) GetClusters() ([]cmd.Cluster, error) {
+func (m *MockCommands) GetClusters(ctx context.Context) ([]cmd.Cluster, error) {
 	if m.GetClustersFunc != nil {
 		return m.GetClustersFunc()
 	}
 	return []cmd.Cluster{}, nil
 }
 
-func (m *MockCommands) GetClusterNodes(name string) ([]cmd.Node, error) {
+func (m *MockCommands) GetClusterNames(ctx context.Context) ([]string, error) {
+	if m.GetClusterNamesFunc != nil {
+		return m.GetClusterNamesFunc()
+	}
+	return []string{}, nil
+}
+
+func (m *MockCommands) CachedCluster(name string) (cmd.Cluster, bool) {
+	if m.CachedClusterFunc != nil {
+		return m.CachedClusterFunc(name)
+	}
+	return cmd.Cluster{}, false
+}
+
+func (m *MockCommands) EnrichClusters(ctx context.Context, names []string, maxAge time.Duration, found func(cmd.Cluster)) {
+	if m.EnrichClustersFunc != nil {
+		m.EnrichClustersFunc(names, maxAge, found)
+		return
+	}
+	for _, name := range names {
+		found(cmd.Cluster{Name: name})
+	}
+}
+
+func (m *MockCommands) GetClusterNodes(ctx context.Context, name string) ([]cmd.Node, error) {
 	if m.GetClusterNodesFunc != nil {
 		return m.GetClusterNodesFunc(name)
 	}
 	return []cmd.Node{}, nil
 }
 
-func (m *MockCommands) GetClusterDetail(name string) (cmd.Cluster, error) {
+func (m *MockCommands) GetClusterDetail(ctx context.Context, name string) (cmd.Cluster, error) {
 	if m.GetClusterDetailFunc != nil {
 		return m.GetClusterDetailFunc(name)
 	}
 	return cmd.Cluster{}, nil
 }
 
-func (m *MockCommands) CreateCluster(name string) error {
+func (m *MockCommands) GetPods(ctx context.Context, cluster, namespace string) ([]cmd.Pod, error) {
+	if m.GetPodsFunc != nil {
+		return m.GetPodsFunc(cluster, namespace)
+	}
+	return []cmd.Pod{}, nil
+}
+
+func (m *MockCommands) GetNodeDetail(ctx context.Context, cluster, node string) (*cmd.NodeDetail, error) {
+	if m.GetNodeDetailFunc != nil {
+		return m.GetNodeDetailFunc(cluster, node)
+	}
+	return &cmd.NodeDetail{Node: cmd.Node{Name: node}}, nil
+}
+
+func (m *MockCommands) CreateCluster(ctx context.Context, config cmd.ClusterConfig, out io.Writer) error {
 	if m.CreateClusterFunc != nil {
-		return m.CreateClusterFunc(name)
+		return m.CreateClusterFunc(config, out)
 	}
 	return nil
 }
 
-func (m *MockCommands) DeleteCluster(name string) error {
+func (m *MockCommands) DeleteCluster(ctx context.Context, name string, out io.Writer) error {
 	if m.DeleteClusterFunc != nil {
-		return m.DeleteClusterFunc(name)
+		return m.DeleteClusterFunc(name, out)
 	}
 	return nil
 }
 
-func (m *MockCommands) LoadDockerImage(image, cluster string) error {
+func (m *MockCommands) StopCluster(ctx context.Context, name string, out io.Writer) error {
+	if m.StopClusterFunc != nil {
+		return m.StopClusterFunc(name, out)
+	}
+	return nil
+}
+
+func (m *MockCommands) StartCluster(ctx context.Context, name string, out io.Writer) error {
+	if m.StartClusterFunc != nil {
+		return m.StartClusterFunc(name, out)
+	}
+	return nil
+}
+
+func (m *MockCommands) RestartCluster(ctx context.Context, name string, out io.Writer) error {
+	if m.RestartClusterFunc != nil {
+		return m.RestartClusterFunc(name, out)
+	}
+	return nil
+}
+
+func (m *MockCommands) AddWorker(ctx context.Context, cluster string, out io.Writer) (string, error) {
+	if m.AddWorkerFunc != nil {
+		return m.AddWorkerFunc(cluster, out)
+	}
+	return cluster + "-worker", nil
+}
+
+func (m *MockCommands) RemoveWorker(ctx context.Context, cluster, node string, out io.Writer) error {
+	if m.RemoveWorkerFunc != nil {
+		return m.RemoveWorkerFunc(cluster, node, out)
+	}
+	return nil
+}
+
+func (m *MockCommands) GetImages(ctx context.Context) ([]cmd.Image, error) {
+	if m.GetImagesFunc != nil {
+		return m.GetImagesFunc()
+	}
+	return []cmd.Image{}, nil
+}
+
+func (m *MockCommands) GetClusterImages(ctx context.Context, clusterName string) ([]cmd.ClusterImage, error) {
+	if m.GetClusterImagesFunc != nil {
+		return m.GetClusterImagesFunc(clusterName)
+	}
+	return []cmd.ClusterImage{}, nil
+}
+
+func (m *MockCommands) LoadDockerImage(ctx context.Context, images []string, cluster string, nodes []string, out io.Writer) error {
 	if m.LoadDockerImageFunc != nil {
-		return m.LoadDockerImageFunc(image, cluster)
+		return m.LoadDockerImageFunc(images, cluster, nodes, out)
+	}
+	return nil
+}
+
+func (m *MockCommands) LoadImageArchive(ctx context.Context, archives []string, cluster string, nodes []string, out io.Writer) error {
+	if m.LoadArchiveFunc != nil {
+		return m.LoadArchiveFunc(archives, cluster, nodes, out)
 	}
 	return nil
 }
 
-func (m *MockCommands) BuildNodeImage(path string) error {
+func (m *MockCommands) BuildAndLoadImage(ctx context.Context, opts cmd.BuildOptions, cluster string, nodes []string, out io.Writer) error {
+	if m.BuildAndLoadFunc != nil {
+		return m.BuildAndLoadFunc(opts, cluster, nodes, out)
+	}
+	return nil
+}
+
+func (m *MockCommands) BuildNodeImage(ctx context.Context, path string, out io.Writer) error {
 	if m.BuildNodeImageFunc != nil {
-		return m.BuildNodeImageFunc(path)
+		return m.BuildNodeImageFunc(path, out)
 	}
 	return nil
 }
 
-func (m *MockCommands) ExportLogs(cluster, path string) error {
+func (m *MockCommands) ExportLogs(ctx context.Context, cluster, path string, out io.Writer) error {
 	if m.ExportLogsFunc != nil {
-		return m.ExportLogsFunc(cluster, path)
+		return m.ExportLogsFunc(cluster, path, out)
+	}
+	return nil
+}
+
+func (m *MockCommands) StreamLogs(ctx context.Context, source cmd.LogSource, opts cmd.LogOptions, out io.Writer) error {
+	if m.StreamLogsFunc != nil {
+		return m.StreamLogsFunc(source, opts, out)
+	}
+	return nil
+}
+
+func (m *MockCommands) CreateRegistry(ctx context.Context, out io.Writer) error {
+	if m.CreateRegistryFunc != nil {
+		return m.CreateRegistryFunc(out)
+	}
+	return nil
+}
+
+func (m *MockCommands) AttachRegistry(ctx context.Context, cluster string, out io.Writer) error {
+	if m.AttachRegistryFunc != nil {
+		return m.AttachRegistryFunc(cluster, out)
+	}
+	return nil
+}
+
+func (m *MockCommands) DetachRegistry(ctx context.Context, cluster string, out io.Writer) error {
+	if m.DetachRegistryFunc != nil {
+		return m.DetachRegistryFunc(cluster, out)
+	}
+	return nil
+}
+
+func (m *MockCommands) GetKubeconfig(ctx context.Context, cluster string, internal bool) (string, error) {
+	if m.GetKubeconfigFunc != nil {
+		return m.GetKubeconfigFunc(cluster, internal)
+	}
+	return "", nil
+}
+
+func (m *MockCommands) ExportKubeconfig(ctx context.Context, cluster, path string, internal bool) error {
+	if m.ExportKubeconfigFunc != nil {
+		return m.ExportKubeconfigFunc(cluster, path, internal)
+	}
+	return nil
+}
+
+func (m *MockCommands) MergeKubeconfig(ctx context.Context, cluster string) error {
+	if m.MergeKubeconfigFunc != nil {
+		return m.MergeKubeconfigFunc(cluster)
+	}
+	return nil
+}
+
+func (m *MockCommands) RemoveKubeconfig(ctx context.Context, cluster string) error {
+	if m.RemoveKubeconfigFunc != nil {
+		return m.RemoveKubeconfigFunc(cluster)
+	}
+	return nil
+}
+
+func (m *MockCommands) UseKubeContext(ctx context.Context, cluster string) error {
+	if m.UseKubeContextFunc != nil {
+		return m.UseKubeContextFunc(cluster)
 	}
 	return nil
 }
 
-func TestGetKindClusters(t *testing.T) {
-	// Store original and restore after test
+func (m *MockCommands) CopyToClipboard(ctx context.Context, text string) error {
+	if m.CopyToClipboardFunc != nil {
+		return m.CopyToClipboardFunc(text)
+	}
+	return nil
+}
+
+func TestRefreshClusters(t *testing.T) {
 	original := cmd.Commands
 	defer func() { cmd.Commands = original }()
 
-	tests := []struct {
-		name        string
-		mockFunc    func() ([]cmd.Cluster, error)
-		expectError bool
-		expectType  string
-	}{
-		{
-			name: "successful fetch",
-			mockFunc: func() ([]cmd.Cluster, error) {
-				return []cmd.Cluster{
-					{Name: "test-cluster", Status: "running"},
-				}, nil
-			},
-			expectError: false,
-			expectType:  "ClustersMsg",
+	var gotAge time.Duration
+	cmd.Commands = &MockCommands{
+		GetClusterNamesFunc: func() ([]string, error) {
+			return []string{"dev", "test"}, nil
 		},
-		{
-			name: "error fetching clusters",
-			mockFunc: func() ([]cmd.Cluster, error) {
-				return nil, errors.New("failed to get clusters")
-			},
-			expectError: true,
-			expectType:  "MessageMsg",
+		CachedClusterFunc: func(name string) (cmd.Cluster, bool) {
+			if name == "dev" {
+				return cmd.Cluster{Name: "dev", Status: "stopped"}, true
+			}
+			return cmd.Cluster{}, false
+		},
+		EnrichClustersFunc: func(names []string, maxAge time.Duration, found func(cmd.Cluster)) {
+			gotAge = maxAge
+			for _, name := range names {
+				found(cmd.Cluster{Name: name, Status: "running"})
+			}
 		},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			cmd.Commands = &MockCommands{
-				GetClustersFunc: tt.mockFunc,
-			}
-			
-			cmdFunc := GetKindClusters()
-			msg := cmdFunc()
-			
-			if tt.expectError {
-				msgMsg, ok := msg.(models.MessageMsg)
-				if !ok {
-					t.Errorf("Expected MessageMsg, got %T", msg)
-				}
-				if msgMsg.MsgType != "error" {
-					t.Errorf("Expected error message type, got %s", msgMsg.MsgType)
-				}
-				if msgMsg.Text != "failed to get clusters" {
-					t.Errorf("Expected error text 'failed to get clusters', got %s", msgMsg.Text)
-				}
-			} else {
-				clustersMsg, ok := msg.(models.ClustersMsg)
-				if !ok {
-					t.Errorf("Expected ClustersMsg, got %T", msg)
-				}
-				if len(clustersMsg) != 1 {
-					t.Errorf("Expected 1 cluster, got %d", len(clustersMsg))
-				}
-			}
-		})
+	msg, ok := RefreshClusters(3, time.Minute)().(models.ClustersMsg)
+	if !ok {
+		t.Fatalf("Expected ClustersMsg, got %T", msg)
+	}
+	if msg.Seq != 3 || len(msg.Clusters) != 2 {
+		t.Fatalf("ClustersMsg = %+v, expected 2 clusters for refresh 3", msg)
+	}
+	// The list starts from the last known state
+	if msg.Clusters[0].Status != "stopped" || msg.Clusters[1].Status != "" {
+		t.Errorf("ClustersMsg clusters = %+v, expected the cached dev and a bare test", msg.Clusters)
+	}
+
+	updated := map[string]string{}
+	for next := msg.Next; next != nil; {
+		result := next()
+		if result == nil {
+			break
+		}
+		update, ok := result.(models.ClusterUpdateMsg)
+		if !ok {
+			t.Fatalf("Expected ClusterUpdateMsg, got %T", result)
+		}
+		if update.Seq != 3 {
+			t.Errorf("ClusterUpdateMsg.Seq = %d, expected 3", update.Seq)
+		}
+		updated[update.Cluster.Name] = update.Cluster.Status
+		next = update.Next
+	}
+	if len(updated) != 2 || updated["dev"] != "running" || updated["test"] != "running" {
+		t.Errorf("updates = %v, expected both clusters running", updated)
+	}
+	if gotAge != time.Minute {
+		t.Errorf("EnrichClusters() maxAge = %s, expected 1m", gotAge)
+	}
+
+	cmd.Commands = &MockCommands{
+		GetClusterNamesFunc: func() ([]string, error) {
+			return nil, errors.New("failed to get clusters")
+		},
+	}
+	if errMsg, ok := RefreshClusters(4, 0)().(models.MessageMsg); !ok || errMsg.MsgType != "error" || errMsg.Text != "failed to get clusters" {
+		t.Errorf("RefreshClusters() with a failing kind = %+v, expected an error message", errMsg)
 	}
 }
 
@@ -271,14 +474,14 @@ func TestCreateKindCluster(t *testing.T) {
 	tests := []struct {
 		name        string
 		clusterName string
-		mockFunc    func(string) error
+		mockFunc    func(cmd.ClusterConfig, io.Writer) error
 		expectError bool
 		expectMsg   string
 	}{
 		{
 			name:        "successful creation",
 			clusterName: "new-cluster",
-			mockFunc: func(name string) error {
+			mockFunc: func(config cmd.ClusterConfig, out io.Writer) error {
 				return nil
 			},
 			expectError: false,
@@ -287,7 +490,7 @@ func TestCreateKindCluster(t *testing.T) {
 		{
 			name:        "error creating cluster",
 			clusterName: "new-cluster",
-			mockFunc: func(name string) error {
+			mockFunc: func(config cmd.ClusterConfig, out io.Writer) error {
 				return errors.New("failed to create cluster")
 			},
 			expectError: true,
@@ -301,7 +504,7 @@ func TestCreateKindCluster(t *testing.T) {
 				CreateClusterFunc: tt.mockFunc,
 			}
 			
-			cmdFunc := CreateKindCluster(tt.clusterName)
+			cmdFunc := CreateKindCluster(context.Background(), cmd.ClusterConfig{Name: tt.clusterName}, nil)
 			msg := cmdFunc()
 			
 			msgMsg, ok := msg.(models.MessageMsg)
@@ -333,14 +536,14 @@ func TestDeleteKindCluster(t *testing.T) {
 	tests := []struct {
 		name        string
 		clusterName string
-		mockFunc    func(string) error
+		mockFunc    func(string, io.Writer) error
 		expectError bool
 		expectMsg   string
 	}{
 		{
 			name:        "successful deletion",
 			clusterName: "old-cluster",
-			mockFunc: func(name string) error {
+			mockFunc: func(name string, out io.Writer) error {
 				return nil
 			},
 			expectError: false,
@@ -349,7 +552,7 @@ func TestDeleteKindCluster(t *testing.T) {
 		{
 			name:        "error deleting cluster",
 			clusterName: "old-cluster",
-			mockFunc: func(name string) error {
+			mockFunc: func(name string, out io.Writer) error {
 				return errors.New("failed to delete cluster")
 			},
 			expectError: true,
@@ -363,7 +566,7 @@ func TestDeleteKindCluster(t *testing.T) {
 				DeleteClusterFunc: tt.mockFunc,
 			}
 			
-			cmdFunc := DeleteKindCluster(tt.clusterName)
+			cmdFunc := DeleteKindCluster(context.Background(), tt.clusterName, nil)
 			msg := cmdFunc()
 			
 			msgMsg, ok := msg.(models.MessageMsg)
@@ -388,33 +591,337 @@ func TestDeleteKindCluster(t *testing.T) {
 	}
 }
 
+func TestGetPods(t *testing.T) {
+	original := cmd.Commands
+	defer func() { cmd.Commands = original }()
+
+	cmd.Commands = &MockCommands{
+		GetPodsFunc: func(cluster, namespace string) ([]cmd.Pod, error) {
+			if cluster != "dev" || namespace != "" {
+				t.Errorf("GetPods() called with %q/%q", cluster, namespace)
+			}
+			return []cmd.Pod{{Name: "coredns"}}, nil
+		},
+	}
+
+	msg, ok := GetPods("dev", "", 3)().(models.PodsMsg)
+	if !ok {
+		t.Fatal("Expected PodsMsg")
+	}
+	if msg.Cluster != "dev" || msg.Seq != 3 || len(msg.Pods) != 1 || msg.Err != nil {
+		t.Errorf("Unexpected PodsMsg %+v", msg)
+	}
+
+	// Errors stay in the PodsMsg so the refresh loop keeps going
+	cmd.Commands = &MockCommands{
+		GetPodsFunc: func(string, string) ([]cmd.Pod, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	msg, ok = GetPods("dev", "", 4)().(models.PodsMsg)
+	if !ok || msg.Err == nil || msg.Seq != 4 {
+		t.Errorf("Expected PodsMsg with an error, got %+v", msg)
+	}
+}
+
+func TestGetNodeDetail(t *testing.T) {
+	original := cmd.Commands
+	defer func() { cmd.Commands = original }()
+
+	cmd.Commands = &MockCommands{
+		GetNodeDetailFunc: func(cluster, node string) (*cmd.NodeDetail, error) {
+			if cluster != "dev" || node != "dev-worker" {
+				t.Errorf("GetNodeDetail() called with %q/%q", cluster, node)
+			}
+			return &cmd.NodeDetail{Node: cmd.Node{Name: node}}, nil
+		},
+	}
+
+	msg, ok := GetNodeDetail("dev", "dev-worker")().(models.NodeDetailMsg)
+	if !ok {
+		t.Fatal("Expected NodeDetailMsg")
+	}
+	if msg.Cluster != "dev" || msg.Node != "dev-worker" || msg.Detail == nil || msg.Err != nil {
+		t.Errorf("Unexpected NodeDetailMsg %+v", msg)
+	}
+
+	// Errors stay in the message so the detail view keeps what it showed
+	cmd.Commands = &MockCommands{
+		GetNodeDetailFunc: func(string, string) (*cmd.NodeDetail, error) {
+			return nil, errors.New("node dev-worker not found")
+		},
+	}
+	msg, ok = GetNodeDetail("dev", "dev-worker")().(models.NodeDetailMsg)
+	if !ok || msg.Err == nil || msg.Node != "dev-worker" {
+		t.Errorf("Expected NodeDetailMsg with an error, got %+v", msg)
+	}
+}
+
+func TestGetImages(t *testing.T) {
+	original := cmd.Commands
+	defer func() { cmd.Commands = original }()
+
+	cmd.Commands = &MockCommands{
+		GetImagesFunc: func() ([]cmd.Image, error) {
+			return []cmd.Image{{Repository: "nginx", Tag: "latest"}}, nil
+		},
+	}
+	msg, ok := GetImages()().(models.ImagesMsg)
+	if !ok || len(msg.Images) != 1 || msg.Err != nil {
+		t.Errorf("Unexpected ImagesMsg %+v", msg)
+	}
+
+	// Errors are shown in the picker instead of as a message
+	cmd.Commands = &MockCommands{
+		GetImagesFunc: func() ([]cmd.Image, error) {
+			return nil, errors.New("docker not running")
+		},
+	}
+	msg, ok = GetImages()().(models.ImagesMsg)
+	if !ok || msg.Err == nil {
+		t.Errorf("Expected ImagesMsg with an error, got %+v", msg)
+	}
+}
+
+func TestGetClusterImages(t *testing.T) {
+	original := cmd.Commands
+	defer func() { cmd.Commands = original }()
+
+	var requested string
+	cmd.Commands = &MockCommands{
+		GetClusterImagesFunc: func(cluster string) ([]cmd.ClusterImage, error) {
+			requested = cluster
+			return []cmd.ClusterImage{{Ref: "docker.io/library/nginx:latest", Status: cmd.ImageCurrent}}, nil
+		},
+	}
+	msg, ok := GetClusterImages("dev")().(models.ClusterImagesMsg)
+	if !ok || requested != "dev" || msg.Cluster != "dev" || len(msg.Images) != 1 || msg.Err != nil {
+		t.Errorf("Unexpected ClusterImagesMsg %+v", msg)
+	}
+
+	cmd.Commands = &MockCommands{
+		GetClusterImagesFunc: func(string) ([]cmd.ClusterImage, error) {
+			return nil, errors.New("cluster not running")
+		},
+	}
+	msg, ok = GetClusterImages("dev")().(models.ClusterImagesMsg)
+	if !ok || msg.Err == nil {
+		t.Errorf("Expected ClusterImagesMsg with an error, got %+v", msg)
+	}
+}
+
+func TestClusterLifecycleCommands(t *testing.T) {
+	original := cmd.Commands
+	defer func() { cmd.Commands = original }()
+
+	var called []string
+	record := func(action string, err error) func(string, io.Writer) error {
+		return func(name string, out io.Writer) error {
+			called = append(called, action+" "+name)
+			return err
+		}
+	}
+
+	tests := []struct {
+		name      string
+		mock      *MockCommands
+		run       func() tea.Cmd
+		expectMsg string
+		msgType   string
+	}{
+		{
+			name:      "stop",
+			mock:      &MockCommands{StopClusterFunc: record("stop", nil)},
+			run:       func() tea.Cmd { return StopKindCluster(context.Background(), "dev", nil) },
+			expectMsg: "Cluster 'dev' stopped successfully!",
+			msgType:   "success",
+		},
+		{
+			name:      "start",
+			mock:      &MockCommands{StartClusterFunc: record("start", nil)},
+			run:       func() tea.Cmd { return StartKindCluster(context.Background(), "dev", nil) },
+			expectMsg: "Cluster 'dev' started successfully!",
+			msgType:   "success",
+		},
+		{
+			name:      "restart",
+			mock:      &MockCommands{RestartClusterFunc: record("restart", nil)},
+			run:       func() tea.Cmd { return RestartKindCluster(context.Background(), "dev", nil) },
+			expectMsg: "Cluster 'dev' restarted successfully!",
+			msgType:   "success",
+		},
+		{
+			name:      "attach registry",
+			mock:      &MockCommands{AttachRegistryFunc: record("attach", nil)},
+			run:       func() tea.Cmd { return AttachRegistry(context.Background(), "dev", nil) },
+			expectMsg: "Registry localhost:5001 attached to cluster 'dev'",
+			msgType:   "success",
+		},
+		{
+			name:      "detach registry",
+			mock:      &MockCommands{DetachRegistryFunc: record("detach", nil)},
+			run:       func() tea.Cmd { return DetachRegistry(context.Background(), "dev", nil) },
+			expectMsg: "Registry detached from cluster 'dev'",
+			msgType:   "success",
+		},
+		{
+			name: "add worker",
+			mock: &MockCommands{AddWorkerFunc: func(cluster string, out io.Writer) (string, error) {
+				called = append(called, "add "+cluster)
+				return "dev-worker2", nil
+			}},
+			run:       func() tea.Cmd { return AddWorker(context.Background(), "dev", nil) },
+			expectMsg: "Worker 'dev-worker2' added to cluster 'dev'",
+			msgType:   "success",
+		},
+		{
+			name: "remove worker",
+			mock: &MockCommands{RemoveWorkerFunc: func(cluster, node string, out io.Writer) error {
+				called = append(called, "remove "+node+" "+cluster)
+				return nil
+			}},
+			run:       func() tea.Cmd { return RemoveWorker(context.Background(), "dev", "dev-worker2", nil) },
+			expectMsg: "Worker 'dev-worker2' removed from cluster 'dev'",
+			msgType:   "success",
+		},
+		{
+			name: "add worker error",
+			mock: &MockCommands{AddWorkerFunc: func(cluster string, out io.Writer) (string, error) {
+				called = append(called, "add "+cluster)
+				return "", errors.New("failed to add worker to dev")
+			}},
+			run:       func() tea.Cmd { return AddWorker(context.Background(), "dev", nil) },
+			expectMsg: "failed to add worker to dev",
+			msgType:   "error",
+		},
+		{
+			name:      "stop error",
+			mock:      &MockCommands{StopClusterFunc: record("stop", errors.New("failed to stop cluster"))},
+			run:       func() tea.Cmd { return StopKindCluster(context.Background(), "dev", nil) },
+			expectMsg: "failed to stop cluster",
+			msgType:   "error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = nil
+			cmd.Commands = tt.mock
+
+			msg, ok := tt.run()().(models.MessageMsg)
+			if !ok {
+				t.Fatalf("Expected MessageMsg")
+			}
+			if msg.MsgType != tt.msgType || msg.Text != tt.expectMsg {
+				t.Errorf("Expected %s message %q, got %s %q", tt.msgType, tt.expectMsg, msg.MsgType, msg.Text)
+			}
+			if len(called) != 1 || !strings.HasSuffix(called[0], " dev") {
+				t.Errorf("Expected one call for cluster dev, got %v", called)
+			}
+		})
+	}
+}
+
+func TestLoadImageArchive(t *testing.T) {
+	original := cmd.Commands
+	defer func() { cmd.Commands = original }()
+
+	var loaded, targets []string
+	cmd.Commands = &MockCommands{
+		LoadArchiveFunc: func(archives []string, cluster string, nodes []string, out io.Writer) error {
+			loaded, targets = archives, nodes
+			return nil
+		},
+	}
+	msg := LoadImageArchive(context.Background(), []string{"app.tar"}, "dev", []string{"dev-worker"}, io.Discard)().(models.MessageMsg)
+	if msg.MsgType != "success" || msg.Text != "Archive 'app.tar' loaded successfully!" || len(loaded) != 1 {
+		t.Errorf("Unexpected message %+v", msg)
+	}
+	if len(targets) != 1 || targets[0] != "dev-worker" {
+		t.Errorf("LoadImageArchive() should pass the target nodes, got %v", targets)
+	}
+	msg = LoadImageArchive(context.Background(), []string{"a.tar", "b.tar"}, "dev", nil, io.Discard)().(models.MessageMsg)
+	if msg.Text != "2 archives loaded successfully!" {
+		t.Errorf("Unexpected message %+v", msg)
+	}
+
+	cmd.Commands = &MockCommands{
+		LoadArchiveFunc: func([]string, string, []string, io.Writer) error {
+			return errors.New("no such file")
+		},
+	}
+	msg = LoadImageArchive(context.Background(), []string{"app.tar"}, "dev", nil, io.Discard)().(models.MessageMsg)
+	if msg.MsgType != "error" || msg.Text != "no such file" {
+		t.Errorf("Expected an error message, got %+v", msg)
+	}
+}
+
+func TestBuildAndLoadImage(t *testing.T) {
+	original := cmd.Commands
+	defer func() { cmd.Commands = original }()
+
+	var built cmd.BuildOptions
+	var into string
+	cmd.Commands = &MockCommands{
+		BuildAndLoadFunc: func(opts cmd.BuildOptions, cluster string, nodes []string, out io.Writer) error {
+			built, into = opts, cluster
+			return nil
+		},
+	}
+	opts := cmd.BuildOptions{Context: "./app", Tag: "myapp:dev"}
+	msg := BuildAndLoadImage(context.Background(), opts, "dev", nil, io.Discard)().(models.MessageMsg)
+	if msg.MsgType != "success" || msg.Text != "Image 'myapp:dev' built and loaded successfully!" || built != opts || into != "dev" {
+		t.Errorf("Unexpected message %+v, built %+v into %q", msg, built, into)
+	}
+
+	cmd.Commands = &MockCommands{
+		BuildAndLoadFunc: func(cmd.BuildOptions, string, []string, io.Writer) error {
+			return errors.New("failed to build image")
+		},
+	}
+	msg = BuildAndLoadImage(context.Background(), opts, "dev", nil, io.Discard)().(models.MessageMsg)
+	if msg.MsgType != "error" {
+		t.Errorf("Expected an error message, got %+v", msg)
+	}
+}
+
 func TestLoadDockerImage(t *testing.T) {
 	original := cmd.Commands
 	defer func() { cmd.Commands = original }()
 
 	tests := []struct {
 		name        string
-		imageName   string
+		images      []string
 		clusterName string
-		mockFunc    func(string, string) error
+		mockFunc    func([]string, string, []string, io.Writer) error
 		expectError bool
 		expectMsg   string
 	}{
 		{
 			name:        "successful load",
-			imageName:   "nginx:latest",
+			images:      []string{"nginx:latest"},
 			clusterName: "test-cluster",
-			mockFunc: func(image, cluster string) error {
+			mockFunc: func(images []string, cluster string, nodes []string, out io.Writer) error {
 				return nil
 			},
 			expectError: false,
 			expectMsg:   "Image 'nginx:latest' loaded successfully!",
 		},
+		{
+			name:        "several images",
+			images:      []string{"nginx:latest", "redis:7"},
+			clusterName: "test-cluster",
+			mockFunc: func(images []string, cluster string, nodes []string, out io.Writer) error {
+				return nil
+			},
+			expectError: false,
+			expectMsg:   "2 images loaded successfully!",
+		},
 		{
 			name:        "error loading image",
-			imageName:   "nginx:latest",
+			images:      []string{"nginx:latest"},
 			clusterName: "test-cluster",
-			mockFunc: func(image, cluster string) error {
+			mockFunc: func(images []string, cluster string, nodes []string, out io.Writer) error {
 				return errors.New("failed to load image")
 			},
 			expectError: true,
@@ -428,7 +935,7 @@ func TestLoadDockerImage(t *testing.T) {
 				LoadDockerImageFunc: tt.mockFunc,
 			}
 			
-			cmdFunc := LoadDockerImage(tt.imageName, tt.clusterName)
+			cmdFunc := LoadDockerImage(context.Background(), tt.images, tt.clusterName, nil, nil)
 			msg := cmdFunc()
 			
 			msgMsg, ok := msg.(models.MessageMsg)
@@ -460,14 +967,14 @@ func TestBuildNodeImage(t *testing.T) {
 	tests := []struct {
 		name        string
 		sourcePath  string
-		mockFunc    func(string) error
+		mockFunc    func(string, io.Writer) error
 		expectError bool
 		expectMsg   string
 	}{
 		{
 			name:       "successful build",
 			sourcePath: "/path/to/source",
-			mockFunc: func(path string) error {
+			mockFunc: func(path string, out io.Writer) error {
 				return nil
 			},
 			expectError: false,
@@ -476,7 +983,7 @@ func TestBuildNodeImage(t *testing.T) {
 		{
 			name:       "error building image",
 			sourcePath: "/path/to/source",
-			mockFunc: func(path string) error {
+			mockFunc: func(path string, out io.Writer) error {
 				return errors.New("failed to build node image")
 			},
 			expectError: true,
@@ -490,7 +997,7 @@ func TestBuildNodeImage(t *testing.T) {
 				BuildNodeImageFunc: tt.mockFunc,
 			}
 			
-			cmdFunc := BuildNodeImage(tt.sourcePath)
+			cmdFunc := BuildNodeImage(context.Background(), tt.sourcePath, nil)
 			msg := cmdFunc()
 			
 			msgMsg, ok := msg.(models.MessageMsg)
@@ -515,6 +1022,107 @@ func TestBuildNodeImage(t *testing.T) {
 	}
 }
 
+func TestStreamLogs(t *testing.T) {
+	original := cmd.Commands
+	defer func() { cmd.Commands = original }()
+
+	source := cmd.LogSource{Cluster: "dev", Namespace: "default", Pod: "web"}
+	var output strings.Builder
+	cmd.Commands = &MockCommands{
+		StreamLogsFunc: func(s cmd.LogSource, opts cmd.LogOptions, out io.Writer) error {
+			if s != source || !opts.Follow {
+				t.Errorf("StreamLogs() called with %+v %+v", s, opts)
+			}
+			fmt.Fprintln(out, "listening on :8080")
+			return nil
+		},
+	}
+
+	if msg := StreamLogs(context.Background(), source, cmd.LogOptions{Follow: true}, &output)(); msg != nil {
+		t.Errorf("Expected no message when the log ends, got %+v", msg)
+	}
+	if output.String() != "listening on :8080\n" {
+		t.Errorf("Expected the log to be written to out, got %q", output.String())
+	}
+
+	cmd.Commands = &MockCommands{
+		StreamLogsFunc: func(cmd.LogSource, cmd.LogOptions, io.Writer) error {
+			return errors.New("container not found")
+		},
+	}
+	msg, ok := StreamLogs(context.Background(), source, cmd.LogOptions{}, &output)().(models.MessageMsg)
+	if !ok || msg.MsgType != "error" || !strings.Contains(msg.Text, "container not found") {
+		t.Errorf("Expected an error message, got %+v", msg)
+	}
+}
+
+func TestPortForwardCommands(t *testing.T) {
+	defer cmd.Forwards.StopAll()
+
+	spec := cmd.PortForwardSpec{Cluster: "dev", Namespace: "default", Kind: "svc", Name: "web", RemotePort: 80}
+	msg, ok := StartPortForward(spec)().(models.MessageMsg)
+	if !ok || msg.MsgType != "success" || !strings.Contains(msg.Text, "default/svc/web:80") {
+		t.Fatalf("Expected a success message, got %+v", msg)
+	}
+
+	forwards := cmd.Forwards.List()
+	if len(forwards) != 1 {
+		t.Fatalf("Expected one forward, got %d", len(forwards))
+	}
+
+	spec.LocalPort = forwards[0].Spec.LocalPort
+	msg, ok = StartPortForward(spec)().(models.MessageMsg)
+	if !ok || msg.MsgType != "error" || !strings.Contains(msg.Text, "already forwarded") {
+		t.Errorf("Expected a port conflict, got %+v", msg)
+	}
+
+	msg, ok = StopPortForward(forwards[0].ID)().(models.MessageMsg)
+	if !ok || msg.MsgType != "success" || !strings.Contains(msg.Text, "Stopped forwarding") {
+		t.Errorf("Expected a stop message, got %+v", msg)
+	}
+	msg, ok = StopPortForward(forwards[0].ID)().(models.MessageMsg)
+	if !ok || msg.MsgType != "error" {
+		t.Errorf("Expected an error stopping a missing forward, got %+v", msg)
+	}
+}
+
+func TestShellDone(t *testing.T) {
+	done := shellDone("dev-worker")
+
+	if msg := done(nil); msg != nil {
+		t.Errorf("Expected no message for a clean exit, got %+v", msg)
+	}
+
+	exitErr := exec.Command("sh", "-c", "exit 3").Run()
+	msg, ok := done(exitErr).(models.MessageMsg)
+	if !ok || msg.MsgType != "info" || !strings.Contains(msg.Text, "status 3") {
+		t.Errorf("Expected an info message with the exit status, got %+v", msg)
+	}
+
+	msg, ok = done(exec.ErrNotFound).(models.MessageMsg)
+	if !ok || msg.MsgType != "error" || !strings.Contains(msg.Text, "dev-worker") {
+		t.Errorf("Expected an error message, got %+v", msg)
+	}
+}
+
+func TestSaveLogs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "web.log")
+
+	msg, ok := SaveLogs(path, []string{"first", "second"})().(models.MessageMsg)
+	if !ok || msg.MsgType != "success" {
+		t.Fatalf("Expected a success message, got %+v", msg)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "first\nsecond\n" {
+		t.Errorf("Saved log = %q, %v", data, err)
+	}
+
+	msg, ok = SaveLogs(filepath.Join(path, "nested.log"), []string{"x"})().(models.MessageMsg)
+	if !ok || msg.MsgType != "error" {
+		t.Errorf("Expected an error saving below a file, got %+v", msg)
+	}
+}
+
 func TestExportKindLogs(t *testing.T) {
 	original := cmd.Commands
 	defer func() { cmd.Commands = original }()
@@ -525,7 +1133,7 @@ func TestExportKindLogs(t *testing.T) {
 		name        string
 		clusterName string
 		outputPath  string
-		mockFunc    func(string, string) error
+		mockFunc    func(string, string, io.Writer) error
 		expectError bool
 		expectMsg   string
 	}{
@@ -533,7 +1141,7 @@ func TestExportKindLogs(t *testing.T) {
 			name:        "successful export to specific path",
 			clusterName: "test-cluster",
 			outputPath:  "/tmp/logs",
-			mockFunc: func(cluster, path string) error {
+			mockFunc: func(cluster, path string, out io.Writer) error {
 				return nil
 			},
 			expectError: false,
@@ -543,7 +1151,7 @@ func TestExportKindLogs(t *testing.T) {
 			name:        "successful export to current directory",
 			clusterName: "test-cluster",
 			outputPath:  "",
-			mockFunc: func(cluster, path string) error {
+			mockFunc: func(cluster, path string, out io.Writer) error {
 				return nil
 			},
 			expectError: false,
@@ -553,7 +1161,7 @@ func TestExportKindLogs(t *testing.T) {
 			name:        "successful export with tilde expansion",
 			clusterName: "test-cluster",
 			outputPath:  "~/logs",
-			mockFunc: func(cluster, path string) error {
+			mockFunc: func(cluster, path string, out io.Writer) error {
 				// Check that path was expanded
 				expectedPath := filepath.Join(homeDir, "logs")
 				if path != expectedPath {
@@ -568,7 +1176,7 @@ func TestExportKindLogs(t *testing.T) {
 			name:        "error exporting logs",
 			clusterName: "test-cluster",
 			outputPath:  "/tmp/logs",
-			mockFunc: func(cluster, path string) error {
+			mockFunc: func(cluster, path string, out io.Writer) error {
 				return errors.New("failed to export logs")
 			},
 			expectError: true,
@@ -582,7 +1190,7 @@ func TestExportKindLogs(t *testing.T) {
 				ExportLogsFunc: tt.mockFunc,
 			}
 			
-			cmdFunc := ExportKindLogs(tt.clusterName, tt.outputPath)
+			cmdFunc := ExportKindLogs(context.Background(), tt.clusterName, tt.outputPath, nil)
 			msg := cmdFunc()
 			
 			msgMsg, ok := msg.(models.MessageMsg)
@@ -614,8 +1222,8 @@ func TestCommandsReturnFunc(t *testing.T) {
 		cmd  tea.Cmd
 	}{
 		{
-			name: "GetKindClusters",
-			cmd:  GetKindClusters(),
+			name: "RefreshClusters",
+			cmd:  RefreshClusters(1, cmd.EnrichTTL),
 		},
 		{
 			name: "GetClusterNodes",
@@ -627,23 +1235,23 @@ func TestCommandsReturnFunc(t *testing.T) {
 		},
 		{
 			name: "CreateKindCluster",
-			cmd:  CreateKindCluster("new-cluster"),
+			cmd:  CreateKindCluster(context.Background(), cmd.ClusterConfig{Name: "new-cluster"}, nil),
 		},
 		{
 			name: "DeleteKindCluster",
-			cmd:  DeleteKindCluster("old-cluster"),
+			cmd:  DeleteKindCluster(context.Background(), "old-cluster", nil),
 		},
 		{
 			name: "LoadDockerImage",
-			cmd:  LoadDockerImage("nginx:latest", "test-cluster"),
+			cmd:  LoadDockerImage(context.Background(), []string{"nginx:latest"}, "test-cluster", nil, nil),
 		},
 		{
 			name: "BuildNodeImage",
-			cmd:  BuildNodeImage("/path/to/source"),
+			cmd:  BuildNodeImage(context.Background(), "/path/to/source", nil),
 		},
 		{
 			name: "ExportKindLogs",
-			cmd:  ExportKindLogs("test-cluster", "./logs"),
+			cmd:  ExportKindLogs(context.Background(), "test-cluster", "./logs", nil),
 		},
 	}
 
@@ -693,7 +1301,7 @@ func TestExportKindLogsPathExpansion(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Get the command
-			cmd := ExportKindLogs("test-cluster", tt.outputPath)
+			cmd := ExportKindLogs(context.Background(), "test-cluster", tt.outputPath, nil)
 			
 			if cmd == nil {
 				t.Error("ExportKindLogs should return a non-nil command")
@@ -754,4 +1362,137 @@ func TestCreateKindClusterMessage(t *testing.T) {
 	if !strings.Contains(expectedMessage, "successfully") {
 		t.Error("Success message should indicate success")
 	}
-}
\ No newline at end of file
+}
+
+func TestTemplateCommands(t *testing.T) {
+	original := cmd.Templates
+	defer func() { cmd.Templates = original }()
+	cmd.Templates = cmd.TemplateStore{Dir: t.TempDir()}
+
+	template := cmd.Template{Name: "dev", Cluster: cmd.NewClusterConfig("", 1, 1, "")}
+
+	msg := SaveTemplate(template)()
+	if m, ok := msg.(models.MessageMsg); !ok || m.MsgType != "success" || m.Text != "Template 'dev' saved successfully!" {
+		t.Errorf("SaveTemplate() = %+v", msg)
+	}
+
+	msg = CloneTemplate("dev", "dev-copy")()
+	if m, ok := msg.(models.MessageMsg); !ok || m.MsgType != "success" {
+		t.Errorf("CloneTemplate() = %+v", msg)
+	}
+
+	msg = GetTemplates()()
+	templatesMsg, ok := msg.(models.TemplatesMsg)
+	if !ok {
+		t.Fatalf("Expected TemplatesMsg, got %T", msg)
+	}
+	if len(templatesMsg) != 2 {
+		t.Errorf("Expected 2 templates, got %d", len(templatesMsg))
+	}
+
+	// A broken file does not hide the other templates
+	if err := os.WriteFile(filepath.Join(cmd.Templates.Dir, "bad.yaml"), []byte("cluster: ["), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	batch, ok := GetTemplates()().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("GetTemplates() with a broken file = %T, expected the templates and a message", batch)
+	}
+	if templates, ok := batch[0]().(models.TemplatesMsg); !ok || len(templates) != 2 {
+		t.Errorf("GetTemplates() with a broken file listed %+v", templates)
+	}
+	if m, ok := batch[1]().(models.MessageMsg); !ok || m.MsgType != "error" || !strings.Contains(m.Text, "bad") {
+		t.Errorf("GetTemplates() with a broken file reported %+v", m)
+	}
+	if err := os.Remove(filepath.Join(cmd.Templates.Dir, "bad.yaml")); err != nil {
+		t.Fatal(err)
+	}
+
+	msg = DeleteTemplate("dev-copy")()
+	if m, ok := msg.(models.MessageMsg); !ok || m.MsgType != "success" {
+		t.Errorf("DeleteTemplate() = %+v", msg)
+	}
+
+	msg = DeleteTemplate("missing")()
+	if m, ok := msg.(models.MessageMsg); !ok || m.MsgType != "error" {
+		t.Errorf("DeleteTemplate() of a missing template should fail, got %+v", msg)
+	}
+}
+
+func TestKubeconfigCommands(t *testing.T) {
+	original := cmd.Commands
+	defer func() { cmd.Commands = original }()
+
+	var calls []string
+	cmd.Commands = &MockCommands{
+		ExportKubeconfigFunc: func(cluster, path string, internal bool) error {
+			calls = append(calls, fmt.Sprintf("export %s %s %v", cluster, path, internal))
+			return nil
+		},
+		MergeKubeconfigFunc: func(cluster string) error {
+			calls = append(calls, "merge "+cluster)
+			return nil
+		},
+		RemoveKubeconfigFunc: func(cluster string) error {
+			calls = append(calls, "remove "+cluster)
+			return nil
+		},
+		UseKubeContextFunc: func(cluster string) error {
+			calls = append(calls, "use "+cluster)
+			return nil
+		},
+	}
+
+	for _, c := range []tea.Cmd{
+		ExportKubeconfig("dev", "/tmp/dev.kubeconfig", true),
+		MergeKubeconfig("dev"),
+		RemoveKubeconfig("dev"),
+		UseKubeContext("dev"),
+	} {
+		if msg := c().(models.MessageMsg); msg.MsgType != "success" || !strings.Contains(msg.Text, "'dev'") {
+			t.Errorf("Unexpected message %+v", msg)
+		}
+	}
+
+	expected := []string{"export dev /tmp/dev.kubeconfig true", "merge dev", "remove dev", "use dev"}
+	if strings.Join(calls, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("calls = %v, expected %v", calls, expected)
+	}
+
+	cmd.Commands = &MockCommands{
+		UseKubeContextFunc: func(string) error { return errors.New("failed to get kubeconfig") },
+	}
+	if msg := UseKubeContext("dev")().(models.MessageMsg); msg.MsgType != "error" {
+		t.Errorf("Expected an error message, got %+v", msg)
+	}
+}
+
+func TestCopyKubeconfig(t *testing.T) {
+	original := cmd.Commands
+	defer func() { cmd.Commands = original }()
+
+	var copied string
+	cmd.Commands = &MockCommands{
+		GetKubeconfigFunc: func(cluster string, internal bool) (string, error) {
+			if !internal {
+				t.Error("CopyKubeconfig() should ask for the internal kubeconfig")
+			}
+			return "apiVersion: v1\n", nil
+		},
+		CopyToClipboardFunc: func(text string) error {
+			copied = text
+			return nil
+		},
+	}
+	msg := CopyKubeconfig("dev", true)().(models.MessageMsg)
+	if msg.MsgType != "success" || copied != "apiVersion: v1\n" {
+		t.Errorf("Unexpected message %+v, copied %q", msg, copied)
+	}
+
+	cmd.Commands = &MockCommands{
+		CopyToClipboardFunc: func(string) error { return cmd.ErrNoClipboard },
+	}
+	if msg := CopyKubeconfig("dev", false)().(models.MessageMsg); msg.MsgType != "error" || msg.Text != cmd.ErrNoClipboard.Error() {
+		t.Errorf("Expected the clipboard error, got %+v", msg)
+	}
+}
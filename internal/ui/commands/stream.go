@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ki/internal/ui/models"
+)
+
+// streamBuffer is the number of lines buffered before writers block
+const streamBuffer = 64
+
+// Stream is an io.Writer that forwards everything written to it to the UI,
+// as one ProgressMsg per line for jobs or as batches of LogLinesMsg for logs
+type Stream struct {
+	// batch is the most lines delivered in one message
+	batch int
+	msg   func(lines []string, next tea.Cmd) tea.Msg
+	done  func(result tea.Msg) tea.Msg
+
+	mu      sync.Mutex
+	lines   chan string
+	partial []byte
+	closed  bool
+}
+
+// NewStream creates a stream for the given job, ready to be passed to a
+// long-running command
+func NewStream(jobID int) *Stream {
+	return &Stream{
+		batch: 1,
+		msg: func(lines []string, next tea.Cmd) tea.Msg {
+			return models.ProgressMsg{JobID: jobID, Line: lines[0], Next: next}
+		},
+		done: func(result tea.Msg) tea.Msg {
+			return models.OperationDoneMsg{JobID: jobID, Result: result}
+		},
+		lines: make(chan string, streamBuffer),
+	}
+}
+
+// NewLogStream creates a stream for the log viewer's stream seq. Lines that
+// arrive together are delivered in one message.
+func NewLogStream(seq int) *Stream {
+	return &Stream{
+		batch: streamBuffer,
+		msg: func(lines []string, next tea.Cmd) tea.Msg {
+			return models.LogLinesMsg{Seq: seq, Lines: lines, Next: next}
+		},
+		done: func(result tea.Msg) tea.Msg {
+			return models.LogDoneMsg{Seq: seq, Result: result}
+		},
+		lines: make(chan string, streamBuffer),
+	}
+}
+
+// Write splits p into lines; an unterminated tail is kept until more output arrives
+func (s *Stream) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return len(p), nil
+	}
+
+	s.partial = append(s.partial, p...)
+	for {
+		idx := bytes.IndexAny(s.partial, "\r\n")
+		if idx < 0 {
+			break
+		}
+		s.send(string(s.partial[:idx]))
+		s.partial = s.partial[idx+1:]
+	}
+	return len(p), nil
+}
+
+// Close flushes any unterminated output and ends the stream
+func (s *Stream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.send(string(s.partial))
+	s.partial = nil
+	s.closed = true
+	close(s.lines)
+	return nil
+}
+
+func (s *Stream) send(line string) {
+	line = strings.TrimRight(line, " \t")
+	if strings.TrimSpace(line) != "" {
+		s.lines <- line
+	}
+}
+
+// Next waits for the next line of output. It returns nil once the stream is closed.
+func (s *Stream) Next() tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-s.lines
+		if !ok {
+			return nil
+		}
+		lines := []string{line}
+		for len(lines) < s.batch {
+			select {
+			case line, ok := <-s.lines:
+				if !ok {
+					return s.msg(lines, s.Next())
+				}
+				lines = append(lines, line)
+			default:
+				return s.msg(lines, s.Next())
+			}
+		}
+		return s.msg(lines, s.Next())
+	}
+}
+
+// Drain discards lines until the stream is closed, so writers do not block
+// once the UI stopped reading them
+func (s *Stream) Drain() {
+	for range s.lines {
+	}
+}
+
+// Run runs c, closes the stream when it finishes and reports its result as an
+// OperationDoneMsg or LogDoneMsg
+func (s *Stream) Run(c tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		result := c()
+		s.Close()
+		return s.done(result)
+	}
+}
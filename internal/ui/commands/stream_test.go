@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ki/internal/ui/models"
+)
+
+func TestStreamSplitsLines(t *testing.T) {
+	stream := NewStream(7)
+
+	fmt.Fprint(stream, "Creating cluster \"kind\" ...\n • Ensuring node")
+	fmt.Fprint(stream, " image ...\r\n\n ✓ Ensuring node image")
+	stream.Close()
+
+	expected := []string{
+		"Creating cluster \"kind\" ...",
+		" • Ensuring node image ...",
+		" ✓ Ensuring node image",
+	}
+
+	next := stream.Next()
+	for i, want := range expected {
+		msg := next()
+		progress, ok := msg.(models.ProgressMsg)
+		if !ok {
+			t.Fatalf("Line %d: expected ProgressMsg, got %T", i, msg)
+		}
+		if progress.JobID != 7 {
+			t.Errorf("Line %d: expected job ID 7, got %d", i, progress.JobID)
+		}
+		if progress.Line != want {
+			t.Errorf("Line %d = %q, want %q", i, progress.Line, want)
+		}
+		next = progress.Next
+	}
+
+	if msg := next(); msg != nil {
+		t.Errorf("Expected nil after the stream is closed, got %v", msg)
+	}
+}
+
+func TestStreamRun(t *testing.T) {
+	stream := NewStream(7)
+
+	inner := func() tea.Msg {
+		fmt.Fprintln(stream, "done")
+		return models.MessageMsg{Text: "ok", MsgType: "success"}
+	}
+
+	msg := stream.Run(inner)()
+	done, ok := msg.(models.OperationDoneMsg)
+	if !ok {
+		t.Fatalf("Expected OperationDoneMsg, got %T", msg)
+	}
+	if done.JobID != 7 {
+		t.Errorf("Expected job ID 7, got %d", done.JobID)
+	}
+	if result, ok := done.Result.(models.MessageMsg); !ok || result.Text != "ok" {
+		t.Errorf("Expected the inner result to be passed through, got %+v", done.Result)
+	}
+
+	// Output written before the command finished is still delivered
+	if progress, ok := stream.Next()().(models.ProgressMsg); !ok || progress.Line != "done" {
+		t.Errorf("Expected buffered output after Run, got %+v", progress)
+	}
+
+	// Writes after close are dropped rather than panicking
+	if _, err := fmt.Fprintln(stream, "late"); err != nil {
+		t.Errorf("Write after Close returned %v", err)
+	}
+}
+
+func TestLogStreamBatchesLines(t *testing.T) {
+	stream := NewLogStream(3)
+
+	fmt.Fprint(stream, "one\ntwo\nthree\n")
+	stream.Close()
+
+	msg, ok := stream.Next()().(models.LogLinesMsg)
+	if !ok {
+		t.Fatalf("Expected LogLinesMsg, got %T", msg)
+	}
+	if msg.Seq != 3 || len(msg.Lines) != 3 || msg.Lines[2] != "three" {
+		t.Errorf("Expected all buffered lines in one message, got %+v", msg)
+	}
+	if next := msg.Next(); next != nil {
+		t.Errorf("Expected nil after the stream is closed, got %v", next)
+	}
+
+	done, ok := NewLogStream(3).Run(func() tea.Msg { return nil })().(models.LogDoneMsg)
+	if !ok || done.Seq != 3 || done.Result != nil {
+		t.Errorf("Expected LogDoneMsg for stream 3, got %+v", done)
+	}
+}
+
+func TestStreamDrain(t *testing.T) {
+	stream := NewStream(1)
+	go stream.Drain()
+
+	// Without a reader, writing more lines than the buffer holds would block
+	for i := 0; i < 3*streamBuffer; i++ {
+		fmt.Fprintf(stream, "line %d\n", i)
+	}
+	stream.Close()
+}
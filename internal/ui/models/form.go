@@ -0,0 +1,270 @@
+package models
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"ki/internal/cmd"
+)
+
+// Create cluster form fields
+const (
+	FieldName = iota
+	FieldImage
+	FieldControlPlanes
+	FieldWorkers
+	FieldPortMappings
+	FieldMounts
+	FieldPodSubnet
+	FieldServiceSubnet
+	FieldFeatureGates
+	FieldRegistry
+	fieldCount
+)
+
+var formFields = []struct {
+	label       string
+	placeholder string
+}{
+	FieldName:          {"Cluster name", "kind"},
+	FieldImage:         {"Node image", "kindest/node (kind default)"},
+	FieldControlPlanes: {"Control-plane nodes", "1"},
+	FieldWorkers:       {"Worker nodes", "0"},
+	FieldPortMappings:  {"Port mappings", "80:80,443:443/tcp"},
+	FieldMounts:        {"Extra mounts", "/host/path:/container/path[:ro]"},
+	FieldPodSubnet:     {"Pod subnet", "10.244.0.0/16"},
+	FieldServiceSubnet: {"Service subnet", "10.96.0.0/12"},
+	FieldFeatureGates:  {"Feature gates", "Name=true,Other=false"},
+	FieldRegistry:      {"Local registry", "no (yes attaches " + cmd.RegistryHost + ")"},
+}
+
+// ClusterForm is a multi-field form that builds a cmd.ClusterConfig
+type ClusterForm struct {
+	Inputs []textinput.Model
+	Focus  int
+
+	// Base keeps the settings the form does not expose so they survive editing
+	Base cmd.ClusterConfig
+}
+
+// NewClusterForm creates an empty create cluster form
+func NewClusterForm() ClusterForm {
+	inputs := make([]textinput.Model, fieldCount)
+	for i := range inputs {
+		ti := textinput.New()
+		ti.Placeholder = formFields[i].placeholder
+		ti.CharLimit = 256
+		ti.Width = 50
+		inputs[i] = ti
+	}
+
+	f := ClusterForm{Inputs: inputs}
+	f.SetFocus(0)
+	return f
+}
+
+// Label returns the label of the field at index i
+func (f ClusterForm) Label(i int) string {
+	return formFields[i].label
+}
+
+// Value returns the trimmed value of the field at index i
+func (f ClusterForm) Value(i int) string {
+	return strings.TrimSpace(f.Inputs[i].Value())
+}
+
+// SetFocus focuses the field at index i and blurs all others
+func (f *ClusterForm) SetFocus(i int) {
+	f.Focus = (i + len(f.Inputs)) % len(f.Inputs)
+	for j := range f.Inputs {
+		if j == f.Focus {
+			f.Inputs[j].Focus()
+		} else {
+			f.Inputs[j].Blur()
+		}
+	}
+}
+
+// Next moves focus to the next field
+func (f *ClusterForm) Next() {
+	f.SetFocus(f.Focus + 1)
+}
+
+// Prev moves focus to the previous field
+func (f *ClusterForm) Prev() {
+	f.SetFocus(f.Focus - 1)
+}
+
+// Reset clears all fields
+func (f *ClusterForm) Reset() {
+	_ = f.SetConfig(cmd.ClusterConfig{})
+}
+
+// Update forwards the message to the focused field
+func (f ClusterForm) Update(msg tea.Msg) (ClusterForm, tea.Cmd) {
+	var c tea.Cmd
+	f.Inputs[f.Focus], c = f.Inputs[f.Focus].Update(msg)
+	return f, c
+}
+
+// SetConfig fills the form from an existing config. A config the form cannot
+// represent without losing settings is refused and the form is left as is.
+func (f *ClusterForm) SetConfig(c cmd.ClusterConfig) error {
+	if err := checkFormConfig(c); err != nil {
+		return err
+	}
+	f.Base = c
+
+	values := make([]string, fieldCount)
+	values[FieldName] = c.Name
+	if len(c.Nodes) > 0 {
+		values[FieldImage] = c.Nodes[0].Image
+		values[FieldControlPlanes] = strconv.Itoa(c.CountNodes(cmd.RoleControlPlane))
+		values[FieldWorkers] = strconv.Itoa(c.CountNodes(cmd.RoleWorker))
+		values[FieldMounts] = cmd.FormatMounts(c.Nodes[0].ExtraMounts)
+		for _, node := range c.Nodes {
+			if node.Role == cmd.RoleControlPlane {
+				values[FieldPortMappings] = cmd.FormatPortMappings(node.ExtraPortMappings)
+				break
+			}
+		}
+	}
+	values[FieldPodSubnet] = c.Networking.PodSubnet
+	values[FieldServiceSubnet] = c.Networking.ServiceSubnet
+	values[FieldFeatureGates] = cmd.FormatFeatureGates(c.FeatureGates)
+	if c.Registry {
+		values[FieldRegistry] = "yes"
+	}
+
+	for i, v := range values {
+		f.Inputs[i].SetValue(v)
+	}
+	f.SetFocus(0)
+	return nil
+}
+
+// checkFormConfig makes sure the form can hold a config: it has a single node
+// image and a single set of mounts for every node, and port mappings only on
+// the first control-plane node
+func checkFormConfig(c cmd.ClusterConfig) error {
+	firstControlPlane := true
+	for _, node := range c.Nodes {
+		if node.Image != c.Nodes[0].Image {
+			return fmt.Errorf("nodes use different images")
+		}
+		if !reflect.DeepEqual(node.ExtraMounts, c.Nodes[0].ExtraMounts) {
+			return fmt.Errorf("nodes have different extra mounts")
+		}
+		if len(node.ExtraPortMappings) > 0 && !(node.Role == cmd.RoleControlPlane && firstControlPlane) {
+			return fmt.Errorf("port mappings are set beyond the first control-plane node")
+		}
+		if node.Role == cmd.RoleControlPlane {
+			firstControlPlane = false
+		}
+	}
+	return nil
+}
+
+// Config builds the cluster config described by the form
+func (f ClusterForm) Config() (cmd.ClusterConfig, error) {
+	config := f.Base
+	config.Name = f.Value(FieldName)
+	if config.Name == "" {
+		config.Name = "kind"
+	}
+
+	controlPlanes, err := f.count(FieldControlPlanes, 1)
+	if err != nil {
+		return config, err
+	}
+	if controlPlanes < 1 {
+		return config, fmt.Errorf("at least one control-plane node is required")
+	}
+	workers, err := f.count(FieldWorkers, 0)
+	if err != nil {
+		return config, err
+	}
+	portMappings, err := cmd.ParsePortMappings(f.Value(FieldPortMappings))
+	if err != nil {
+		return config, err
+	}
+	mounts, err := cmd.ParseMounts(f.Value(FieldMounts))
+	if err != nil {
+		return config, err
+	}
+	featureGates, err := cmd.ParseFeatureGates(f.Value(FieldFeatureGates))
+	if err != nil {
+		return config, err
+	}
+	registry, err := f.yesNo(FieldRegistry)
+	if err != nil {
+		return config, err
+	}
+
+	// Rebuild the node list, reusing per-node settings from the base config
+	image := f.Value(FieldImage)
+	config.Nodes = nil
+	for _, role := range []string{cmd.RoleControlPlane, cmd.RoleWorker} {
+		count := controlPlanes
+		if role == cmd.RoleWorker {
+			count = workers
+		}
+		base := baseNodes(f.Base, role)
+		for i := 0; i < count; i++ {
+			node := cmd.NodeConfig{Role: role}
+			if i < len(base) {
+				node = base[i]
+			}
+			node.Image = image
+			node.ExtraMounts = mounts
+			node.ExtraPortMappings = nil
+			if role == cmd.RoleControlPlane && i == 0 {
+				node.ExtraPortMappings = portMappings
+			}
+			config.Nodes = append(config.Nodes, node)
+		}
+	}
+
+	config.Networking.PodSubnet = f.Value(FieldPodSubnet)
+	config.Networking.ServiceSubnet = f.Value(FieldServiceSubnet)
+	config.FeatureGates = featureGates
+	config.Registry = registry
+
+	return config, config.Validate()
+}
+
+func (f ClusterForm) count(field, fallback int) (int, error) {
+	value := f.Value(field)
+	if value == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative number", strings.ToLower(f.Label(field)))
+	}
+	return n, nil
+}
+
+func (f ClusterForm) yesNo(field int) (bool, error) {
+	switch strings.ToLower(f.Value(field)) {
+	case "", "n", "no", "false":
+		return false, nil
+	case "y", "yes", "true":
+		return true, nil
+	}
+	return false, fmt.Errorf("%s must be yes or no", strings.ToLower(f.Label(field)))
+}
+
+func baseNodes(c cmd.ClusterConfig, role string) []cmd.NodeConfig {
+	var nodes []cmd.NodeConfig
+	for _, node := range c.Nodes {
+		if node.Role == role {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,176 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"ki/internal/cmd"
+)
+
+func TestClusterFormConfig(t *testing.T) {
+	form := NewClusterForm()
+	form.Inputs[FieldName].SetValue("dev")
+	form.Inputs[FieldImage].SetValue("kindest/node:v1.29.2")
+	form.Inputs[FieldControlPlanes].SetValue("1")
+	form.Inputs[FieldWorkers].SetValue("2")
+	form.Inputs[FieldPortMappings].SetValue("80:80")
+	form.Inputs[FieldMounts].SetValue("/src:/src")
+	form.Inputs[FieldRegistry].SetValue("yes")
+
+	config, err := form.Config()
+	if err != nil {
+		t.Fatalf("Config() error = %v", err)
+	}
+
+	if config.Name != "dev" {
+		t.Errorf("Expected name 'dev', got %s", config.Name)
+	}
+	if !config.Registry {
+		t.Error("Expected the local registry to be enabled")
+	}
+	if len(config.Nodes) != 3 {
+		t.Fatalf("Expected 3 nodes, got %d", len(config.Nodes))
+	}
+	if len(config.Nodes[0].ExtraPortMappings) != 1 {
+		t.Error("Expected port mappings on the first control-plane node")
+	}
+	if len(config.Nodes[1].ExtraPortMappings) != 0 {
+		t.Error("Expected no port mappings on worker nodes")
+	}
+	for i, node := range config.Nodes {
+		if node.Image != "kindest/node:v1.29.2" {
+			t.Errorf("Node[%d].Image = %s", i, node.Image)
+		}
+		if len(node.ExtraMounts) != 1 {
+			t.Errorf("Node[%d] expected 1 mount, got %d", i, len(node.ExtraMounts))
+		}
+	}
+}
+
+func TestClusterFormDefaults(t *testing.T) {
+	config, err := NewClusterForm().Config()
+	if err != nil {
+		t.Fatalf("Config() error = %v", err)
+	}
+	if config.Name != "kind" {
+		t.Errorf("Expected default name 'kind', got %s", config.Name)
+	}
+	if len(config.Nodes) != 1 || config.Nodes[0].Role != cmd.RoleControlPlane {
+		t.Errorf("Expected a single control-plane node, got %+v", config.Nodes)
+	}
+}
+
+func TestClusterFormErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		field int
+		value string
+	}{
+		{name: "no control-plane", field: FieldControlPlanes, value: "0"},
+		{name: "invalid worker count", field: FieldWorkers, value: "many"},
+		{name: "invalid port mapping", field: FieldPortMappings, value: "80"},
+		{name: "invalid mount", field: FieldMounts, value: "/only"},
+		{name: "invalid feature gate", field: FieldFeatureGates, value: "Foo"},
+		{name: "invalid registry choice", field: FieldRegistry, value: "maybe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := NewClusterForm()
+			form.Inputs[tt.field].SetValue(tt.value)
+			if _, err := form.Config(); err == nil {
+				t.Error("Config() should return an error")
+			}
+		})
+	}
+}
+
+func TestClusterFormSetConfigPreservesBase(t *testing.T) {
+	base := cmd.NewClusterConfig("ha", 3, 1, "")
+	base.Nodes[0].ExtraPortMappings = []cmd.PortMapping{{HostPort: 443, ContainerPort: 443}}
+	base.Nodes[3].Labels = map[string]string{"tier": "edge"}
+	base.ContainerdConfigPatches = []string{"patch"}
+
+	form := NewClusterForm()
+	if err := form.SetConfig(base); err != nil {
+		t.Fatalf("SetConfig() error = %v", err)
+	}
+
+	if form.Value(FieldControlPlanes) != "3" || form.Value(FieldWorkers) != "1" {
+		t.Errorf("Node counts not filled: %s/%s", form.Value(FieldControlPlanes), form.Value(FieldWorkers))
+	}
+	if form.Value(FieldPortMappings) != "443:443" {
+		t.Errorf("Port mappings not filled: %s", form.Value(FieldPortMappings))
+	}
+
+	config, err := form.Config()
+	if err != nil {
+		t.Fatalf("Config() error = %v", err)
+	}
+	if len(config.ContainerdConfigPatches) != 1 {
+		t.Error("Containerd patches should be kept from the base config")
+	}
+	if config.Nodes[3].Labels["tier"] != "edge" {
+		t.Error("Worker labels should be kept from the base config")
+	}
+}
+
+func TestClusterFormSetConfigRefusesPerNodeSettings(t *testing.T) {
+	mounts := cmd.NewClusterConfig("dev", 1, 1, "")
+	mounts.Nodes[1].ExtraMounts = []cmd.Mount{{HostPath: "/data", ContainerPath: "/data"}}
+
+	ports := cmd.NewClusterConfig("dev", 1, 1, "")
+	ports.Nodes[1].ExtraPortMappings = []cmd.PortMapping{{HostPort: 80, ContainerPort: 80}}
+
+	images := cmd.NewClusterConfig("dev", 1, 1, "kindest/node:v1.30.0")
+	images.Nodes[1].Image = "kindest/node:v1.31.0"
+
+	for name, config := range map[string]cmd.ClusterConfig{"mounts": mounts, "ports": ports, "images": images} {
+		form := NewClusterForm()
+		form.Inputs[FieldName].SetValue("kept")
+		if err := form.SetConfig(config); err == nil {
+			t.Errorf("SetConfig() with per-node %s should fail", name)
+		}
+		if form.Value(FieldName) != "kept" {
+			t.Errorf("SetConfig() with per-node %s changed the form", name)
+		}
+	}
+
+	// The same mounts on every node and a listen address round trip intact
+	shared := cmd.NewClusterConfig("dev", 1, 2, "")
+	for i := range shared.Nodes {
+		shared.Nodes[i].ExtraMounts = []cmd.Mount{{HostPath: "/data", ContainerPath: "/data", ReadOnly: true}}
+	}
+	shared.Nodes[0].ExtraPortMappings = []cmd.PortMapping{{HostPort: 8080, ContainerPort: 80, ListenAddress: "127.0.0.1"}}
+
+	form := NewClusterForm()
+	if err := form.SetConfig(shared); err != nil {
+		t.Fatalf("SetConfig() error = %v", err)
+	}
+	config, err := form.Config()
+	if err != nil {
+		t.Fatalf("Config() error = %v", err)
+	}
+	if !reflect.DeepEqual(config.Nodes, shared.Nodes) {
+		t.Errorf("Config().Nodes = %+v, expected %+v", config.Nodes, shared.Nodes)
+	}
+}
+
+func TestClusterFormFocus(t *testing.T) {
+	form := NewClusterForm()
+	if form.Focus != 0 {
+		t.Fatalf("Expected initial focus 0, got %d", form.Focus)
+	}
+
+	form.Prev()
+	if form.Focus != len(form.Inputs)-1 {
+		t.Errorf("Prev() should wrap to the last field, got %d", form.Focus)
+	}
+	form.Next()
+	if form.Focus != 0 {
+		t.Errorf("Next() should wrap to the first field, got %d", form.Focus)
+	}
+	if !form.Inputs[0].Focused() || form.Inputs[1].Focused() {
+		t.Error("Only the focused field should have focus")
+	}
+}
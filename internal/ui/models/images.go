@@ -0,0 +1,247 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"ki/internal/cmd"
+)
+
+// ImagePicker holds the state of the image picker that loads local images into a cluster
+type ImagePicker struct {
+	Cluster string // empty loads into the default cluster
+	Images  []cmd.Image
+	Err     error
+	Loaded  bool
+
+	Filter    textinput.Model
+	Filtering bool
+	Cursor    int
+	// Selected holds the refs of the images marked for loading
+	Selected map[string]bool
+
+	// Nodes are the nodes of the cluster that loading can be limited to
+	Nodes        []string
+	Targets      map[string]bool
+	PickingNodes bool
+	NodeCursor   int
+
+	// ReturnTo is the view esc goes back to
+	ReturnTo ViewMode
+}
+
+// NewImagePicker creates an empty image picker
+func NewImagePicker() ImagePicker {
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "filter by repository, tag or ID"
+	filter.CharLimit = 100
+	filter.Width = 40
+	return ImagePicker{Filter: filter, Selected: map[string]bool{}, Targets: map[string]bool{}}
+}
+
+// Open resets the picker for loading into cluster
+func (p *ImagePicker) Open(cluster string, returnTo ViewMode) {
+	p.Cluster = cluster
+	p.Images = nil
+	p.Err = nil
+	p.Loaded = false
+	p.Filter.SetValue("")
+	p.Filter.Blur()
+	p.Filtering = false
+	p.Cursor = 0
+	p.Selected = map[string]bool{}
+	p.Nodes = nil
+	p.Targets = map[string]bool{}
+	p.PickingNodes = false
+	p.NodeCursor = 0
+	p.ReturnTo = returnTo
+}
+
+// SetNodes sets the nodes loading can be limited to
+func (p *ImagePicker) SetNodes(nodes []cmd.Node) {
+	p.Nodes = make([]string, len(nodes))
+	for i, node := range nodes {
+		p.Nodes[i] = node.Name
+	}
+}
+
+// MoveNodeCursor moves the cursor of the node list by delta rows
+func (p *ImagePicker) MoveNodeCursor(delta int) {
+	p.NodeCursor = max(min(p.NodeCursor+delta, len(p.Nodes)-1), 0)
+}
+
+// ToggleTarget adds or removes the node under the node cursor from the targets
+func (p *ImagePicker) ToggleTarget() {
+	if p.NodeCursor < 0 || p.NodeCursor >= len(p.Nodes) {
+		return
+	}
+	node := p.Nodes[p.NodeCursor]
+	if p.Targets[node] {
+		delete(p.Targets, node)
+	} else {
+		p.Targets[node] = true
+	}
+}
+
+// TargetNodes returns the nodes to load into in cluster order. Nil loads into
+// every node.
+func (p ImagePicker) TargetNodes() []string {
+	var nodes []string
+	for _, node := range p.Nodes {
+		if p.Targets[node] {
+			nodes = append(nodes, node)
+		}
+	}
+	// Picking every node is the same as the default
+	if len(nodes) == len(p.Nodes) {
+		return nil
+	}
+	return nodes
+}
+
+// SetImages replaces the images after they were listed
+func (p *ImagePicker) SetImages(images []cmd.Image, err error) {
+	p.Err = err
+	p.Loaded = true
+	if err == nil {
+		p.Images = images
+	}
+	p.clampCursor()
+}
+
+// MoveCursor moves the cursor by delta rows within the visible images
+func (p *ImagePicker) MoveCursor(delta int) {
+	p.Cursor += delta
+	p.clampCursor()
+}
+
+// Current returns the image under the cursor, or nil when there is none
+func (p ImagePicker) Current() *cmd.Image {
+	visible := p.Visible()
+	if p.Cursor < 0 || p.Cursor >= len(visible) {
+		return nil
+	}
+	return &visible[p.Cursor]
+}
+
+// Toggle marks or unmarks the image under the cursor
+func (p *ImagePicker) Toggle() {
+	image := p.Current()
+	if image == nil {
+		return
+	}
+	ref := image.Ref()
+	if p.Selected[ref] {
+		delete(p.Selected, ref)
+	} else {
+		p.Selected[ref] = true
+	}
+}
+
+// Refs returns the images to load: the marked ones in list order, or the one
+// under the cursor when none are marked
+func (p ImagePicker) Refs() []string {
+	var refs []string
+	for _, image := range p.Images {
+		if ref := image.Ref(); p.Selected[ref] {
+			refs = append(refs, ref)
+		}
+	}
+	if len(refs) == 0 {
+		if image := p.Current(); image != nil {
+			refs = append(refs, image.Ref())
+		}
+	}
+	return refs
+}
+
+// Visible returns the images matching the filter
+func (p ImagePicker) Visible() []cmd.Image {
+	filter := strings.ToLower(strings.TrimSpace(p.Filter.Value()))
+	if filter == "" {
+		return p.Images
+	}
+
+	visible := make([]cmd.Image, 0, len(p.Images))
+	for _, image := range p.Images {
+		if imageMatches(image, filter) {
+			visible = append(visible, image)
+		}
+	}
+	return visible
+}
+
+func (p *ImagePicker) clampCursor() {
+	if n := len(p.Visible()); p.Cursor >= n {
+		p.Cursor = n - 1
+	}
+	if p.Cursor < 0 {
+		p.Cursor = 0
+	}
+}
+
+func imageMatches(image cmd.Image, filter string) bool {
+	for _, field := range []string{image.Repository, image.Tag, image.ID} {
+		if strings.Contains(strings.ToLower(field), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClusterImageBrowser holds the images found on the nodes of a cluster
+type ClusterImageBrowser struct {
+	Cluster string
+	Images  []cmd.ClusterImage
+	Err     error
+	Loaded  bool
+	Cursor  int
+
+	// ReturnTo is the view esc goes back to
+	ReturnTo ViewMode
+}
+
+// Open resets the browser for cluster
+func (b *ClusterImageBrowser) Open(cluster string, returnTo ViewMode) {
+	*b = ClusterImageBrowser{Cluster: cluster, ReturnTo: returnTo}
+}
+
+// SetImages replaces the images after they were compared. Results for another
+// cluster are ignored.
+func (b *ClusterImageBrowser) SetImages(cluster string, images []cmd.ClusterImage, err error) {
+	if cluster != b.Cluster {
+		return
+	}
+	b.Err = err
+	b.Loaded = true
+	if err == nil {
+		b.Images = images
+	}
+	b.MoveCursor(0)
+}
+
+// MoveCursor moves the cursor by delta rows
+func (b *ClusterImageBrowser) MoveCursor(delta int) {
+	b.Cursor = max(min(b.Cursor+delta, len(b.Images)-1), 0)
+}
+
+// Current returns the image under the cursor, or nil when there is none
+func (b ClusterImageBrowser) Current() *cmd.ClusterImage {
+	if b.Cursor < 0 || b.Cursor >= len(b.Images) {
+		return nil
+	}
+	return &b.Images[b.Cursor]
+}
+
+// Outdated returns the local refs of the images the cluster has a stale or
+// partial copy of
+func (b ClusterImageBrowser) Outdated() []string {
+	var refs []string
+	for _, image := range b.Images {
+		if image.Outdated() && image.LocalRef != "" {
+			refs = append(refs, image.LocalRef)
+		}
+	}
+	return refs
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"ki/internal/cmd"
+)
+
+func testImages() []cmd.Image {
+	return []cmd.Image{
+		{Repository: "nginx", Tag: "1.27", ID: "a1b2c3"},
+		{Repository: "myapp", Tag: "dev", ID: "d4e5f6"},
+		{Repository: "redis", Tag: "7", ID: "0718ab"},
+	}
+}
+
+func TestImagePickerRefs(t *testing.T) {
+	p := NewImagePicker()
+	p.Open("dev", ClusterListView)
+	p.SetImages(testImages(), nil)
+
+	// Without marks the image under the cursor is loaded
+	p.MoveCursor(1)
+	if refs := p.Refs(); !equal(refs, []string{"myapp:dev"}) {
+		t.Errorf("Refs() = %v, expected the image under the cursor", refs)
+	}
+
+	// Marked images are loaded in list order
+	p.Toggle()
+	p.MoveCursor(-1)
+	p.Toggle()
+	if refs := p.Refs(); !equal(refs, []string{"nginx:1.27", "myapp:dev"}) {
+		t.Errorf("Refs() = %v, expected the marked images", refs)
+	}
+
+	p.Toggle()
+	if refs := p.Refs(); !equal(refs, []string{"myapp:dev"}) {
+		t.Errorf("Refs() after unmarking = %v", refs)
+	}
+}
+
+func TestImagePickerFilter(t *testing.T) {
+	p := NewImagePicker()
+	p.Open("", MainMenuView)
+	p.SetImages(testImages(), nil)
+	p.MoveCursor(2)
+
+	p.Filter.SetValue("NGI")
+	p.MoveCursor(0)
+	if visible := p.Visible(); len(visible) != 1 || visible[0].Repository != "nginx" {
+		t.Errorf("Visible() = %v, expected only nginx", visible)
+	}
+	if current := p.Current(); current == nil || current.Repository != "nginx" {
+		t.Errorf("Current() should stay within the filtered images, got %v", current)
+	}
+
+	p.Filter.SetValue("0718")
+	if visible := p.Visible(); len(visible) != 1 || visible[0].Repository != "redis" {
+		t.Errorf("Visible() should match image IDs, got %v", visible)
+	}
+}
+
+func TestImagePickerOpenResets(t *testing.T) {
+	p := NewImagePicker()
+	p.Open("dev", ClusterListView)
+	p.SetImages(testImages(), nil)
+	p.Toggle()
+
+	p.Open("test", MainMenuView)
+	if p.Loaded || len(p.Images) != 0 || len(p.Selected) != 0 || p.Cluster != "test" {
+		t.Errorf("Open() should reset the picker, got %+v", p)
+	}
+	if refs := p.Refs(); len(refs) != 0 {
+		t.Errorf("Refs() of an empty picker = %v", refs)
+	}
+}
+
+func TestImagePickerTargets(t *testing.T) {
+	p := NewImagePicker()
+	p.Open("dev", ClusterListView)
+	p.SetNodes([]cmd.Node{{Name: "dev-control-plane"}, {Name: "dev-worker"}, {Name: "dev-worker2"}})
+
+	if nodes := p.TargetNodes(); nodes != nil {
+		t.Errorf("TargetNodes() without picked nodes = %v, expected every node", nodes)
+	}
+
+	p.MoveNodeCursor(2)
+	p.ToggleTarget()
+	p.MoveNodeCursor(-1)
+	p.ToggleTarget()
+	if nodes := p.TargetNodes(); !equal(nodes, []string{"dev-worker", "dev-worker2"}) {
+		t.Errorf("TargetNodes() = %v, expected the workers in cluster order", nodes)
+	}
+
+	p.MoveNodeCursor(-5)
+	p.ToggleTarget()
+	if nodes := p.TargetNodes(); nodes != nil {
+		t.Errorf("TargetNodes() with every node picked = %v, expected the default", nodes)
+	}
+
+	p.Open("test", ClusterListView)
+	if len(p.Nodes) != 0 || len(p.Targets) != 0 || p.TargetNodes() != nil {
+		t.Errorf("Open() should reset the nodes, got %+v", p)
+	}
+}
+
+func TestClusterImageBrowser(t *testing.T) {
+	b := ClusterImageBrowser{}
+	b.Open("dev", ClusterDetailView)
+	if b.Current() != nil || len(b.Outdated()) != 0 {
+		t.Errorf("an empty browser should have no images, got %+v", b)
+	}
+
+	images := []cmd.ClusterImage{
+		{Ref: "docker.io/library/myapp:dev", LocalRef: "myapp:dev", Status: cmd.ImageStale},
+		{Ref: "docker.io/library/nginx:1.27", LocalRef: "nginx:1.27", Status: cmd.ImageCurrent},
+		{Ref: "docker.io/library/redis:7", LocalRef: "redis:7", NodeIDs: map[string]string{"dev-control-plane": "sha256:redis"},
+			Missing: []string{"dev-worker"}, Status: cmd.ImageMissing},
+		{Ref: "docker.io/library/unloaded:latest", LocalRef: "unloaded:latest",
+			Missing: []string{"dev-control-plane", "dev-worker"}, Status: cmd.ImageMissing},
+		{Ref: "registry.k8s.io/pause:3.9", Status: cmd.ImageNotLocal},
+	}
+
+	// Results of another cluster arrive late and are dropped
+	b.SetImages("test", images, nil)
+	if b.Loaded {
+		t.Error("SetImages() should ignore images of another cluster")
+	}
+
+	b.SetImages("dev", images, nil)
+	b.MoveCursor(10)
+	if current := b.Current(); current == nil || current.Ref != "registry.k8s.io/pause:3.9" {
+		t.Errorf("MoveCursor() should stop at the last image, got %v", current)
+	}
+	if refs := b.Outdated(); !equal(refs, []string{"myapp:dev", "redis:7"}) {
+		t.Errorf("Outdated() = %v, expected the stale and partly missing images", refs)
+	}
+
+	// A failed refresh keeps the previous images
+	b.SetImages("dev", nil, errors.New("cluster not running"))
+	if b.Err == nil || len(b.Images) != 5 {
+		t.Errorf("SetImages() with an error = %+v", b)
+	}
+}
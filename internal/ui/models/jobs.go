@@ -0,0 +1,71 @@
+package models
+
+// maxFinishedJobs caps how many finished jobs are kept for the jobs view
+const maxFinishedJobs = 50
+
+// Jobs tracks every operation started from the UI, running or finished
+type Jobs struct {
+	list   []*Operation
+	nextID int
+}
+
+// Start registers a new running operation and returns it
+func (j *Jobs) Start(jobType, cluster, title string) *Operation {
+	j.nextID++
+	op := NewOperation(title)
+	op.ID = j.nextID
+	op.Type = jobType
+	op.Cluster = cluster
+
+	j.list = append(j.list, op)
+	j.prune()
+	return op
+}
+
+// Get returns the job with the given ID, or nil if it is unknown
+func (j *Jobs) Get(id int) *Operation {
+	for _, op := range j.list {
+		if op.ID == id {
+			return op
+		}
+	}
+	return nil
+}
+
+// All returns every job, newest first
+func (j *Jobs) All() []*Operation {
+	all := make([]*Operation, len(j.list))
+	for i, op := range j.list {
+		all[len(j.list)-1-i] = op
+	}
+	return all
+}
+
+// Running returns the number of jobs that have not finished yet
+func (j *Jobs) Running() int {
+	running := 0
+	for _, op := range j.list {
+		if !op.Done {
+			running++
+		}
+	}
+	return running
+}
+
+// prune drops the oldest finished jobs once there are more than maxFinishedJobs
+func (j *Jobs) prune() {
+	finished := len(j.list) - j.Running()
+	if finished <= maxFinishedJobs {
+		return
+	}
+
+	kept := make([]*Operation, 0, len(j.list))
+	for _, op := range j.list {
+		if op.Done && finished > maxFinishedJobs {
+			finished--
+			continue
+		}
+		kept = append(kept, op)
+	}
+	j.list = kept
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestJobsStart(t *testing.T) {
+	var jobs Jobs
+	first := jobs.Start("create", "dev", "Creating cluster 'dev'")
+	second := jobs.Start("load", "dev", "Loading image 'nginx'")
+
+	if first.ID != 1 || second.ID != 2 {
+		t.Errorf("Expected sequential IDs 1 and 2, got %d and %d", first.ID, second.ID)
+	}
+	if first.Type != "create" || first.Cluster != "dev" {
+		t.Errorf("Start() should record type and cluster, got %q/%q", first.Type, first.Cluster)
+	}
+	if jobs.Get(2) != second {
+		t.Error("Get(2) should return the second job")
+	}
+	if jobs.Get(3) != nil {
+		t.Error("Get() should return nil for unknown IDs")
+	}
+
+	all := jobs.All()
+	if len(all) != 2 || all[0] != second || all[1] != first {
+		t.Error("All() should return jobs newest first")
+	}
+}
+
+func TestJobsRunning(t *testing.T) {
+	var jobs Jobs
+	jobs.Start("create", "a", "a")
+	b := jobs.Start("create", "b", "b")
+
+	if jobs.Running() != 2 {
+		t.Errorf("Expected 2 running jobs, got %d", jobs.Running())
+	}
+	b.Finish(false)
+	if jobs.Running() != 1 {
+		t.Errorf("Expected 1 running job, got %d", jobs.Running())
+	}
+}
+
+func TestJobsPrune(t *testing.T) {
+	var jobs Jobs
+	running := jobs.Start("create", "keep", "still running")
+	for i := 0; i < maxFinishedJobs+10; i++ {
+		jobs.Start("load", "", fmt.Sprintf("load %d", i)).Finish(false)
+	}
+	jobs.Start("load", "", "trigger prune")
+
+	if jobs.Get(running.ID) == nil {
+		t.Error("Pruning should never drop running jobs")
+	}
+	if finished := len(jobs.All()) - jobs.Running(); finished > maxFinishedJobs {
+		t.Errorf("Expected at most %d finished jobs, got %d", maxFinishedJobs, finished)
+	}
+	if jobs.Get(2) != nil {
+		t.Error("Pruning should drop the oldest finished jobs first")
+	}
+}
+
+func TestOperationStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		finish   func(*Operation)
+		expected string
+	}{
+		{"running", func(*Operation) {}, JobRunning},
+		{"succeeded", func(op *Operation) { op.Finish(false) }, JobSucceeded},
+		{"failed", func(op *Operation) { op.Finish(true) }, JobFailed},
+		{"cancelled", func(op *Operation) { op.Abort(); op.Finish(true) }, JobCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := NewOperation("test")
+			tt.finish(op)
+			if op.Status() != tt.expected {
+				t.Errorf("Status() = %q, expected %q", op.Status(), tt.expected)
+			}
+		})
+	}
+}
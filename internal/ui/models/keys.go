@@ -6,25 +6,55 @@ import (
 
 // KeyMap defines all keyboard shortcuts
 type KeyMap struct {
-	Up      key.Binding
-	Down    key.Binding
-	Left    key.Binding
-	Right   key.Binding
-	Enter   key.Binding
-	Back    key.Binding
-	Quit    key.Binding
-	Help    key.Binding
-	Create  key.Binding
-	Delete  key.Binding
-	Refresh key.Binding
-	Load    key.Binding
-	Build   key.Binding
-	Logs    key.Binding
-	Nodes   key.Binding
-	Detail  key.Binding
-	Yes     key.Binding
-	No      key.Binding
-	Tab     key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	Left       key.Binding
+	Right      key.Binding
+	Enter      key.Binding
+	Back       key.Binding
+	Quit       key.Binding
+	Help       key.Binding
+	Create     key.Binding
+	Delete     key.Binding
+	Refresh    key.Binding
+	Load       key.Binding
+	Build      key.Binding
+	Logs       key.Binding
+	Nodes      key.Binding
+	Detail     key.Binding
+	Yes        key.Binding
+	No         key.Binding
+	Tab        key.Binding
+	Edit       key.Binding
+	Clone      key.Binding
+	Preview    key.Binding
+	Save       key.Binding
+	Cancel     key.Binding
+	Jobs       key.Binding
+	Stop       key.Binding
+	Start      key.Binding
+	Restart    key.Binding
+	Pods       key.Binding
+	Namespace  key.Binding
+	Filter     key.Binding
+	Sort       key.Binding
+	Follow     key.Binding
+	Wrap       key.Binding
+	Previous   key.Binding
+	Tail       key.Binding
+	Since      key.Binding
+	NextMatch  key.Binding
+	PrevMatch  key.Binding
+	Shell      key.Binding
+	Forward    key.Binding
+	Registry   key.Binding
+	Select     key.Binding
+	Manual     key.Binding
+	Images     key.Binding
+	Archive    key.Binding
+	Kubeconfig key.Binding
+	AddWorker  key.Binding
+	Remove     key.Binding
 }
 
 var Keys = KeyMap{
@@ -104,6 +134,126 @@ var Keys = KeyMap{
 		key.WithKeys("tab"),
 		key.WithHelp("tab", "switch"),
 	),
+	Edit: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit template"),
+	),
+	Clone: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "clone template"),
+	),
+	Preview: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "preview template"),
+	),
+	Save: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("ctrl+s", "save as template"),
+	),
+	Cancel: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "cancel operation"),
+	),
+	Jobs: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "jobs"),
+	),
+	Stop: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "stop cluster"),
+	),
+	Start: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "start cluster"),
+	),
+	Restart: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "restart cluster"),
+	),
+	Pods: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "pods"),
+	),
+	Namespace: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "next namespace"),
+	),
+	Filter: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "filter"),
+	),
+	Sort: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "sort order"),
+	),
+	Follow: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "follow log"),
+	),
+	Wrap: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "wrap lines"),
+	),
+	Previous: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "previous container"),
+	),
+	Tail: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "tail lines"),
+	),
+	Since: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "since"),
+	),
+	NextMatch: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next match"),
+	),
+	PrevMatch: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "previous match"),
+	),
+	Shell: key.NewBinding(
+		key.WithKeys("!"),
+		key.WithHelp("!", "open shell"),
+	),
+	Forward: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "port forward"),
+	),
+	Registry: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("g", "attach/detach registry"),
+	),
+	Select: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "select"),
+	),
+	Manual: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "enter image name"),
+	),
+	Images: key.NewBinding(
+		key.WithKeys("I"),
+		key.WithHelp("I", "cluster images"),
+	),
+	Archive: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "load archive"),
+	),
+	Kubeconfig: key.NewBinding(
+		key.WithKeys("K"),
+		key.WithHelp("K", "kubeconfig"),
+	),
+	AddWorker: key.NewBinding(
+		key.WithKeys("+"),
+		key.WithHelp("+", "add worker"),
+	),
+	Remove: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "remove worker"),
+	),
 }
 
 func (k KeyMap) ShortHelp() []key.Binding {
@@ -114,7 +264,11 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right, k.Enter},
 		{k.Create, k.Delete, k.Refresh},
-		{k.Load, k.Build, k.Logs},
-		{k.Nodes, k.Detail, k.Back, k.Quit},
+		{k.Load, k.Build, k.Logs, k.Select, k.Manual, k.Images, k.Archive},
+		{k.Nodes, k.Detail, k.Jobs, k.Back, k.Quit},
+		{k.Preview, k.Edit, k.Clone, k.Save, k.Cancel},
+		{k.Stop, k.Start, k.Restart, k.Registry, k.Kubeconfig, k.AddWorker, k.Remove},
+		{k.Pods, k.Namespace, k.Filter, k.Sort, k.Shell, k.Forward},
+		{k.Follow, k.Wrap, k.Previous, k.Tail, k.Since, k.NextMatch, k.PrevMatch},
 	}
-}
\ No newline at end of file
+}
@@ -99,8 +99,8 @@ func TestKeyMapHelpers(t *testing.T) {
 
 	t.Run("FullHelp", func(t *testing.T) {
 		fullHelp := Keys.FullHelp()
-		if len(fullHelp) != 4 {
-			t.Errorf("Expected 4 groups in full help, got %d", len(fullHelp))
+		if len(fullHelp) != 8 {
+			t.Errorf("Expected 8 groups in full help, got %d", len(fullHelp))
 		}
 
 		// First group should have navigation keys
@@ -118,7 +118,7 @@ func TestKeyMapHelpers(t *testing.T) {
 func TestKeyConflicts(t *testing.T) {
 	// Check for conflicting key bindings
 	keyMap := make(map[string]string)
-	
+
 	bindings := []struct {
 		name    string
 		binding key.Binding
@@ -142,6 +142,36 @@ func TestKeyConflicts(t *testing.T) {
 		{"Yes", Keys.Yes},
 		{"No", Keys.No},
 		{"Tab", Keys.Tab},
+		{"Edit", Keys.Edit},
+		{"Clone", Keys.Clone},
+		{"Preview", Keys.Preview},
+		{"Save", Keys.Save},
+		{"Cancel", Keys.Cancel},
+		{"Jobs", Keys.Jobs},
+		{"Stop", Keys.Stop},
+		{"Start", Keys.Start},
+		{"Restart", Keys.Restart},
+		{"Pods", Keys.Pods},
+		{"Namespace", Keys.Namespace},
+		{"Filter", Keys.Filter},
+		{"Sort", Keys.Sort},
+		{"Follow", Keys.Follow},
+		{"Wrap", Keys.Wrap},
+		{"Previous", Keys.Previous},
+		{"Tail", Keys.Tail},
+		{"Since", Keys.Since},
+		{"NextMatch", Keys.NextMatch},
+		{"PrevMatch", Keys.PrevMatch},
+		{"Shell", Keys.Shell},
+		{"Forward", Keys.Forward},
+		{"Registry", Keys.Registry},
+		{"Select", Keys.Select},
+		{"Manual", Keys.Manual},
+		{"Images", Keys.Images},
+		{"Archive", Keys.Archive},
+		{"Kubeconfig", Keys.Kubeconfig},
+		{"AddWorker", Keys.AddWorker},
+		{"Remove", Keys.Remove},
 	}
 
 	for _, b := range bindings {
@@ -150,8 +180,10 @@ func TestKeyConflicts(t *testing.T) {
 				// Special cases for context-specific keys:
 				// 'n' is used for both "nodes" and "no" in different contexts
 				// 'l' is used for both "right/select" and "load" in different contexts
+				// 'n' and 'N' jump between search matches in the log view only
 				if (k == "n" && ((existing == "Nodes" && b.name == "No") || (existing == "No" && b.name == "Nodes"))) ||
-				   (k == "l" && ((existing == "Right" && b.name == "Load") || (existing == "Load" && b.name == "Right"))) {
+					(k == "l" && ((existing == "Right" && b.name == "Load") || (existing == "Load" && b.name == "Right"))) ||
+					(k == "n" && b.name == "NextMatch") || (k == "N" && b.name == "PrevMatch") {
 					continue
 				}
 				t.Errorf("Key conflict: %q is used by both %s and %s", k, existing, b.name)
@@ -159,4 +191,4 @@ func TestKeyConflicts(t *testing.T) {
 			keyMap[k] = b.name
 		}
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,53 @@
+package models
+
+// Kubeconfig actions offered for a cluster
+const (
+	KubeconfigUse            = "use"
+	KubeconfigMerge          = "merge"
+	KubeconfigRemove         = "remove"
+	KubeconfigExport         = "export"
+	KubeconfigExportInternal = "export-internal"
+	KubeconfigCopy           = "copy"
+	KubeconfigCopyInternal   = "copy-internal"
+)
+
+// KubeconfigAction is an entry of the kubeconfig menu
+type KubeconfigAction struct {
+	ID    string
+	Label string
+}
+
+// KubeconfigActions lists the kubeconfig menu in display order
+var KubeconfigActions = []KubeconfigAction{
+	{KubeconfigUse, "Use as the current kubectl context"},
+	{KubeconfigMerge, "Merge into the default kubeconfig"},
+	{KubeconfigRemove, "Remove from the default kubeconfig"},
+	{KubeconfigExport, "Export to a file"},
+	{KubeconfigExportInternal, "Export the internal kubeconfig to a file"},
+	{KubeconfigCopy, "Copy to the clipboard"},
+	{KubeconfigCopyInternal, "Copy the internal kubeconfig to the clipboard"},
+}
+
+// KubeconfigMenu holds the state of the kubeconfig menu of a cluster
+type KubeconfigMenu struct {
+	Cluster string
+	Cursor  int
+
+	// ReturnTo is the view esc goes back to
+	ReturnTo ViewMode
+}
+
+// Open shows the menu for cluster
+func (k *KubeconfigMenu) Open(cluster string, returnTo ViewMode) {
+	*k = KubeconfigMenu{Cluster: cluster, ReturnTo: returnTo}
+}
+
+// MoveCursor moves the cursor by delta entries
+func (k *KubeconfigMenu) MoveCursor(delta int) {
+	k.Cursor = max(min(k.Cursor+delta, len(KubeconfigActions)-1), 0)
+}
+
+// Current returns the action under the cursor
+func (k KubeconfigMenu) Current() KubeconfigAction {
+	return KubeconfigActions[k.Cursor]
+}
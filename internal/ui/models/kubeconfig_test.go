@@ -0,0 +1,26 @@
+package models
+
+import "testing"
+
+func TestKubeconfigMenu(t *testing.T) {
+	var k KubeconfigMenu
+	k.Open("dev", ClusterDetailView)
+
+	if k.Current().ID != KubeconfigUse {
+		t.Errorf("Current() = %+v, expected the first action", k.Current())
+	}
+
+	k.MoveCursor(-1)
+	if k.Cursor != 0 {
+		t.Errorf("MoveCursor() should stop at the first action, got %d", k.Cursor)
+	}
+	k.MoveCursor(100)
+	if k.Current().ID != KubeconfigCopyInternal {
+		t.Errorf("MoveCursor() should stop at the last action, got %+v", k.Current())
+	}
+
+	k.Open("test", ClusterListView)
+	if k.Cursor != 0 || k.Cluster != "test" || k.ReturnTo != ClusterListView {
+		t.Errorf("Open() should reset the menu, got %+v", k)
+	}
+}
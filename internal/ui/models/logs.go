@@ -0,0 +1,303 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+	"ki/internal/cmd"
+)
+
+// maxLogLines caps the number of lines the log viewer keeps
+const maxLogLines = 5000
+
+// Choices cycled by the log viewer's tail and since keys
+var (
+	LogTailChoices  = []int{100, 1000, -1}
+	LogSinceChoices = []time.Duration{0, 5 * time.Minute, time.Hour, 24 * time.Hour}
+)
+
+// Log viewer prompts
+const (
+	LogPromptNone = iota
+	LogPromptSearch
+	LogPromptSave
+)
+
+var logMatchStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#000")).
+	Background(lipgloss.Color("#FFD75F"))
+
+// LogViewer holds the state of the log view of a pod container or node unit
+type LogViewer struct {
+	Source  cmd.LogSource
+	Options cmd.LogOptions
+	// Targets are the pod's containers or the node's units, cycled with tab
+	Targets []string
+
+	Lines    []string
+	Viewport viewport.Model
+	Wrap     bool
+	Done     bool // the stream ended, e.g. a log that is not followed
+
+	Input  textinput.Model
+	Prompt int
+	Query  string
+	// Matches are the indexes of the lines containing Query, Match the current one
+	Matches []int
+	Match   int
+
+	// ReturnTo is the view esc goes back to
+	ReturnTo ViewMode
+	// Seq identifies the current stream; lines of older streams are dropped
+	Seq int
+	// Cancel stops the current stream
+	Cancel context.CancelFunc
+
+	// offsets are the first viewport row of every line
+	offsets []int
+}
+
+// NewLogViewer creates an empty log viewer
+func NewLogViewer() LogViewer {
+	vp := viewport.New(0, 0)
+	// f, b, u and d are log viewer keys, so only keep the unambiguous paging keys
+	vp.KeyMap.PageDown = key.NewBinding(key.WithKeys("pgdown", " "))
+	vp.KeyMap.PageUp = key.NewBinding(key.WithKeys("pgup"))
+	vp.KeyMap.HalfPageDown = key.NewBinding(key.WithKeys("ctrl+d"))
+	vp.KeyMap.HalfPageUp = key.NewBinding(key.WithKeys("ctrl+u"))
+	vp.SetHorizontalStep(8)
+
+	input := textinput.New()
+	input.CharLimit = 200
+	input.Width = 50
+
+	return LogViewer{Viewport: vp, Input: input}
+}
+
+// Open resets the viewer for a log source
+func (l *LogViewer) Open(source cmd.LogSource, targets []string, returnTo ViewMode) {
+	l.Stop()
+	l.Source = source
+	l.Targets = targets
+	l.Options = cmd.LogOptions{Follow: true, Tail: LogTailChoices[0]}
+	l.Wrap = false
+	l.Query = ""
+	l.ClosePrompt()
+	l.ReturnTo = returnTo
+	l.Restart()
+}
+
+// Restart clears the log for a new stream with the current source and options
+func (l *LogViewer) Restart() {
+	l.Stop()
+	l.Lines = nil
+	l.Done = false
+	l.Seq++
+	l.refresh()
+}
+
+// Stop cancels the current stream
+func (l *LogViewer) Stop() {
+	if l.Cancel != nil {
+		l.Cancel()
+		l.Cancel = nil
+	}
+}
+
+// AddLines appends streamed lines, scrolling along when the end of the log is in view
+func (l *LogViewer) AddLines(lines []string) {
+	l.Lines = append(l.Lines, lines...)
+	if len(l.Lines) > maxLogLines {
+		l.Lines = l.Lines[len(l.Lines)-maxLogLines:]
+	}
+	l.refresh()
+}
+
+// SetSize resizes the viewport
+func (l *LogViewer) SetSize(width, height int) {
+	l.Viewport.Width = width
+	l.Viewport.Height = max(height, 1)
+	l.refresh()
+}
+
+// NextTarget switches to the next container or unit; it reports whether there was one
+func (l *LogViewer) NextTarget() bool {
+	if len(l.Targets) < 2 {
+		return false
+	}
+	current := l.Source.Container
+	if l.Source.IsNode() {
+		current = l.Source.Unit
+	}
+	next := l.Targets[0]
+	for i, t := range l.Targets {
+		if t == current && i+1 < len(l.Targets) {
+			next = l.Targets[i+1]
+		}
+	}
+	if l.Source.IsNode() {
+		l.Source.Unit = next
+	} else {
+		l.Source.Container = next
+	}
+	return true
+}
+
+// TogglePrevious switches between the current and previous container instance;
+// it reports whether the source has one
+func (l *LogViewer) TogglePrevious() bool {
+	if l.Source.IsNode() {
+		return false
+	}
+	l.Options.Previous = !l.Options.Previous
+	return true
+}
+
+// NextTail cycles the number of lines read from the end of the log
+func (l *LogViewer) NextTail() {
+	l.Options.Tail = LogTailChoices[(indexOf(LogTailChoices, l.Options.Tail)+1)%len(LogTailChoices)]
+}
+
+// NextSince cycles how far back the log is read
+func (l *LogViewer) NextSince() {
+	l.Options.Since = LogSinceChoices[(indexOf(LogSinceChoices, l.Options.Since)+1)%len(LogSinceChoices)]
+}
+
+// ToggleFollow switches between streaming new lines and reading the log once
+func (l *LogViewer) ToggleFollow() {
+	l.Options.Follow = !l.Options.Follow
+}
+
+// ToggleWrap switches between wrapping long lines and scrolling horizontally
+func (l *LogViewer) ToggleWrap() {
+	l.Wrap = !l.Wrap
+	l.Viewport.SetXOffset(0)
+	l.refresh()
+}
+
+// OpenPrompt focuses the input for a search or the save path
+func (l *LogViewer) OpenPrompt(prompt int) {
+	l.Prompt = prompt
+	switch prompt {
+	case LogPromptSearch:
+		l.Input.Prompt = "/"
+		l.Input.Placeholder = "search"
+		l.Input.SetValue(l.Query)
+	case LogPromptSave:
+		l.Input.Prompt = "Save to: "
+		l.Input.Placeholder = ""
+		l.Input.SetValue(l.DefaultSavePath())
+	}
+	l.Input.CursorEnd()
+	l.Input.Focus()
+}
+
+// ClosePrompt hides the input
+func (l *LogViewer) ClosePrompt() {
+	l.Prompt = LogPromptNone
+	l.Input.Blur()
+	l.Input.SetValue("")
+}
+
+// DefaultSavePath suggests a file name for the current source
+func (l LogViewer) DefaultSavePath() string {
+	if l.Source.IsNode() {
+		return fmt.Sprintf("%s-%s.log", l.Source.Node, l.Source.Unit)
+	}
+	if l.Source.Container != "" {
+		return fmt.Sprintf("%s-%s.log", l.Source.Pod, l.Source.Container)
+	}
+	return l.Source.Pod + ".log"
+}
+
+// Search highlights the lines containing query and jumps to the last match
+func (l *LogViewer) Search(query string) {
+	l.Query = query
+	l.refresh()
+	l.Match = len(l.Matches) - 1
+	l.showMatch()
+}
+
+// NextMatch moves delta matches forward, wrapping around
+func (l *LogViewer) NextMatch(delta int) {
+	if len(l.Matches) == 0 {
+		return
+	}
+	l.Match = ((l.Match+delta)%len(l.Matches) + len(l.Matches)) % len(l.Matches)
+	l.showMatch()
+}
+
+// showMatch scrolls the current match into view
+func (l *LogViewer) showMatch() {
+	if l.Match < 0 || l.Match >= len(l.Matches) {
+		return
+	}
+	l.Viewport.SetYOffset(l.offsets[l.Matches[l.Match]] - l.Viewport.Height/2)
+}
+
+// refresh renders the lines into the viewport, wrapping and highlighting them
+func (l *LogViewer) refresh() {
+	query := strings.ToLower(l.Query)
+	l.Matches = l.Matches[:0]
+	l.offsets = l.offsets[:0]
+
+	var content strings.Builder
+	row := 0
+	for i, line := range l.Lines {
+		if query != "" && strings.Contains(strings.ToLower(line), query) {
+			l.Matches = append(l.Matches, i)
+			line = highlight(line, query)
+		}
+		if l.Wrap && l.Viewport.Width > 0 {
+			line = lipgloss.NewStyle().Width(l.Viewport.Width).Render(line)
+		}
+		l.offsets = append(l.offsets, row)
+		row += strings.Count(line, "\n") + 1
+		if i > 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(line)
+	}
+	if l.Match >= len(l.Matches) {
+		l.Match = len(l.Matches) - 1
+	}
+
+	// Stay at the end unless the user scrolled up to read
+	atBottom := l.Viewport.AtBottom()
+	l.Viewport.SetContent(content.String())
+	if atBottom {
+		l.Viewport.GotoBottom()
+	}
+}
+
+// highlight marks every case-insensitive occurrence of query in line
+func highlight(line, query string) string {
+	lower := strings.ToLower(line)
+	var b strings.Builder
+	for {
+		i := strings.Index(lower, query)
+		// ToLower can change byte lengths outside ASCII, so only highlight when it did not
+		if i < 0 || len(lower) != len(line) {
+			b.WriteString(line)
+			return b.String()
+		}
+		b.WriteString(line[:i])
+		b.WriteString(logMatchStyle.Render(line[i : i+len(query)]))
+		line, lower = line[i+len(query):], lower[i+len(query):]
+	}
+}
+
+func indexOf[T comparable](values []T, v T) int {
+	for i, value := range values {
+		if value == v {
+			return i
+		}
+	}
+	return -1
+}
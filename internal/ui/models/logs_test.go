@@ -0,0 +1,145 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"ki/internal/cmd"
+)
+
+func TestLogViewerOpen(t *testing.T) {
+	l := NewLogViewer()
+	l.SetSize(80, 10)
+
+	cancelled := false
+	l.Cancel = func() { cancelled = true }
+
+	source := cmd.LogSource{Cluster: "dev", Namespace: "default", Pod: "web", Container: "web"}
+	l.Open(source, []string{"web", "sidecar"}, PodListView)
+
+	if !cancelled {
+		t.Error("Open() should stop the previous stream")
+	}
+	if l.Seq != 1 || l.Source != source || l.ReturnTo != PodListView {
+		t.Errorf("Unexpected viewer after Open(): %+v", l)
+	}
+	if !l.Options.Follow || l.Options.Tail != LogTailChoices[0] {
+		t.Errorf("Open() should follow the last lines, got %+v", l.Options)
+	}
+}
+
+func TestLogViewerAddLines(t *testing.T) {
+	l := NewLogViewer()
+	l.SetSize(80, 5)
+	l.Open(cmd.LogSource{Node: "dev-worker", Unit: "kubelet"}, cmd.NodeLogUnits, NodeListView)
+
+	for i := 0; i < 20; i++ {
+		l.AddLines([]string{fmt.Sprintf("line %d", i)})
+	}
+	if !l.Viewport.AtBottom() || !strings.Contains(l.Viewport.View(), "line 19") {
+		t.Errorf("The viewer should stay at the end of the log.\nGot:\n%s", l.Viewport.View())
+	}
+
+	// Scrolled up, new lines do not move the view
+	l.Viewport.GotoTop()
+	l.AddLines([]string{"line 20"})
+	if !l.Viewport.AtTop() {
+		t.Error("New lines should not scroll a viewer the user scrolled up")
+	}
+
+	many := make([]string, maxLogLines+10)
+	l.AddLines(many)
+	if len(l.Lines) != maxLogLines {
+		t.Errorf("Expected at most %d lines, got %d", maxLogLines, len(l.Lines))
+	}
+}
+
+func TestLogViewerSearch(t *testing.T) {
+	l := NewLogViewer()
+	l.SetSize(80, 3)
+	l.Open(cmd.LogSource{Cluster: "dev", Namespace: "default", Pod: "web"}, nil, PodListView)
+
+	lines := make([]string, 30)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("%02d ok", i)
+	}
+	lines[2] = "02 ERROR disk full"
+	lines[25] = "25 error retrying"
+	l.AddLines(lines)
+
+	l.Search("error")
+	if len(l.Matches) != 2 || l.Matches[0] != 2 || l.Matches[1] != 25 {
+		t.Fatalf("Search() matches = %v, expected [2 25]", l.Matches)
+	}
+	if l.Match != 1 || !strings.Contains(l.Viewport.View(), "retrying") {
+		t.Errorf("Search() should show the last match.\nGot:\n%s", l.Viewport.View())
+	}
+
+	l.NextMatch(1)
+	if l.Match != 0 || !strings.Contains(l.Viewport.View(), "disk full") {
+		t.Errorf("NextMatch() should wrap around to the first match.\nGot:\n%s", l.Viewport.View())
+	}
+	l.NextMatch(-1)
+	if l.Match != 1 {
+		t.Errorf("NextMatch(-1) should wrap around to the last match, got %d", l.Match)
+	}
+
+	l.Search("")
+	if len(l.Matches) != 0 {
+		t.Errorf("An empty search should clear the matches, got %v", l.Matches)
+	}
+}
+
+func TestLogViewerWrap(t *testing.T) {
+	l := NewLogViewer()
+	l.SetSize(10, 10)
+	l.Open(cmd.LogSource{Node: "dev-worker", Unit: "kubelet"}, nil, NodeListView)
+	l.AddLines([]string{strings.Repeat("x", 25), "short"})
+
+	if l.Viewport.TotalLineCount() != 2 {
+		t.Errorf("Without wrapping every line is one row, got %d", l.Viewport.TotalLineCount())
+	}
+	l.ToggleWrap()
+	if l.Viewport.TotalLineCount() != 4 {
+		t.Errorf("Wrapped at 10 columns the log should take 4 rows, got %d", l.Viewport.TotalLineCount())
+	}
+}
+
+func TestLogViewerOptions(t *testing.T) {
+	l := NewLogViewer()
+	l.Open(cmd.LogSource{Cluster: "dev", Namespace: "default", Pod: "web", Container: "web"}, []string{"web", "sidecar"}, PodListView)
+
+	if !l.NextTarget() || l.Source.Container != "sidecar" {
+		t.Errorf("NextTarget() should switch to sidecar, got %q", l.Source.Container)
+	}
+	if !l.NextTarget() || l.Source.Container != "web" {
+		t.Errorf("NextTarget() should wrap around to web, got %q", l.Source.Container)
+	}
+	if !l.TogglePrevious() || !l.Options.Previous {
+		t.Error("TogglePrevious() should read the previous container")
+	}
+
+	l.NextTail()
+	if l.Options.Tail != LogTailChoices[1] {
+		t.Errorf("NextTail() = %d, expected %d", l.Options.Tail, LogTailChoices[1])
+	}
+	l.NextSince()
+	l.NextSince()
+	if l.Options.Since != time.Hour {
+		t.Errorf("NextSince() twice = %s, expected 1h", l.Options.Since)
+	}
+
+	node := NewLogViewer()
+	node.Open(cmd.LogSource{Node: "dev-worker", Unit: "kubelet"}, cmd.NodeLogUnits, NodeListView)
+	if node.TogglePrevious() {
+		t.Error("Node logs have no previous container")
+	}
+	if !node.NextTarget() || node.Source.Unit != "containerd" {
+		t.Errorf("NextTarget() should switch the unit, got %q", node.Source.Unit)
+	}
+	if path := node.DefaultSavePath(); path != "dev-worker-containerd.log" {
+		t.Errorf("DefaultSavePath() = %q", path)
+	}
+}
@@ -1,14 +1,106 @@
 package models
 
-import "ki/internal/cmd"
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"ki/internal/cmd"
+)
 
 // Message types
 type (
-	ClustersMsg      []cmd.Cluster
 	NodesMsg         []cmd.Node
 	ClusterDetailMsg cmd.Cluster
+	TemplatesMsg     []cmd.Template
 	MessageMsg       struct {
 		Text    string
 		MsgType string
 	}
-)
\ No newline at end of file
+
+	// ClustersMsg carries the clusters of the refresh Seq in their last known
+	// state. Next waits for the first of them to be brought up to date.
+	ClustersMsg struct {
+		Seq      int
+		Clusters []cmd.Cluster
+		Next     tea.Cmd
+	}
+
+	// ClusterUpdateMsg carries one cluster brought up to date by the refresh
+	// Seq. Next waits for the one after it.
+	ClusterUpdateMsg struct {
+		Seq     int
+		Cluster cmd.Cluster
+		Next    tea.Cmd
+	}
+
+	// ProgressMsg carries one line of output from a running job.
+	// Next waits for the line after it.
+	ProgressMsg struct {
+		JobID int
+		Line  string
+		Next  tea.Cmd
+	}
+
+	// OperationDoneMsg reports that a streamed job finished with Result
+	OperationDoneMsg struct {
+		JobID  int
+		Result tea.Msg
+	}
+
+	// PodsMsg carries the pods of a cluster for the refresh loop Seq.
+	// Err is set instead of sending a MessageMsg so the loop keeps running.
+	PodsMsg struct {
+		Cluster string
+		Seq     int
+		Pods    []cmd.Pod
+		Err     error
+	}
+
+	// NodeDetailMsg carries the detail of Node in Cluster
+	NodeDetailMsg struct {
+		Cluster string
+		Node    string
+		Detail  *cmd.NodeDetail
+		Err     error
+	}
+
+	// LogLinesMsg carries lines read by the log stream Seq.
+	// Next waits for the lines after them.
+	LogLinesMsg struct {
+		Seq   int
+		Lines []string
+		Next  tea.Cmd
+	}
+
+	// LogDoneMsg reports that the log stream Seq ended with Result
+	LogDoneMsg struct {
+		Seq    int
+		Result tea.Msg
+	}
+
+	// ImagesMsg carries the images of the local container runtime
+	ImagesMsg struct {
+		Images []cmd.Image
+		Err    error
+	}
+
+	// ClusterImagesMsg carries the images on the nodes of Cluster
+	ClusterImagesMsg struct {
+		Cluster string
+		Images  []cmd.ClusterImage
+		Err     error
+	}
+
+	// ForwardsTickMsg asks the port forwards view loop Seq to redraw its counters
+	ForwardsTickMsg struct {
+		Seq int
+	}
+
+	// PodsTickMsg asks the refresh loop Seq to reload the pods
+	PodsTickMsg struct {
+		Seq int
+	}
+
+	// RefreshTickMsg asks the auto-refresh loop Seq to reload the open view
+	RefreshTickMsg struct {
+		Seq int
+	}
+)
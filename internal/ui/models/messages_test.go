@@ -32,10 +32,10 @@ func TestClustersMsg(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			msg := ClustersMsg(tt.clusters)
+			msg := ClustersMsg{Seq: 1, Clusters: tt.clusters}
 			
-			// Verify type conversion works
-			clusters := []cmd.Cluster(msg)
+			// Verify content is kept
+			clusters := msg.Clusters
 			if len(clusters) != len(tt.clusters) {
 				t.Errorf("Expected %d clusters, got %d", len(tt.clusters), len(clusters))
 			}
@@ -274,11 +274,11 @@ func TestMessageConversions(t *testing.T) {
 	
 	// Test ClustersMsg
 	clusters := []cmd.Cluster{{Name: "test", Status: "running"}}
-	msg = ClustersMsg(clusters)
+	msg = ClustersMsg{Clusters: clusters}
 	
 	if clustersMsg, ok := msg.(ClustersMsg); !ok {
 		t.Error("ClustersMsg type assertion failed")
-	} else if len(clustersMsg) != 1 {
+	} else if len(clustersMsg.Clusters) != 1 {
 		t.Error("ClustersMsg content incorrect")
 	}
 	
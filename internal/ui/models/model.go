@@ -3,6 +3,7 @@ package models
 import (
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"ki/internal/cmd"
@@ -14,17 +15,36 @@ type Model struct {
 	CurrentView ViewMode
 
 	// Components
-	MainMenu    list.Model
-	ClusterList list.Model
-	NodeList    list.Model
-	TextInput   textinput.Model
-	Help        help.Model
+	MainMenu     list.Model
+	ClusterList  list.Model
+	NodeList     list.Model
+	TemplateList list.Model
+	TextInput    textinput.Model
+	ClusterForm  ClusterForm
+	Pods         PodBrowser
+	Logs         LogViewer
+	Images       ImagePicker
+	NodeImages   ClusterImageBrowser
+	Kubeconfig   KubeconfigMenu
+	Refresh      AutoRefresh
+	Spinner      spinner.Model
+	Help         help.Model
 
 	// Data
-	Clusters       []cmd.Cluster
-	CurrentCluster *cmd.Cluster
-	Message        string
-	MessageType    string // "success", "error", "info"
+	Clusters        []cmd.Cluster
+	CurrentCluster  *cmd.Cluster
+	DetailChanges   map[string]string // earlier status of CurrentCluster's nodes that changed
+	NodesCluster    string            // cluster shown in NodeListView
+	Nodes           []cmd.Node
+	NodeChanges     map[string]string // earlier status of the Nodes that changed
+	NodeName        string            // node shown in NodeDetailView
+	NodeDetail      *cmd.NodeDetail   // last detail of NodeName
+	NodeDetailError string            // why NodeDetail could not be refreshed
+	Templates       []cmd.Template
+	CurrentTemplate *cmd.Template
+	Jobs            Jobs
+	Message         string
+	MessageType     string // "success", "error", "info"
 
 	// State
 	ShowHelp bool
@@ -39,6 +59,16 @@ type Model struct {
 	SelectedCluster     string
 	ClusterToDelete     string
 	DeleteConfirmChoice int // 0 = Yes, 1 = No
+	TemplateToDelete    string
+	WorkerToRemove      string // worker node of NodesCluster awaiting confirmation
+	EditingTemplate     string // name of the template being edited in ClusterForm
+	CurrentJob          int    // ID of the job shown in OperationView
+	JobCursor           int    // selected row in JobListView
+	ForwardCursor       int    // selected row in PortForwardListView
+	ForwardSeq          int    // refresh loop of PortForwardListView
+	ClusterSeq          int    // latest refresh of the cluster list
+	ForwardReturn       ViewMode
+	QuitReturn          ViewMode // view to go back to when quitting is called off
 }
 
 // Implement tea.Model interface
@@ -52,4 +82,4 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m Model) View() string {
 	return ""
-}
\ No newline at end of file
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Operation step states
+const (
+	StepRunning = "running"
+	StepDone    = "done"
+	StepFailed  = "failed"
+)
+
+// Job states
+const (
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+	JobCancelled = "cancelled"
+)
+
+// maxOperationOutput caps the number of output lines kept per operation
+const maxOperationOutput = 500
+
+// OperationStep is one of the steps KIND reports, e.g. "Preparing nodes"
+type OperationStep struct {
+	Name  string
+	State string
+}
+
+// Operation tracks a long-running KIND command and the output it streamed
+type Operation struct {
+	ID        int
+	Type      string // "create", "delete", "load", "build", "logs"
+	Cluster   string
+	Title     string
+	Started   time.Time
+	Finished  time.Time
+	Steps     []OperationStep
+	Output    []string
+	Done      bool
+	Failed    bool
+	Cancelled bool
+
+	// Cancel aborts the operation's context
+	Cancel context.CancelFunc
+}
+
+// NewOperation starts tracking an operation
+func NewOperation(title string) *Operation {
+	return &Operation{Title: title, Started: time.Now()}
+}
+
+// AddLine records a line of output, updating the step list when KIND reports
+// a step starting (•), succeeding (✓) or failing (✗)
+func (o *Operation) AddLine(line string) {
+	o.Output = append(o.Output, line)
+	if len(o.Output) > maxOperationOutput {
+		o.Output = o.Output[len(o.Output)-maxOperationOutput:]
+	}
+
+	trimmed := strings.TrimSpace(line)
+	for marker, state := range map[string]string{"•": StepRunning, "✓": StepDone, "✗": StepFailed} {
+		if strings.HasPrefix(trimmed, marker) {
+			o.setStep(stepName(strings.TrimPrefix(trimmed, marker)), state)
+			return
+		}
+	}
+}
+
+// Abort cancels a running operation
+func (o *Operation) Abort() {
+	if o.Done || o.Cancelled {
+		return
+	}
+	o.Cancelled = true
+	if o.Cancel != nil {
+		o.Cancel()
+	}
+}
+
+// Finish marks the operation as done
+func (o *Operation) Finish(failed bool) {
+	o.Done = true
+	o.Failed = failed
+	o.Finished = time.Now()
+
+	// Steps still running when KIND exits did not complete
+	for i := range o.Steps {
+		if o.Steps[i].State == StepRunning {
+			if failed {
+				o.Steps[i].State = StepFailed
+			} else {
+				o.Steps[i].State = StepDone
+			}
+		}
+	}
+}
+
+// Status returns the job state of the operation
+func (o *Operation) Status() string {
+	switch {
+	case !o.Done:
+		return JobRunning
+	case o.Cancelled:
+		return JobCancelled
+	case o.Failed:
+		return JobFailed
+	default:
+		return JobSucceeded
+	}
+}
+
+// Elapsed returns how long the operation has been running, or ran for
+func (o *Operation) Elapsed() time.Duration {
+	if o.Done {
+		return o.Finished.Sub(o.Started)
+	}
+	return time.Since(o.Started)
+}
+
+func (o *Operation) setStep(name, state string) {
+	for i := range o.Steps {
+		if o.Steps[i].Name == name {
+			o.Steps[i].State = state
+			return
+		}
+	}
+	o.Steps = append(o.Steps, OperationStep{Name: name, State: state})
+}
+
+// stepName strips the progress dots KIND appends to running steps
+func stepName(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "...")
+	return strings.TrimSpace(s)
+}
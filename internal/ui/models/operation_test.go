@@ -0,0 +1,109 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOperationSteps(t *testing.T) {
+	op := NewOperation("Creating cluster 'kind'")
+
+	lines := []string{
+		"Creating cluster \"kind\" ...",
+		" • Ensuring node image (kindest/node:v1.29.2) 🖼  ...",
+		" ✓ Ensuring node image (kindest/node:v1.29.2) 🖼",
+		" • Preparing nodes 📦  ...",
+		" ✓ Preparing nodes 📦",
+		" • Starting control-plane 🕹️  ...",
+	}
+	for _, line := range lines {
+		op.AddLine(line)
+	}
+
+	expected := []OperationStep{
+		{Name: "Ensuring node image (kindest/node:v1.29.2) 🖼", State: StepDone},
+		{Name: "Preparing nodes 📦", State: StepDone},
+		{Name: "Starting control-plane 🕹️", State: StepRunning},
+	}
+	if len(op.Steps) != len(expected) {
+		t.Fatalf("Expected %d steps, got %d: %+v", len(expected), len(op.Steps), op.Steps)
+	}
+	for i, step := range op.Steps {
+		if step != expected[i] {
+			t.Errorf("Step[%d] = %+v, want %+v", i, step, expected[i])
+		}
+	}
+	if len(op.Output) != len(lines) {
+		t.Errorf("Expected %d output lines, got %d", len(lines), len(op.Output))
+	}
+
+	op.AddLine(" ✗ Starting control-plane 🕹️")
+	if op.Steps[2].State != StepFailed {
+		t.Errorf("Expected the failed step to be marked, got %s", op.Steps[2].State)
+	}
+}
+
+func TestOperationFinish(t *testing.T) {
+	tests := []struct {
+		name      string
+		failed    bool
+		stepState string
+	}{
+		{name: "success", failed: false, stepState: StepDone},
+		{name: "failure", failed: true, stepState: StepFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := NewOperation("test")
+			op.AddLine(" • Writing configuration 📜 ...")
+			op.Finish(tt.failed)
+
+			if !op.Done || op.Failed != tt.failed {
+				t.Errorf("Finish(%v) left Done=%v Failed=%v", tt.failed, op.Done, op.Failed)
+			}
+			if op.Steps[0].State != tt.stepState {
+				t.Errorf("Running step should become %s, got %s", tt.stepState, op.Steps[0].State)
+			}
+
+			elapsed := op.Elapsed()
+			time.Sleep(time.Millisecond)
+			if op.Elapsed() != elapsed {
+				t.Error("Elapsed() should stop advancing once the operation is done")
+			}
+		})
+	}
+}
+
+func TestOperationOutputIsCapped(t *testing.T) {
+	op := NewOperation("test")
+	for i := 0; i < maxOperationOutput+10; i++ {
+		op.AddLine("line")
+	}
+	if len(op.Output) != maxOperationOutput {
+		t.Errorf("Expected output capped at %d lines, got %d", maxOperationOutput, len(op.Output))
+	}
+}
+
+func TestOperationAbort(t *testing.T) {
+	op := NewOperation("test")
+	calls := 0
+	op.Cancel = func() { calls++ }
+
+	op.Abort()
+	op.Abort()
+	if !op.Cancelled {
+		t.Error("Abort() should mark the operation as cancelled")
+	}
+	if calls != 1 {
+		t.Errorf("Abort() should cancel the context once, got %d calls", calls)
+	}
+
+	done := NewOperation("done")
+	done.Cancel = func() { t.Error("Abort() should not cancel a finished operation") }
+	done.Finish(false)
+	done.Abort()
+	if done.Cancelled {
+		t.Error("A finished operation cannot be cancelled")
+	}
+}
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"ki/internal/cmd"
+)
+
+// Pod list sort columns
+const (
+	SortByNamespace = iota
+	SortByName
+	SortByStatus
+	SortByRestarts
+	SortByAge
+	sortColumnCount
+)
+
+var sortColumnNames = []string{
+	SortByNamespace: "namespace",
+	SortByName:      "name",
+	SortByStatus:    "status",
+	SortByRestarts:  "restarts",
+	SortByAge:       "age",
+}
+
+// PodBrowser holds the state of the pods view of a single cluster
+type PodBrowser struct {
+	Cluster   string
+	Namespace string // empty shows every namespace
+	Pods      []cmd.Pod
+	Err       error
+	Updated   time.Time
+
+	Filter    textinput.Model
+	Filtering bool
+	SortBy    int
+	Cursor    int
+
+	// ReturnTo is the view esc goes back to
+	ReturnTo ViewMode
+	// Seq identifies the current refresh loop; ticks from older loops are dropped
+	Seq int
+}
+
+// NewPodBrowser creates an empty pod browser
+func NewPodBrowser() PodBrowser {
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "filter by name, namespace, status or node"
+	filter.CharLimit = 100
+	filter.Width = 40
+	return PodBrowser{Filter: filter}
+}
+
+// Open resets the browser for a cluster and starts a new refresh loop
+func (b *PodBrowser) Open(cluster string, returnTo ViewMode) {
+	b.Cluster = cluster
+	b.Namespace = ""
+	b.Pods = nil
+	b.Err = nil
+	b.Updated = time.Time{}
+	b.Filter.SetValue("")
+	b.Filter.Blur()
+	b.Filtering = false
+	b.Cursor = 0
+	b.ReturnTo = returnTo
+	b.Seq++
+}
+
+// SetPods replaces the pods after a refresh
+func (b *PodBrowser) SetPods(pods []cmd.Pod, err error) {
+	b.Err = err
+	if err == nil {
+		b.Pods = pods
+		b.Updated = time.Now()
+	}
+	b.clampCursor()
+}
+
+// Namespaces returns every namespace that has pods
+func (b PodBrowser) Namespaces() []string {
+	return cmd.PodNamespaces(b.Pods)
+}
+
+// NextNamespace cycles through all namespaces and then back to every namespace
+func (b *PodBrowser) NextNamespace() {
+	namespaces := b.Namespaces()
+	next := ""
+	for i, ns := range namespaces {
+		if ns == b.Namespace && i+1 < len(namespaces) {
+			next = namespaces[i+1]
+			break
+		}
+	}
+	if b.Namespace == "" && len(namespaces) > 0 {
+		next = namespaces[0]
+	}
+	b.Namespace = next
+	b.Cursor = 0
+}
+
+// NextSort switches to the next sort column
+func (b *PodBrowser) NextSort() {
+	b.SortBy = (b.SortBy + 1) % sortColumnCount
+}
+
+// SortName returns the name of the current sort column
+func (b PodBrowser) SortName() string {
+	return sortColumnNames[b.SortBy]
+}
+
+// MoveCursor moves the selection by delta rows within the visible pods
+func (b *PodBrowser) MoveCursor(delta int) {
+	b.Cursor += delta
+	b.clampCursor()
+}
+
+// Selected returns the pod under the cursor, or nil when there is none
+func (b PodBrowser) Selected() *cmd.Pod {
+	visible := b.Visible()
+	if b.Cursor < 0 || b.Cursor >= len(visible) {
+		return nil
+	}
+	return &visible[b.Cursor]
+}
+
+// Visible returns the pods in the current namespace that match the filter, sorted
+func (b PodBrowser) Visible() []cmd.Pod {
+	filter := strings.ToLower(strings.TrimSpace(b.Filter.Value()))
+
+	visible := make([]cmd.Pod, 0, len(b.Pods))
+	for _, p := range b.Pods {
+		if b.Namespace != "" && p.Namespace != b.Namespace {
+			continue
+		}
+		if filter != "" && !podMatches(p, filter) {
+			continue
+		}
+		visible = append(visible, p)
+	}
+
+	sort.SliceStable(visible, func(i, j int) bool {
+		a, c := visible[i], visible[j]
+		switch b.SortBy {
+		case SortByName:
+			return a.Name < c.Name
+		case SortByStatus:
+			if a.Status != c.Status {
+				return a.Status < c.Status
+			}
+		case SortByRestarts:
+			if a.Restarts != c.Restarts {
+				return a.Restarts > c.Restarts
+			}
+		case SortByAge:
+			if !a.Created.Equal(c.Created) {
+				return a.Created.After(c.Created)
+			}
+		}
+		if a.Namespace != c.Namespace {
+			return a.Namespace < c.Namespace
+		}
+		return a.Name < c.Name
+	})
+	return visible
+}
+
+func (b *PodBrowser) clampCursor() {
+	if n := len(b.Visible()); b.Cursor >= n {
+		b.Cursor = n - 1
+	}
+	if b.Cursor < 0 {
+		b.Cursor = 0
+	}
+}
+
+func podMatches(p cmd.Pod, filter string) bool {
+	for _, field := range []string{p.Name, p.Namespace, p.Status, p.Node} {
+		if strings.Contains(strings.ToLower(field), filter) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"ki/internal/cmd"
+)
+
+func testPods() []cmd.Pod {
+	now := time.Now()
+	return []cmd.Pod{
+		{Namespace: "kube-system", Name: "coredns", Status: "Running", Restarts: 1, Node: "dev-control-plane", Created: now.Add(-48 * time.Hour)},
+		{Namespace: "default", Name: "web", Status: "CrashLoopBackOff", Restarts: 7, Node: "dev-worker", Created: now.Add(-time.Hour)},
+		{Namespace: "default", Name: "api", Status: "Running", Restarts: 0, Node: "dev-worker", Created: now.Add(-time.Minute)},
+	}
+}
+
+func names(pods []cmd.Pod) []string {
+	result := make([]string, len(pods))
+	for i, p := range pods {
+		result[i] = p.Name
+	}
+	return result
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPodBrowserSort(t *testing.T) {
+	b := NewPodBrowser()
+	b.Open("dev", ClusterListView)
+	b.SetPods(testPods(), nil)
+
+	tests := []struct {
+		sortBy   int
+		expected []string
+	}{
+		{SortByNamespace, []string{"api", "web", "coredns"}},
+		{SortByName, []string{"api", "coredns", "web"}},
+		{SortByStatus, []string{"web", "api", "coredns"}},
+		{SortByRestarts, []string{"web", "coredns", "api"}},
+		{SortByAge, []string{"api", "web", "coredns"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(sortColumnNames[tt.sortBy], func(t *testing.T) {
+			b.SortBy = tt.sortBy
+			if result := names(b.Visible()); !equal(result, tt.expected) {
+				t.Errorf("Visible() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+
+	b.SortBy = sortColumnCount - 1
+	b.NextSort()
+	if b.SortBy != SortByNamespace {
+		t.Error("NextSort() should wrap around")
+	}
+}
+
+func TestPodBrowserFilterAndNamespace(t *testing.T) {
+	b := NewPodBrowser()
+	b.Open("dev", ClusterListView)
+	b.SetPods(testPods(), nil)
+
+	b.Filter.SetValue("worker")
+	if result := names(b.Visible()); !equal(result, []string{"api", "web"}) {
+		t.Errorf("filter by node = %v", result)
+	}
+	b.Filter.SetValue("CRASH")
+	if result := names(b.Visible()); !equal(result, []string{"web"}) {
+		t.Errorf("filter by status should ignore case, got %v", result)
+	}
+	b.Filter.SetValue("")
+
+	// "" -> default -> kube-system -> ""
+	expected := []string{"default", "kube-system", ""}
+	for _, ns := range expected {
+		b.NextNamespace()
+		if b.Namespace != ns {
+			t.Errorf("NextNamespace() = %q, expected %q", b.Namespace, ns)
+		}
+	}
+
+	b.Namespace = "kube-system"
+	if result := names(b.Visible()); !equal(result, []string{"coredns"}) {
+		t.Errorf("namespace kube-system = %v", result)
+	}
+}
+
+func TestPodBrowserCursor(t *testing.T) {
+	b := NewPodBrowser()
+	b.Open("dev", ClusterListView)
+	b.SetPods(testPods(), nil)
+
+	b.MoveCursor(10)
+	if b.Cursor != 2 {
+		t.Errorf("MoveCursor() should stop at the last pod, got %d", b.Cursor)
+	}
+	b.MoveCursor(-10)
+	if b.Cursor != 0 || b.Selected().Name != "api" {
+		t.Errorf("MoveCursor() should stop at the first pod, got %d", b.Cursor)
+	}
+
+	// A failed refresh keeps the previous pods
+	b.SetPods(nil, errors.New("connection refused"))
+	if len(b.Pods) != 3 || b.Err == nil {
+		t.Error("SetPods() with an error should keep the pods and record the error")
+	}
+
+	seq := b.Seq
+	b.Open("other", ClusterDetailView)
+	if b.Seq != seq+1 || len(b.Pods) != 0 || b.ReturnTo != ClusterDetailView {
+		t.Error("Open() should reset the browser and start a new refresh loop")
+	}
+}
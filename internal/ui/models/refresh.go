@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"ki/internal/cmd"
+)
+
+// DefaultRefreshInterval is how often the cluster and node views reload unless configured otherwise
+const DefaultRefreshInterval = 5 * time.Second
+
+// AutoRefresh reloads the cluster list, node list, cluster detail and node
+// detail views while one of them is open
+type AutoRefresh struct {
+	Interval time.Duration // 0 turns auto-refresh off
+	Seq      int           // the running tick loop
+
+	// When the data of each view last arrived
+	Clusters time.Time
+	Nodes    time.Time
+	Detail   time.Time
+	Node     time.Time
+}
+
+// NodeStatusChanges maps each node whose status differs between before and
+// after to its status before. Nodes that came or went are not changes.
+func NodeStatusChanges(before, after []cmd.Node) map[string]string {
+	previous := make(map[string]string, len(before))
+	for _, node := range before {
+		previous[node.Name] = node.Status
+	}
+	changes := make(map[string]string)
+	for _, node := range after {
+		if status, ok := previous[node.Name]; ok && status != node.Status {
+			changes[node.Name] = status
+		}
+	}
+	return changes
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"testing"
+
+	"ki/internal/cmd"
+)
+
+func TestNodeStatusChanges(t *testing.T) {
+	before := []cmd.Node{
+		{Name: "dev-control-plane", Status: "Ready"},
+		{Name: "dev-worker", Status: "Ready"},
+		{Name: "dev-worker2", Status: "NotReady"},
+	}
+	after := []cmd.Node{
+		{Name: "dev-control-plane", Status: "Ready"},
+		{Name: "dev-worker", Status: "NotReady"},
+		{Name: "dev-worker3", Status: "NotReady"},
+	}
+
+	changes := NodeStatusChanges(before, after)
+	if len(changes) != 1 || changes["dev-worker"] != "Ready" {
+		t.Errorf("NodeStatusChanges() = %v, expected only dev-worker, which was Ready", changes)
+	}
+
+	if changes := NodeStatusChanges(nil, after); len(changes) != 0 {
+		t.Errorf("NodeStatusChanges() without earlier nodes = %v, expected none", changes)
+	}
+}
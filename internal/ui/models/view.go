@@ -13,4 +13,19 @@ const (
 	BuildImageView
 	ExportLogsView
 	DeleteConfirmView
-)
\ No newline at end of file
+	TemplateListView
+	TemplatePreviewView
+	TemplateNameView
+	OperationView
+	JobListView
+	PodListView
+	LogView
+	PortForwardView
+	PortForwardListView
+	ImagePickerView
+	ClusterImagesView
+	KubeconfigView
+	KubeconfigExportView
+	NodeDetailView
+	QuitConfirmView
+)
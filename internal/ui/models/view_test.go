@@ -53,6 +53,81 @@ func TestViewMode(t *testing.T) {
 			mode:     DeleteConfirmView,
 			expected: 8,
 		},
+		{
+			name:     "template list view",
+			mode:     TemplateListView,
+			expected: 9,
+		},
+		{
+			name:     "template preview view",
+			mode:     TemplatePreviewView,
+			expected: 10,
+		},
+		{
+			name:     "template name view",
+			mode:     TemplateNameView,
+			expected: 11,
+		},
+		{
+			name:     "operation view",
+			mode:     OperationView,
+			expected: 12,
+		},
+		{
+			name:     "job list view",
+			mode:     JobListView,
+			expected: 13,
+		},
+		{
+			name:     "pod list view",
+			mode:     PodListView,
+			expected: 14,
+		},
+		{
+			name:     "log view",
+			mode:     LogView,
+			expected: 15,
+		},
+		{
+			name:     "port forward view",
+			mode:     PortForwardView,
+			expected: 16,
+		},
+		{
+			name:     "port forward list view",
+			mode:     PortForwardListView,
+			expected: 17,
+		},
+		{
+			name:     "image picker view",
+			mode:     ImagePickerView,
+			expected: 18,
+		},
+		{
+			name:     "cluster images view",
+			mode:     ClusterImagesView,
+			expected: 19,
+		},
+		{
+			name:     "kubeconfig view",
+			mode:     KubeconfigView,
+			expected: 20,
+		},
+		{
+			name:     "kubeconfig export view",
+			mode:     KubeconfigExportView,
+			expected: 21,
+		},
+		{
+			name:     "node detail view",
+			mode:     NodeDetailView,
+			expected: 22,
+		},
+		{
+			name:     "quit confirm view",
+			mode:     QuitConfirmView,
+			expected: 23,
+		},
 	}
 
 	for _, tt := range tests {
@@ -67,15 +142,30 @@ func TestViewMode(t *testing.T) {
 func TestViewModeValues(t *testing.T) {
 	// Ensure view modes have distinct values
 	modes := map[ViewMode]string{
-		MainMenuView:      "MainMenuView",
-		ClusterListView:   "ClusterListView",
-		ClusterDetailView: "ClusterDetailView",
-		NodeListView:      "NodeListView",
-		CreateClusterView: "CreateClusterView",
-		LoadImageView:     "LoadImageView",
-		BuildImageView:    "BuildImageView",
-		ExportLogsView:    "ExportLogsView",
-		DeleteConfirmView: "DeleteConfirmView",
+		MainMenuView:         "MainMenuView",
+		ClusterListView:      "ClusterListView",
+		ClusterDetailView:    "ClusterDetailView",
+		NodeListView:         "NodeListView",
+		CreateClusterView:    "CreateClusterView",
+		LoadImageView:        "LoadImageView",
+		BuildImageView:       "BuildImageView",
+		ExportLogsView:       "ExportLogsView",
+		DeleteConfirmView:    "DeleteConfirmView",
+		TemplateListView:     "TemplateListView",
+		TemplatePreviewView:  "TemplatePreviewView",
+		TemplateNameView:     "TemplateNameView",
+		OperationView:        "OperationView",
+		JobListView:          "JobListView",
+		PodListView:          "PodListView",
+		LogView:              "LogView",
+		PortForwardView:      "PortForwardView",
+		PortForwardListView:  "PortForwardListView",
+		ImagePickerView:      "ImagePickerView",
+		ClusterImagesView:    "ClusterImagesView",
+		KubeconfigView:       "KubeconfigView",
+		KubeconfigExportView: "KubeconfigExportView",
+		NodeDetailView:       "NodeDetailView",
+		QuitConfirmView:      "QuitConfirmView",
 	}
 
 	// Check for duplicate values
@@ -88,8 +178,8 @@ func TestViewModeValues(t *testing.T) {
 	}
 
 	// Verify we have the expected number of modes
-	expectedCount := 9
+	expectedCount := 24
 	if len(modes) != expectedCount {
 		t.Errorf("Expected %d view modes, got %d", expectedCount, len(modes))
 	}
-}
\ No newline at end of file
+}
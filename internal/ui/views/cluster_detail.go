@@ -8,8 +8,9 @@ import (
 	"ki/internal/ui/styles"
 )
 
-// RenderClusterDetail renders the cluster detail view
-func RenderClusterDetail(cluster *cmd.Cluster) string {
+// RenderClusterDetail renders the cluster detail view. Nodes in changes are
+// highlighted along with the status they had before.
+func RenderClusterDetail(cluster *cmd.Cluster, changes map[string]string) string {
 	if cluster == nil {
 		return "Loading cluster details..."
 	}
@@ -24,11 +25,17 @@ func RenderClusterDetail(cluster *cmd.Cluster) string {
 	details.WriteString(styles.Status.Render("Basic Information:"))
 	details.WriteString("\n")
 	details.WriteString(fmt.Sprintf("• Name: %s\n", cluster.Name))
-	details.WriteString(fmt.Sprintf("• Status: %s\n", cluster.Status))
+	details.WriteString(fmt.Sprintf("• Status: %s\n", RenderStatus(cluster.Status)))
 	if cluster.KubeVersion != "" {
 		details.WriteString(fmt.Sprintf("• Kubernetes Version: %s\n", cluster.KubeVersion))
 	}
 	details.WriteString(fmt.Sprintf("• Node Count: %d\n", len(cluster.Nodes)))
+	if cluster.Registry != "" {
+		details.WriteString(fmt.Sprintf("• Local Registry: %s (%s)\n", cluster.Registry, cmd.RegistryName))
+	}
+	if cluster.Error != "" {
+		details.WriteString(styles.Error.Render("• Error: "+cluster.Error) + "\n")
+	}
 
 	// Nodes section
 	if len(cluster.Nodes) > 0 {
@@ -44,8 +51,25 @@ func RenderClusterDetail(cluster *cmd.Cluster) string {
 
 		// Node rows
 		for _, node := range cluster.Nodes {
-			details.WriteString(fmt.Sprintf("%-25s %-15s %-10s %-10s %-15s\n",
-				node.Name, node.Role, node.Status, node.Age, node.InternalIP))
+			row := fmt.Sprintf("%-25s %-15s %-10s %-10s %-15s",
+				node.Name, node.Role, node.Status, node.Age, node.InternalIP)
+			if previous, ok := changes[node.Name]; ok {
+				row = styles.Error.Render(row + " was " + previous)
+			}
+			details.WriteString(row + "\n")
+		}
+	}
+
+	// Node containers, which explain stopped and degraded clusters
+	if len(cluster.Containers) > 0 {
+		details.WriteString("\n")
+		details.WriteString(styles.Status.Render("Node Containers:"))
+		details.WriteString("\n")
+		details.WriteString(fmt.Sprintf("%-25s %-15s %-10s\n", "NAME", "ROLE", "STATE"))
+		details.WriteString(strings.Repeat("-", 52))
+		details.WriteString("\n")
+		for _, c := range cluster.Containers {
+			details.WriteString(fmt.Sprintf("%-25s %-15s %-10s\n", c.Name, c.Role, c.State))
 		}
 	}
 
@@ -53,4 +77,16 @@ func RenderClusterDetail(cluster *cmd.Cluster) string {
 	details.WriteString(styles.Help.Render("Press 'esc' to go back to cluster list"))
 
 	return details.String()
+}
+
+// RenderStatus colours a cluster status by how healthy it is
+func RenderStatus(status string) string {
+	switch status {
+	case cmd.StatusRunning:
+		return styles.Status.Render(status)
+	case cmd.StatusStopped, cmd.StatusUnknown:
+		return styles.Help.Render(status)
+	default:
+		return styles.Error.Render(status)
+	}
 }
\ No newline at end of file
@@ -83,6 +83,17 @@ func TestRenderClusterDetail(t *testing.T) {
 				"172.18.0.3",
 			},
 		},
+		{
+			name: "cluster with a local registry",
+			cluster: &cmd.Cluster{
+				Name:     "dev",
+				Status:   "running",
+				Registry: "localhost:5001",
+			},
+			contains: []string{
+				"Local Registry: localhost:5001 (kind-registry)",
+			},
+		},
 		{
 			name: "cluster without kube version",
 			cluster: &cmd.Cluster{
@@ -98,11 +109,22 @@ func TestRenderClusterDetail(t *testing.T) {
 				"Node Count: 0",
 			},
 		},
+		{
+			name: "cluster whose nodes could not be read",
+			cluster: &cmd.Cluster{
+				Name:   "test",
+				Status: "running",
+				Error:  "failed to get nodes: context deadline exceeded",
+			},
+			contains: []string{
+				"Error: failed to get nodes: context deadline exceeded",
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := RenderClusterDetail(tt.cluster)
+			result := RenderClusterDetail(tt.cluster, nil)
 
 			for _, expected := range tt.contains {
 				if !strings.Contains(result, expected) {
@@ -141,7 +163,7 @@ func TestRenderClusterDetailFormatting(t *testing.T) {
 		},
 	}
 
-	result := RenderClusterDetail(cluster)
+	result := RenderClusterDetail(cluster, nil)
 
 	// Check for proper table alignment
 	lines := strings.Split(result, "\n")
@@ -168,4 +190,47 @@ func TestRenderClusterDetailFormatting(t *testing.T) {
 	if !strings.Contains(dataLine, "very-long-node-name-that-should-fit") {
 		t.Error("Data line should contain the node name")
 	}
-}
\ No newline at end of file
+}
+
+func TestRenderClusterDetailContainers(t *testing.T) {
+	cluster := &cmd.Cluster{
+		Name:   "dev",
+		Status: cmd.StatusDegraded,
+		Containers: []cmd.Container{
+			{Name: "dev-control-plane", Role: "control-plane", State: "running"},
+			{Name: "dev-worker", Role: "worker", State: "exited"},
+		},
+	}
+
+	result := RenderClusterDetail(cluster, nil)
+	contains := []string{
+		"Status: degraded",
+		"Node Containers:",
+		"dev-worker",
+		"exited",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderClusterDetail() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+}
+
+func TestRenderClusterDetailChanges(t *testing.T) {
+	cluster := &cmd.Cluster{
+		Name:   "dev",
+		Status: cmd.StatusRunning,
+		Nodes: []cmd.Node{
+			{Name: "dev-control-plane", Role: "control-plane", Status: "Ready"},
+			{Name: "dev-worker", Role: "worker", Status: "NotReady"},
+		},
+	}
+
+	result := RenderClusterDetail(cluster, map[string]string{"dev-worker": "Ready"})
+	if !strings.Contains(result, "was Ready") {
+		t.Errorf("RenderClusterDetail() should show the earlier status of a changed node.\nGot:\n%s", result)
+	}
+	if strings.Count(result, "was ") != 1 {
+		t.Errorf("RenderClusterDetail() should only mark the changed node.\nGot:\n%s", result)
+	}
+}
@@ -0,0 +1,43 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"ki/internal/ui/models"
+	"ki/internal/ui/styles"
+)
+
+// RenderClusterForm renders the create cluster form, or the template editor when
+// editingTemplate is set
+func RenderClusterForm(form models.ClusterForm, editingTemplate string) string {
+	var content strings.Builder
+
+	if editingTemplate != "" {
+		content.WriteString(styles.Status.Render("Edit Template: " + editingTemplate))
+	} else {
+		content.WriteString(styles.Status.Render("Create Cluster"))
+	}
+	content.WriteString("\n\n")
+
+	for i, input := range form.Inputs {
+		label := fmt.Sprintf("%-20s", form.Label(i))
+		if i == form.Focus {
+			label = styles.Focused.Render(label)
+		} else {
+			label = styles.Blurred.Render(label)
+		}
+		content.WriteString(fmt.Sprintf("%s %s\n", label, input.View()))
+	}
+
+	content.WriteString("\n")
+	content.WriteString(styles.Help.Render("Port mappings apply to the first control-plane node, mounts to every node"))
+	content.WriteString("\n")
+	if editingTemplate != "" {
+		content.WriteString(styles.Help.Render("Press Tab/↓ for next field, Shift+Tab/↑ for previous, Enter to save, Esc to cancel"))
+	} else {
+		content.WriteString(styles.Help.Render("Press Tab/↓ for next field, Shift+Tab/↑ for previous, Enter to create, Ctrl+S to save as template, Esc to cancel"))
+	}
+
+	return content.String()
+}
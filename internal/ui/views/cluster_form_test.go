@@ -0,0 +1,44 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"ki/internal/ui/models"
+)
+
+func TestRenderClusterForm(t *testing.T) {
+	form := models.NewClusterForm()
+	form.Inputs[models.FieldName].SetValue("dev")
+
+	result := RenderClusterForm(form, "")
+
+	contains := []string{
+		"Create Cluster",
+		"Cluster name",
+		"Node image",
+		"Control-plane nodes",
+		"Worker nodes",
+		"Port mappings",
+		"Extra mounts",
+		"Feature gates",
+		"dev",
+		"Enter to create",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderClusterForm() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+}
+
+func TestRenderClusterFormEditingTemplate(t *testing.T) {
+	result := RenderClusterForm(models.NewClusterForm(), "ha-ingress")
+
+	if !strings.Contains(result, "Edit Template: ha-ingress") {
+		t.Errorf("RenderClusterForm() should show the template being edited.\nGot:\n%s", result)
+	}
+	if !strings.Contains(result, "Enter to save") {
+		t.Error("RenderClusterForm() should offer to save the template")
+	}
+}
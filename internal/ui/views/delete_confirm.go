@@ -45,4 +45,85 @@ func RenderDeleteConfirmation(clusterToDelete string, deleteConfirmChoice int) s
 	content.WriteString(styles.Help.Render("Use ←/→/Tab to select, Enter to confirm, or press Y/N directly"))
 
 	return content.String()
-}
\ No newline at end of file
+}
+
+// RenderTemplateDeleteConfirmation renders the delete confirmation dialog for a template
+func RenderTemplateDeleteConfirmation(templateToDelete string, deleteConfirmChoice int) string {
+	var content strings.Builder
+
+	content.WriteString(styles.Error.Render("⚠️  DELETE TEMPLATE CONFIRMATION"))
+	content.WriteString("\n\n")
+
+	content.WriteString(styles.Status.Render(fmt.Sprintf("Template Name: %s", templateToDelete)))
+	content.WriteString("\n\n")
+	content.WriteString("The template file will be removed. Existing clusters are not affected.\n\n")
+
+	if deleteConfirmChoice == 0 {
+		content.WriteString(styles.Focused.Render("  [Y] Yes, delete the template  "))
+		content.WriteString("  ")
+		content.WriteString(styles.Blurred.Render("  [N] No, cancel  "))
+	} else {
+		content.WriteString(styles.Blurred.Render("  [Y] Yes, delete the template  "))
+		content.WriteString("  ")
+		content.WriteString(styles.Focused.Render("  [N] No, cancel  "))
+	}
+	content.WriteString("\n\n")
+
+	content.WriteString(styles.Help.Render("Use ←/→/Tab to select, Enter to confirm, or press Y/N directly"))
+
+	return content.String()
+}
+
+// RenderWorkerRemoveConfirmation renders the confirmation dialog for removing a worker node
+func RenderWorkerRemoveConfirmation(cluster, node string, deleteConfirmChoice int) string {
+	var content strings.Builder
+
+	content.WriteString(styles.Error.Render("⚠️  REMOVE WORKER CONFIRMATION"))
+	content.WriteString("\n\n")
+
+	content.WriteString(styles.Status.Render(fmt.Sprintf("Node: %s (cluster %s)", node, cluster)))
+	content.WriteString("\n\n")
+	content.WriteString("The node is drained, its pods are evicted and its container is removed.\n\n")
+
+	if deleteConfirmChoice == 0 {
+		content.WriteString(styles.Focused.Render("  [Y] Yes, remove the worker  "))
+		content.WriteString("  ")
+		content.WriteString(styles.Blurred.Render("  [N] No, cancel  "))
+	} else {
+		content.WriteString(styles.Blurred.Render("  [Y] Yes, remove the worker  "))
+		content.WriteString("  ")
+		content.WriteString(styles.Focused.Render("  [N] No, cancel  "))
+	}
+	content.WriteString("\n\n")
+
+	content.WriteString(styles.Help.Render("Use ←/→/Tab to select, Enter to confirm, or press Y/N directly"))
+
+	return content.String()
+}
+
+// RenderQuitConfirmation renders the dialog asking whether to quit while jobs are running
+func RenderQuitConfirmation(running int, choice int) string {
+	var content strings.Builder
+
+	content.WriteString(styles.Error.Render("⚠️  QUIT WITH RUNNING JOBS"))
+	content.WriteString("\n\n")
+
+	content.WriteString(styles.Status.Render(fmt.Sprintf("Running jobs: %d", running)))
+	content.WriteString("\n\n")
+	content.WriteString("Quitting cancels them and waits while they clean up, e.g. delete a half-created cluster.\n\n")
+
+	if choice == 0 {
+		content.WriteString(styles.Focused.Render("  [Y] Yes, cancel and quit  "))
+		content.WriteString("  ")
+		content.WriteString(styles.Blurred.Render("  [N] No, stay  "))
+	} else {
+		content.WriteString(styles.Blurred.Render("  [Y] Yes, cancel and quit  "))
+		content.WriteString("  ")
+		content.WriteString(styles.Focused.Render("  [N] No, stay  "))
+	}
+	content.WriteString("\n\n")
+
+	content.WriteString(styles.Help.Render("Use ←/→/Tab to select, Enter to confirm, or press Y/N directly"))
+
+	return content.String()
+}
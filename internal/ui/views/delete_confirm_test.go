@@ -127,4 +127,53 @@ func TestRenderDeleteConfirmationLayout(t *testing.T) {
 	if emptyLineCount < 2 {
 		t.Error("Delete confirmation should have empty lines for better readability")
 	}
-}
\ No newline at end of file
+}
+
+func TestRenderTemplateDeleteConfirmation(t *testing.T) {
+	result := RenderTemplateDeleteConfirmation("ha-ingress", 1)
+
+	contains := []string{
+		"DELETE TEMPLATE CONFIRMATION",
+		"Template Name: ha-ingress",
+		"[Y] Yes, delete the template",
+		"[N] No, cancel",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderTemplateDeleteConfirmation() should contain %q", expected)
+		}
+	}
+}
+
+func TestRenderWorkerRemoveConfirmation(t *testing.T) {
+	result := RenderWorkerRemoveConfirmation("dev", "dev-worker2", 0)
+
+	contains := []string{
+		"REMOVE WORKER CONFIRMATION",
+		"Node: dev-worker2 (cluster dev)",
+		"drained",
+		"[Y] Yes, remove the worker",
+		"[N] No, cancel",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderWorkerRemoveConfirmation() should contain %q", expected)
+		}
+	}
+}
+
+func TestRenderQuitConfirmation(t *testing.T) {
+	result := RenderQuitConfirmation(2, 1)
+
+	contains := []string{
+		"QUIT WITH RUNNING JOBS",
+		"Running jobs: 2",
+		"[Y] Yes, cancel and quit",
+		"[N] No, stay",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderQuitConfirmation() should contain %q", expected)
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"ki/internal/cmd"
+	"ki/internal/ui/models"
+	"ki/internal/ui/styles"
+)
+
+// imageRowFormat lays out REPOSITORY TAG IMAGE-ID SIZE CREATED
+const imageRowFormat = "%-40s %-20s %-14s %-10s %s"
+
+// RenderImages renders the image picker as a table with at most maxRows rows
+func RenderImages(p models.ImagePicker, maxRows int) string {
+	var content strings.Builder
+
+	cluster := p.Cluster
+	if cluster == "" {
+		cluster = "kind"
+	}
+	content.WriteString(styles.Title.Render(fmt.Sprintf("Load Images into %s", cluster)))
+	if len(p.Selected) > 0 {
+		content.WriteString(" ")
+		content.WriteString(styles.Help.Render(fmt.Sprintf("%d selected", len(p.Selected))))
+	}
+	content.WriteString("\n")
+
+	if len(p.Nodes) > 1 {
+		target := "all nodes"
+		if nodes := p.TargetNodes(); len(nodes) > 0 {
+			target = strings.Join(nodes, ", ")
+		}
+		content.WriteString(styles.Help.Render("Nodes: " + target))
+		content.WriteString("\n")
+	}
+	if p.Filtering || p.Filter.Value() != "" {
+		content.WriteString(p.Filter.View())
+		content.WriteString("\n")
+	}
+	if p.Err != nil {
+		content.WriteString(styles.Error.Render("✗ " + p.Err.Error()))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	if p.PickingNodes {
+		content.WriteString(renderTargetNodes(p))
+		return content.String()
+	}
+
+	if !p.Loaded {
+		content.WriteString("Loading images...")
+		return content.String()
+	}
+
+	images := p.Visible()
+	if len(images) == 0 {
+		content.WriteString(styles.Help.Render("No images found, press m to enter an image name"))
+		return content.String()
+	}
+
+	content.WriteString(styles.Help.Render(fmt.Sprintf("      "+imageRowFormat, "REPOSITORY", "TAG", "IMAGE ID", "SIZE", "CREATED")))
+	content.WriteString("\n")
+
+	// Scroll so the cursor stays visible
+	if maxRows < 1 {
+		maxRows = len(images)
+	}
+	start := 0
+	if p.Cursor >= maxRows {
+		start = p.Cursor - maxRows + 1
+	}
+	end := min(start+maxRows, len(images))
+
+	for i := start; i < end; i++ {
+		image := images[i]
+		mark := "[ ] "
+		if p.Selected[image.Ref()] {
+			mark = "[x] "
+		}
+		row := mark + fmt.Sprintf(imageRowFormat, image.Repository, image.Tag, shortID(image.ID), image.Size, image.Created)
+		if i == p.Cursor {
+			content.WriteString(styles.Status.Render("> " + row))
+		} else {
+			content.WriteString("  " + row)
+		}
+		content.WriteString("\n")
+	}
+
+	if len(images) > maxRows {
+		content.WriteString(styles.Help.Render(fmt.Sprintf("  %d-%d of %d images", start+1, end, len(images))))
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
+
+// renderTargetNodes lists the cluster's nodes with the ones to load into marked
+func renderTargetNodes(p models.ImagePicker) string {
+	var content strings.Builder
+	content.WriteString(styles.Status.Render("Load into nodes:"))
+	content.WriteString("\n")
+	for i, node := range p.Nodes {
+		mark := "[ ] "
+		if p.Targets[node] {
+			mark = "[x] "
+		}
+		if i == p.NodeCursor {
+			content.WriteString(styles.Status.Render("> " + mark + node))
+		} else {
+			content.WriteString("  " + mark + node)
+		}
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	content.WriteString(styles.Help.Render("Images are loaded into every node when none is marked"))
+	return content.String()
+}
+
+// shortID trims an image ID to the 12 characters runtimes usually show
+func shortID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// clusterImageRowFormat lays out IMAGE STATUS NODES NODE-ID LOCAL-ID
+const clusterImageRowFormat = "%-50s %-10s %-6s %-14s %s"
+
+// RenderClusterImages renders the images on a cluster's nodes with at most maxRows rows
+func RenderClusterImages(b models.ClusterImageBrowser, maxRows int) string {
+	var content strings.Builder
+
+	content.WriteString(styles.Title.Render(fmt.Sprintf("Images on %s", b.Cluster)))
+	if outdated := len(b.Outdated()); outdated > 0 {
+		content.WriteString(" ")
+		content.WriteString(styles.Error.Render(fmt.Sprintf("%d outdated", outdated)))
+	}
+	content.WriteString("\n")
+	if b.Err != nil {
+		content.WriteString(styles.Error.Render("✗ " + b.Err.Error()))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	if !b.Loaded {
+		content.WriteString("Listing images on the nodes...")
+		return content.String()
+	}
+	if len(b.Images) == 0 {
+		content.WriteString(styles.Help.Render("No images found on the nodes"))
+		return content.String()
+	}
+
+	content.WriteString(styles.Help.Render(fmt.Sprintf("  "+clusterImageRowFormat, "IMAGE", "STATUS", "NODES", "NODE ID", "LOCAL ID")))
+	content.WriteString("\n")
+
+	if maxRows < 1 {
+		maxRows = len(b.Images)
+	}
+	start := 0
+	if b.Cursor >= maxRows {
+		start = b.Cursor - maxRows + 1
+	}
+	end := min(start+maxRows, len(b.Images))
+
+	for i := start; i < end; i++ {
+		image := b.Images[i]
+		localID := "-"
+		if image.LocalID != "" {
+			localID = shortID(image.LocalID)
+		}
+		nodes := fmt.Sprintf("%d/%d", len(image.NodeIDs), len(image.NodeIDs)+len(image.Missing))
+		row := fmt.Sprintf(clusterImageRowFormat, image.Ref, image.Status, nodes, nodeImageID(image), localID)
+
+		switch {
+		case i == b.Cursor:
+			content.WriteString(styles.Status.Render("> " + row))
+		case image.Outdated():
+			content.WriteString(styles.Error.Render("  " + row))
+		default:
+			content.WriteString("  " + row)
+		}
+		content.WriteString("\n")
+	}
+
+	if len(b.Images) > maxRows {
+		content.WriteString(styles.Help.Render(fmt.Sprintf("  %d-%d of %d images", start+1, end, len(b.Images))))
+		content.WriteString("\n")
+	}
+
+	if image := b.Current(); image != nil && len(image.Missing) > 0 {
+		content.WriteString("\n")
+		content.WriteString(styles.Help.Render("Missing on: " + strings.Join(image.Missing, ", ")))
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
+
+// nodeImageID returns the short ID the nodes have, or "mixed" when they differ
+func nodeImageID(image cmd.ClusterImage) string {
+	ids := make([]string, 0, len(image.NodeIDs))
+	for _, id := range image.NodeIDs {
+		ids = append(ids, shortID(id))
+	}
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	switch len(ids) {
+	case 0:
+		return "-"
+	case 1:
+		return ids[0]
+	default:
+		return "mixed"
+	}
+}
@@ -0,0 +1,137 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"ki/internal/cmd"
+	"ki/internal/ui/models"
+)
+
+func TestRenderImages(t *testing.T) {
+	p := models.NewImagePicker()
+	p.Open("dev", models.ClusterListView)
+
+	if result := RenderImages(p, 10); !strings.Contains(result, "Loading images...") {
+		t.Errorf("RenderImages() should show a loading state.\nGot:\n%s", result)
+	}
+
+	p.SetImages([]cmd.Image{
+		{Repository: "nginx", Tag: "1.27", ID: "sha256:a1b2c3d4e5f6a7b8c9d0", Size: "192MB", Created: "2 weeks ago"},
+		{Repository: "myapp", Tag: "dev", ID: "d4e5f6", Size: "25MB", Created: "5 minutes ago"},
+	}, nil)
+	p.MoveCursor(1)
+	p.Toggle()
+
+	result := RenderImages(p, 10)
+	contains := []string{
+		"Load Images into dev",
+		"1 selected",
+		"REPOSITORY",
+		"a1b2c3d4e5f6 ",
+		"192MB",
+		"> [x] myapp",
+		"[ ] nginx",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderImages() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+
+	if strings.Contains(result, "Nodes:") {
+		t.Errorf("RenderImages() should only offer nodes of multi-node clusters.\nGot:\n%s", result)
+	}
+
+	p.Filter.SetValue("nothing-matches")
+	if result := RenderImages(p, 10); !strings.Contains(result, "No images found") {
+		t.Errorf("RenderImages() should say when the filter matches nothing.\nGot:\n%s", result)
+	}
+
+	p.SetImages(nil, errors.New("cannot connect to the docker daemon"))
+	if result := RenderImages(p, 10); !strings.Contains(result, "cannot connect to the docker daemon") {
+		t.Errorf("RenderImages() should show listing errors.\nGot:\n%s", result)
+	}
+}
+
+func TestRenderClusterImages(t *testing.T) {
+	b := models.ClusterImageBrowser{}
+	b.Open("dev", models.ClusterDetailView)
+
+	if result := RenderClusterImages(b, 10); !strings.Contains(result, "Listing images on the nodes...") {
+		t.Errorf("RenderClusterImages() should show a loading state.\nGot:\n%s", result)
+	}
+
+	b.SetImages("dev", []cmd.ClusterImage{
+		{
+			Ref: "docker.io/library/myapp:dev", LocalRef: "myapp:dev", LocalID: "sha256:0123456789abcdef",
+			NodeIDs: map[string]string{"dev-control-plane": "sha256:aaaaaaaaaaaaaaaa", "dev-worker": "sha256:0123456789abcdef"},
+			Status:  cmd.ImageStale,
+		},
+		{
+			Ref: "docker.io/library/redis:7", LocalRef: "redis:7", LocalID: "sha256:fedcba987654",
+			NodeIDs: map[string]string{"dev-control-plane": "sha256:fedcba987654"},
+			Missing: []string{"dev-worker"}, Status: cmd.ImageMissing,
+		},
+		{
+			Ref:     "registry.k8s.io/pause:3.9",
+			NodeIDs: map[string]string{"dev-control-plane": "sha256:e6f181688397", "dev-worker": "sha256:e6f181688397"},
+			Status:  cmd.ImageNotLocal,
+		},
+	}, nil)
+	b.MoveCursor(1)
+
+	result := RenderClusterImages(b, 10)
+	contains := []string{
+		"Images on dev",
+		"2 outdated",
+		"NODE ID",
+		"docker.io/library/myapp:dev",
+		"mixed",
+		"> docker.io/library/redis:7",
+		"1/2",
+		"e6f181688397   -",
+		"Missing on: dev-worker",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderClusterImages() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+
+	b.SetImages("dev", nil, errors.New("cluster not running"))
+	if result := RenderClusterImages(b, 10); !strings.Contains(result, "cluster not running") {
+		t.Errorf("RenderClusterImages() should show listing errors.\nGot:\n%s", result)
+	}
+}
+
+func TestRenderImagesTargetNodes(t *testing.T) {
+	p := models.NewImagePicker()
+	p.Open("dev", models.ClusterListView)
+	p.SetNodes([]cmd.Node{{Name: "dev-control-plane"}, {Name: "dev-worker"}})
+	p.SetImages([]cmd.Image{{Repository: "nginx", Tag: "1.27", ID: "a1b2c3"}}, nil)
+
+	if result := RenderImages(p, 10); !strings.Contains(result, "Nodes: all nodes") {
+		t.Errorf("RenderImages() should load into all nodes by default.\nGot:\n%s", result)
+	}
+
+	p.PickingNodes = true
+	p.MoveNodeCursor(1)
+	p.ToggleTarget()
+	result := RenderImages(p, 10)
+	contains := []string{
+		"Nodes: dev-worker",
+		"Load into nodes:",
+		"  [ ] dev-control-plane",
+		"> [x] dev-worker",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderImages() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+	if strings.Contains(result, "REPOSITORY") {
+		t.Errorf("RenderImages() should hide the images while picking nodes.\nGot:\n%s", result)
+	}
+}
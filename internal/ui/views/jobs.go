@@ -0,0 +1,70 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ki/internal/ui/models"
+	"ki/internal/ui/styles"
+)
+
+// jobStatusWidth is the width of the status column, including its icon
+const jobStatusWidth = 12
+
+// RenderJobs renders every tracked job as a table, highlighting the row at cursor
+func RenderJobs(jobs []*models.Operation, cursor int, spinner string) string {
+	var content strings.Builder
+
+	content.WriteString(styles.Title.Render("Jobs"))
+	content.WriteString("\n\n")
+
+	if len(jobs) == 0 {
+		content.WriteString(styles.Help.Render("No jobs yet. Creates, deletes, loads, builds and log exports show up here."))
+		return content.String()
+	}
+
+	content.WriteString(styles.Help.Render(fmt.Sprintf("  %-4s %-8s %-16s %-*s %-9s %-9s %s",
+		"ID", "TYPE", "CLUSTER", jobStatusWidth, "STATUS", "STARTED", "DURATION", "TITLE")))
+	content.WriteString("\n")
+
+	for i, job := range jobs {
+		cluster := job.Cluster
+		if cluster == "" {
+			cluster = "-"
+		}
+
+		// Pad before styling so escape codes do not break the alignment
+		status := job.Status()
+		padding := strings.Repeat(" ", max(0, jobStatusWidth-len(status)-2))
+		switch status {
+		case models.JobRunning:
+			status = spinner + " " + status
+		case models.JobSucceeded:
+			status = styles.Status.Render("✓ " + status)
+		case models.JobCancelled:
+			status = styles.Error.Render("⊘ " + status)
+		default:
+			status = styles.Error.Render("✗ " + status)
+		}
+
+		row := fmt.Sprintf("%-4d %-8s %-16s %s%s %-9s %-9s %s",
+			job.ID,
+			job.Type,
+			cluster,
+			status, padding,
+			job.Started.Format("15:04:05"),
+			job.Elapsed().Round(time.Second).String(),
+			job.Title,
+		)
+
+		if i == cursor {
+			content.WriteString(styles.Status.Render("> ") + row)
+		} else {
+			content.WriteString("  " + row)
+		}
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
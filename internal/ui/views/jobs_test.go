@@ -0,0 +1,32 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"ki/internal/ui/models"
+)
+
+func TestRenderJobs(t *testing.T) {
+	if result := RenderJobs(nil, 0, "⣾"); !strings.Contains(result, "No jobs yet") {
+		t.Errorf("RenderJobs(nil) should explain the empty list.\nGot:\n%s", result)
+	}
+
+	var jobs models.Jobs
+	jobs.Start("create", "dev", "Creating cluster 'dev'")
+	jobs.Start("load", "", "Loading image 'nginx'").Finish(true)
+
+	result := RenderJobs(jobs.All(), 0, "⣾")
+	contains := []string{
+		"Creating cluster 'dev'",
+		"Loading image 'nginx'",
+		"⣾ running",
+		"✗ failed",
+		"> 2",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderJobs() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"ki/internal/cmd"
+	"ki/internal/ui/models"
+	"ki/internal/ui/styles"
+)
+
+// RenderKubeconfigMenu renders the kubeconfig actions of a cluster
+func RenderKubeconfigMenu(k models.KubeconfigMenu) string {
+	var content strings.Builder
+
+	content.WriteString(styles.Title.Render(fmt.Sprintf("Kubeconfig: %s", k.Cluster)))
+	content.WriteString("\n")
+	content.WriteString(styles.Help.Render("Default kubeconfig: " + cmd.KubeconfigPath()))
+	content.WriteString("\n\n")
+
+	for i, action := range models.KubeconfigActions {
+		if i == k.Cursor {
+			content.WriteString(styles.Status.Render("> " + action.Label))
+		} else {
+			content.WriteString("  " + action.Label)
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(styles.Help.Render("The internal kubeconfig reaches the API server from containers on the kind network"))
+	return content.String()
+}
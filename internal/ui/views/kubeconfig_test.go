@@ -0,0 +1,32 @@
+package views
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ki/internal/ui/models"
+)
+
+func TestRenderKubeconfigMenu(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+	t.Setenv("KUBECONFIG", path)
+
+	var k models.KubeconfigMenu
+	k.Open("dev", models.ClusterDetailView)
+	k.MoveCursor(1)
+
+	result := RenderKubeconfigMenu(k)
+	contains := []string{
+		"Kubeconfig: dev",
+		"Default kubeconfig: " + path,
+		"  Use as the current kubectl context",
+		"> Merge into the default kubeconfig",
+		"Copy the internal kubeconfig to the clipboard",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderKubeconfigMenu() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+}
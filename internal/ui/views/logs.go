@@ -0,0 +1,65 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"ki/internal/ui/models"
+	"ki/internal/ui/styles"
+)
+
+// RenderLogs renders the log viewer with a status line describing the stream
+func RenderLogs(l models.LogViewer) string {
+	var content strings.Builder
+
+	content.WriteString(styles.Title.Render("Logs - " + l.Source.String()))
+	content.WriteString(" ")
+	content.WriteString(styles.Help.Render(strings.Join(logStatus(l), " • ")))
+	content.WriteString("\n\n")
+
+	if len(l.Lines) == 0 {
+		if l.Done {
+			content.WriteString(styles.Help.Render("The log is empty"))
+		} else {
+			content.WriteString("Waiting for log lines...")
+		}
+	} else {
+		content.WriteString(l.Viewport.View())
+	}
+
+	if l.Prompt != models.LogPromptNone {
+		content.WriteString("\n")
+		content.WriteString(l.Input.View())
+	}
+
+	return content.String()
+}
+
+// logStatus lists the stream options and search state shown next to the title
+func logStatus(l models.LogViewer) []string {
+	var status []string
+	switch {
+	case l.Done:
+		status = append(status, "ended")
+	case l.Options.Follow:
+		status = append(status, "following")
+	}
+	if l.Options.Previous {
+		status = append(status, "previous container")
+	}
+	if l.Options.Tail >= 0 {
+		status = append(status, fmt.Sprintf("last %d lines", l.Options.Tail))
+	} else {
+		status = append(status, "all lines")
+	}
+	if l.Options.Since > 0 {
+		status = append(status, "since "+l.Options.Since.String())
+	}
+	if l.Wrap {
+		status = append(status, "wrapped")
+	}
+	if l.Query != "" {
+		status = append(status, fmt.Sprintf("%q %d/%d", l.Query, l.Match+1, len(l.Matches)))
+	}
+	return status
+}
@@ -0,0 +1,41 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ki/internal/cmd"
+	"ki/internal/ui/models"
+)
+
+func TestRenderLogs(t *testing.T) {
+	l := models.NewLogViewer()
+	l.SetSize(80, 10)
+	l.Open(cmd.LogSource{Cluster: "dev", Namespace: "default", Pod: "web", Container: "app"}, nil, models.PodListView)
+
+	result := RenderLogs(l)
+	for _, expected := range []string{"Logs - default/web [app]", "following", "last 100 lines", "Waiting for log lines..."} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderLogs() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+
+	l.AddLines([]string{"starting server", "listening on :8080"})
+	l.Options.Since = 5 * time.Minute
+	l.Search("listening")
+	l.OpenPrompt(models.LogPromptSave)
+
+	result = RenderLogs(l)
+	for _, expected := range []string{"starting server", "since 5m0s", `"listening" 1/1`, "Save to: web-app.log"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderLogs() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+
+	l.Restart()
+	l.Done = true
+	if result := RenderLogs(l); !strings.Contains(result, "ended") || !strings.Contains(result, "The log is empty") {
+		t.Errorf("RenderLogs() should show an ended, empty log.\nGot:\n%s", result)
+	}
+}
@@ -0,0 +1,99 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"ki/internal/cmd"
+	"ki/internal/ui/styles"
+)
+
+// nodePodRowFormat lays out NAMESPACE NAME STATUS CPU MEMORY
+const nodePodRowFormat = "%-16s %-40s %-18s %-8s %s"
+
+// RenderNodeDetail renders the node detail view. err is shown above the last
+// detail that could be read.
+func RenderNodeDetail(detail *cmd.NodeDetail, err string) string {
+	if detail == nil {
+		if err != "" {
+			return styles.Error.Render("✗ " + err)
+		}
+		return "Loading node details..."
+	}
+
+	var content strings.Builder
+	if err != "" {
+		content.WriteString(styles.Error.Render("✗ " + err))
+		content.WriteString("\n")
+	}
+	content.WriteString(RenderNodeSummary(&detail.Node))
+
+	// Requests are what the scheduler counts against the allocatable resources
+	content.WriteString("\n")
+	content.WriteString(styles.Status.Render("Requested/Allocatable:"))
+	content.WriteString("\n")
+	content.WriteString(fmt.Sprintf("• cpu: %s\n",
+		renderRequested(detail.RequestedCPU, detail.Node.Allocatable["cpu"], cmd.FormatCPU)))
+	content.WriteString(fmt.Sprintf("• memory: %s\n",
+		renderRequested(detail.RequestedMemory, detail.Node.Allocatable["memory"], cmd.FormatBytes)))
+
+	content.WriteString("\n")
+	content.WriteString(styles.Status.Render("Node Container:"))
+	content.WriteString("\n")
+	if detail.Stats != nil {
+		content.WriteString(fmt.Sprintf("• CPU: %s | Memory: %s (%s)\n",
+			detail.Stats.CPU, detail.Stats.Memory, detail.Stats.MemoryPercent))
+	}
+	if len(detail.Services) > 0 {
+		services := make([]string, len(detail.Services))
+		for i, s := range detail.Services {
+			text := s.Name + " " + s.State
+			if !s.Active() {
+				text = styles.Error.Render(text)
+			}
+			services[i] = text
+		}
+		content.WriteString("• Services: " + strings.Join(services, ", ") + "\n")
+	}
+	for _, e := range detail.Errors {
+		content.WriteString(styles.Error.Render("• "+e) + "\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(styles.Status.Render(fmt.Sprintf("Pods (%d):", len(detail.Pods))))
+	content.WriteString("\n")
+	if len(detail.Pods) == 0 {
+		content.WriteString(styles.Help.Render("No pods scheduled"))
+		content.WriteString("\n")
+		return content.String()
+	}
+	content.WriteString(styles.Help.Render(fmt.Sprintf(nodePodRowFormat, "NAMESPACE", "NAME", "STATUS", "CPU", "MEMORY")))
+	content.WriteString("\n")
+	for _, pod := range detail.Pods {
+		cpu, memory := "-", "-"
+		if pod.CPURequest > 0 {
+			cpu = cmd.FormatCPU(pod.CPURequest)
+		}
+		if pod.MemoryRequest > 0 {
+			memory = cmd.FormatBytes(pod.MemoryRequest)
+		}
+		content.WriteString(fmt.Sprintf(nodePodRowFormat, pod.Namespace, pod.Name, pod.Status, cpu, memory))
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
+
+// renderRequested formats requested against an allocatable quantity, with the
+// share of it that is taken when allocatable is known
+func renderRequested(requested float64, allocatable string, format func(float64) string) string {
+	total, err := cmd.ParseQuantity(allocatable)
+	if err != nil || total <= 0 {
+		return format(requested) + " / unknown"
+	}
+	text := fmt.Sprintf("%s / %s (%d%%)", format(requested), format(total), int(requested/total*100+0.5))
+	if requested > total {
+		return styles.Error.Render(text)
+	}
+	return text
+}
@@ -0,0 +1,60 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"ki/internal/cmd"
+)
+
+func TestRenderNodeDetail(t *testing.T) {
+	if result := RenderNodeDetail(nil, ""); !strings.Contains(result, "Loading") {
+		t.Errorf("RenderNodeDetail(nil) = %q, expected a loading message", result)
+	}
+	if result := RenderNodeDetail(nil, "node gone not found"); !strings.Contains(result, "node gone not found") {
+		t.Errorf("RenderNodeDetail(nil, err) = %q, expected the error", result)
+	}
+
+	detail := &cmd.NodeDetail{
+		Node: cmd.Node{
+			Name:        "dev-worker",
+			Allocatable: map[string]string{"cpu": "4", "memory": "8Gi"},
+		},
+		Pods: []cmd.Pod{
+			{Namespace: "default", Name: "web-1", Status: "Running", CPURequest: 0.25, MemoryRequest: 128 << 20},
+			{Namespace: "kube-system", Name: "kube-proxy-x", Status: "Running"},
+		},
+		RequestedCPU:    1,
+		RequestedMemory: 2 << 30,
+		Stats:           &cmd.ContainerStats{CPU: "3.50%", Memory: "512MiB / 15.5GiB", MemoryPercent: "3.2%"},
+		Services:        []cmd.ServiceState{{Name: "kubelet", State: "active"}, {Name: "containerd", State: "failed"}},
+		Errors:          []string{"failed to get pods: timeout"},
+	}
+
+	result := RenderNodeDetail(detail, "")
+	contains := []string{
+		"Node: dev-worker",
+		"cpu: 1 / 4 (25%)",
+		"memory: 2Gi / 8Gi (25%)",
+		"CPU: 3.50% | Memory: 512MiB / 15.5GiB (3.2%)",
+		"kubelet active",
+		"containerd failed",
+		"failed to get pods: timeout",
+		"Pods (2):",
+		"web-1",
+		"250m",
+		"128Mi",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderNodeDetail() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+
+	result = RenderNodeDetail(&cmd.NodeDetail{Node: cmd.Node{Name: "dev-worker"}}, "connection refused")
+	for _, expected := range []string{"connection refused", "cpu: 0m / unknown", "No pods scheduled"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderNodeDetail() of a bare node should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"ki/internal/cmd"
+	"ki/internal/ui/styles"
+)
+
+// nodeResources are the capacity entries shown for a node, in order
+var nodeResources = []string{"cpu", "memory", "ephemeral-storage", "pods"}
+
+// RenderNodeSummary renders what the node list does not show of a node:
+// system info, conditions, capacity, taints and labels
+func RenderNodeSummary(node *cmd.Node) string {
+	if node == nil {
+		return ""
+	}
+
+	var content strings.Builder
+	content.WriteString(styles.Status.Render(fmt.Sprintf("Node: %s", node.Name)))
+	content.WriteString("\n")
+	content.WriteString(fmt.Sprintf("• OS: %s | Kernel: %s | Runtime: %s\n",
+		orUnknown(node.OSImage), orUnknown(node.KernelVersion), orUnknown(node.ContainerRuntime)))
+
+	if len(node.Conditions) > 0 {
+		conditions := make([]string, len(node.Conditions))
+		for i, c := range node.Conditions {
+			text := c.Type + "=" + c.Status
+			if !c.Healthy() {
+				if c.Reason != "" {
+					text += " (" + c.Reason + ")"
+				}
+				text = styles.Error.Render(text)
+			}
+			conditions[i] = text
+		}
+		content.WriteString("• Conditions: " + strings.Join(conditions, ", ") + "\n")
+	}
+
+	if len(node.Capacity) > 0 {
+		resources := make([]string, 0, len(nodeResources))
+		for _, name := range nodeResources {
+			capacity, ok := node.Capacity[name]
+			if !ok {
+				continue
+			}
+			// Allocatable is what is left for pods once system reservations are taken
+			if allocatable := node.Allocatable[name]; allocatable != "" && allocatable != capacity {
+				resources = append(resources, fmt.Sprintf("%s %s/%s", name, allocatable, capacity))
+			} else {
+				resources = append(resources, fmt.Sprintf("%s %s", name, capacity))
+			}
+		}
+		content.WriteString("• Allocatable/Capacity: " + strings.Join(resources, ", ") + "\n")
+	}
+
+	taints := make([]string, len(node.Taints))
+	for i, t := range node.Taints {
+		taints[i] = t.String()
+	}
+	content.WriteString("• Taints: " + orNone(strings.Join(taints, ", ")) + "\n")
+
+	labels := make([]string, 0, len(node.Labels))
+	for k, v := range node.Labels {
+		labels = append(labels, k+"="+v)
+	}
+	sort.Strings(labels)
+	content.WriteString("• Labels: " + orNone(strings.Join(labels, ", ")) + "\n")
+
+	return content.String()
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return styles.Help.Render("none")
+	}
+	return s
+}
@@ -0,0 +1,52 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"ki/internal/cmd"
+)
+
+func TestRenderNodeSummary(t *testing.T) {
+	if result := RenderNodeSummary(nil); result != "" {
+		t.Errorf("RenderNodeSummary(nil) = %q, expected nothing", result)
+	}
+
+	node := &cmd.Node{
+		Name:             "dev-control-plane",
+		OSImage:          "Debian GNU/Linux 12 (bookworm)",
+		KernelVersion:    "6.6.16-linuxkit",
+		ContainerRuntime: "containerd://1.7.15",
+		Conditions: []cmd.NodeCondition{
+			{Type: "Ready", Status: "True"},
+			{Type: "DiskPressure", Status: "True", Reason: "KubeletHasDiskPressure"},
+		},
+		Capacity:    map[string]string{"cpu": "8", "memory": "16Gi", "pods": "110"},
+		Allocatable: map[string]string{"cpu": "8", "memory": "15Gi", "pods": "110"},
+		Taints:      []cmd.Taint{{Key: "node-role.kubernetes.io/control-plane", Effect: "NoSchedule"}},
+		Labels:      map[string]string{"kubernetes.io/os": "linux", "kubernetes.io/arch": "amd64"},
+	}
+
+	result := RenderNodeSummary(node)
+	contains := []string{
+		"Node: dev-control-plane",
+		"OS: Debian GNU/Linux 12 (bookworm) | Kernel: 6.6.16-linuxkit | Runtime: containerd://1.7.15",
+		"Ready=True",
+		"DiskPressure=True (KubeletHasDiskPressure)",
+		"cpu 8, memory 15Gi/16Gi, pods 110",
+		"Taints: node-role.kubernetes.io/control-plane:NoSchedule",
+		"Labels: kubernetes.io/arch=amd64, kubernetes.io/os=linux",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderNodeSummary() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+
+	result = RenderNodeSummary(&cmd.Node{Name: "dev-worker"})
+	for _, expected := range []string{"Runtime: unknown", "Taints: none", "Labels: none"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderNodeSummary() of a bare node should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ki/internal/ui/models"
+	"ki/internal/ui/styles"
+)
+
+// operationOutputLines is the number of trailing output lines shown under the steps
+const operationOutputLines = 8
+
+// RenderOperation renders the progress of a long-running operation
+func RenderOperation(op *models.Operation, spinner string) string {
+	if op == nil {
+		return "No operation running"
+	}
+
+	var content strings.Builder
+
+	// Header with spinner and elapsed time
+	status := spinner
+	switch {
+	case op.Done && op.Cancelled:
+		status = styles.Error.Render("⊘")
+	case op.Done && op.Failed:
+		status = styles.Error.Render("✗")
+	case op.Done:
+		status = styles.Status.Render("✓")
+	}
+	elapsed := op.Elapsed().Round(time.Second)
+	content.WriteString(fmt.Sprintf("%s %s %s", status, styles.Status.Render(op.Title), styles.Help.Render(elapsed.String())))
+	content.WriteString("\n\n")
+
+	// Steps reported by KIND
+	if len(op.Steps) > 0 {
+		for _, step := range op.Steps {
+			switch step.State {
+			case models.StepDone:
+				content.WriteString(styles.Status.Render(" ✓ ") + step.Name)
+			case models.StepFailed:
+				content.WriteString(styles.Error.Render(" ✗ ") + step.Name)
+			default:
+				content.WriteString(" " + spinner + " " + step.Name)
+			}
+			content.WriteString("\n")
+		}
+		content.WriteString("\n")
+	}
+
+	// Tail of the raw output
+	output := op.Output
+	if len(output) > operationOutputLines {
+		output = output[len(output)-operationOutputLines:]
+	}
+	for _, line := range output {
+		content.WriteString(styles.Help.Render(line))
+		content.WriteString("\n")
+	}
+
+	switch {
+	case op.Done && op.Cancelled:
+		content.WriteString("\n")
+		content.WriteString(styles.Error.Render("Operation cancelled"))
+	case op.Cancelled:
+		content.WriteString("\n")
+		content.WriteString(styles.Help.Render("Cancelling..."))
+	case !op.Done:
+		content.WriteString("\n")
+		content.WriteString(styles.Help.Render("Press 'x' to cancel, 'esc' to go back, the operation keeps running"))
+	}
+
+	return content.String()
+}
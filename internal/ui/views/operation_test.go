@@ -0,0 +1,71 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"ki/internal/ui/models"
+)
+
+func TestRenderOperation(t *testing.T) {
+	if result := RenderOperation(nil, "⣾"); result != "No operation running" {
+		t.Errorf("RenderOperation(nil) = %q", result)
+	}
+
+	op := models.NewOperation("Creating cluster 'dev'")
+	op.AddLine(" • Ensuring node image 🖼 ...")
+	op.AddLine(" ✓ Ensuring node image 🖼")
+	op.AddLine(" • Preparing nodes 📦 ...")
+
+	result := RenderOperation(op, "⣾")
+	contains := []string{
+		"Creating cluster 'dev'",
+		"✓ Ensuring node image",
+		"⣾ Preparing nodes",
+		"the operation keeps running",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderOperation() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+
+	op.Finish(true)
+	result = RenderOperation(op, "⣾")
+	if !strings.Contains(result, "✗ Preparing nodes") {
+		t.Errorf("RenderOperation() should show the failed step.\nGot:\n%s", result)
+	}
+	if strings.Contains(result, "keeps running") {
+		t.Error("RenderOperation() should not show the background hint once done")
+	}
+}
+
+func TestRenderOperationOutputTail(t *testing.T) {
+	op := models.NewOperation("Building node image")
+	for i := 0; i < operationOutputLines+5; i++ {
+		op.AddLine("old line")
+	}
+	op.AddLine("latest line")
+
+	result := RenderOperation(op, "")
+	if !strings.Contains(result, "latest line") {
+		t.Error("RenderOperation() should show the latest output")
+	}
+	if strings.Count(result, "old line") != operationOutputLines-1 {
+		t.Errorf("RenderOperation() should only show the last %d lines", operationOutputLines)
+	}
+}
+
+func TestRenderOperationCancelled(t *testing.T) {
+	op := models.NewOperation("Creating cluster 'dev'")
+	op.Abort()
+
+	if result := RenderOperation(op, ""); !strings.Contains(result, "Cancelling...") {
+		t.Errorf("RenderOperation() should show that the operation is being cancelled.\nGot:\n%s", result)
+	}
+
+	op.Finish(true)
+	if result := RenderOperation(op, ""); !strings.Contains(result, "Operation cancelled") {
+		t.Errorf("RenderOperation() should show that the operation was cancelled.\nGot:\n%s", result)
+	}
+}
@@ -0,0 +1,91 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ki/internal/ui/models"
+	"ki/internal/ui/styles"
+)
+
+// podRowFormat lays out NAMESPACE NAME READY STATUS RESTARTS AGE NODE
+const podRowFormat = "%-16s %-40s %-6s %-18s %-8s %-6s %s"
+
+// RenderPods renders the pods of a cluster as a table with at most maxRows rows
+func RenderPods(b models.PodBrowser, maxRows int) string {
+	var content strings.Builder
+
+	// Header with the current namespace, sort column and refresh time
+	namespace := b.Namespace
+	if namespace == "" {
+		namespace = "all namespaces"
+	}
+	content.WriteString(styles.Title.Render(fmt.Sprintf("Pods - %s", b.Cluster)))
+	content.WriteString(" ")
+	content.WriteString(styles.Help.Render(fmt.Sprintf("namespace: %s • sort: %s", namespace, b.SortName())))
+	if !b.Updated.IsZero() {
+		content.WriteString(styles.Help.Render(fmt.Sprintf(" • updated %s", b.Updated.Format(time.TimeOnly))))
+	}
+	content.WriteString("\n")
+
+	if b.Filtering || b.Filter.Value() != "" {
+		content.WriteString(b.Filter.View())
+		content.WriteString("\n")
+	}
+	if b.Err != nil {
+		content.WriteString(styles.Error.Render("✗ " + b.Err.Error()))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	if b.Updated.IsZero() && b.Err == nil {
+		content.WriteString("Loading pods...")
+		return content.String()
+	}
+
+	pods := b.Visible()
+	if len(pods) == 0 {
+		content.WriteString(styles.Help.Render("No pods found"))
+		return content.String()
+	}
+
+	content.WriteString(styles.Help.Render(fmt.Sprintf("  "+podRowFormat, "NAMESPACE", "NAME", "READY", "STATUS", "RESTARTS", "AGE", "NODE")))
+	content.WriteString("\n")
+
+	// Scroll so the cursor stays visible
+	if maxRows < 1 {
+		maxRows = len(pods)
+	}
+	start := 0
+	if b.Cursor >= maxRows {
+		start = b.Cursor - maxRows + 1
+	}
+	end := min(start+maxRows, len(pods))
+
+	for i := start; i < end; i++ {
+		p := pods[i]
+		row := fmt.Sprintf(podRowFormat, p.Namespace, p.Name, p.Ready, p.Status, fmt.Sprint(p.Restarts), p.Age, p.Node)
+		switch {
+		case i == b.Cursor:
+			content.WriteString(styles.Status.Render("> " + row))
+		case !podHealthy(p.Status):
+			content.WriteString("  " + styles.Error.Render(row))
+		default:
+			content.WriteString("  " + row)
+		}
+		content.WriteString("\n")
+	}
+
+	if len(pods) > maxRows {
+		content.WriteString(styles.Help.Render(fmt.Sprintf("  %d-%d of %d pods", start+1, end, len(pods))))
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
+
+// podHealthy reports whether a pod status needs no attention
+func podHealthy(status string) bool {
+	return status == "Running" || status == "Succeeded" || status == "Completed"
+}
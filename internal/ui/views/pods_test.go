@@ -0,0 +1,71 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"ki/internal/cmd"
+	"ki/internal/ui/models"
+)
+
+func TestRenderPods(t *testing.T) {
+	b := models.NewPodBrowser()
+	b.Open("dev", models.ClusterListView)
+
+	if result := RenderPods(b, 10); !strings.Contains(result, "Loading pods...") {
+		t.Errorf("RenderPods() should show a loading state.\nGot:\n%s", result)
+	}
+
+	b.SetPods([]cmd.Pod{
+		{Namespace: "kube-system", Name: "coredns", Ready: "1/1", Status: "Running", Age: "2d", Node: "dev-control-plane"},
+		{Namespace: "default", Name: "web", Ready: "0/1", Status: "CrashLoopBackOff", Restarts: 7, Age: "1h", Node: "dev-worker"},
+	}, nil)
+
+	result := RenderPods(b, 10)
+	contains := []string{
+		"Pods - dev",
+		"namespace: all namespaces",
+		"sort: namespace",
+		"NAMESPACE",
+		"coredns",
+		"CrashLoopBackOff",
+		"> default",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderPods() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+
+	b.Filter.SetValue("nothing-matches")
+	if result := RenderPods(b, 10); !strings.Contains(result, "No pods found") {
+		t.Errorf("RenderPods() should say when the filter matches nothing.\nGot:\n%s", result)
+	}
+
+	b.SetPods(nil, errors.New("connection refused"))
+	if result := RenderPods(b, 10); !strings.Contains(result, "connection refused") {
+		t.Errorf("RenderPods() should show refresh errors.\nGot:\n%s", result)
+	}
+}
+
+func TestRenderPodsScrolling(t *testing.T) {
+	b := models.NewPodBrowser()
+	b.Open("dev", models.ClusterListView)
+
+	pods := make([]cmd.Pod, 20)
+	for i := range pods {
+		pods[i] = cmd.Pod{Namespace: "default", Name: fmt.Sprintf("pod-%02d", i), Status: "Running"}
+	}
+	b.SetPods(pods, nil)
+	b.MoveCursor(15)
+
+	result := RenderPods(b, 5)
+	if !strings.Contains(result, "pod-15") || strings.Contains(result, "pod-10") {
+		t.Errorf("RenderPods() should scroll to keep the cursor visible.\nGot:\n%s", result)
+	}
+	if !strings.Contains(result, "12-16 of 20 pods") {
+		t.Errorf("RenderPods() should show the scroll position.\nGot:\n%s", result)
+	}
+}
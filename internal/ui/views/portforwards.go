@@ -0,0 +1,82 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"ki/internal/cmd"
+	"ki/internal/ui/styles"
+)
+
+// forwardRowFormat lays out ID CLUSTER LOCAL TARGET STATUS CONNS IN OUT AGE
+const forwardRowFormat = "%-4s %-14s %-7s %-36s %-13s %-6s %-10s %-10s %s"
+
+// RenderPortForwards renders the running port forwards as a table, highlighting the row at cursor
+func RenderPortForwards(forwards []cmd.PortForwardInfo, cursor int) string {
+	var content strings.Builder
+
+	content.WriteString(styles.Title.Render("Port Forwards"))
+	content.WriteString("\n\n")
+
+	if len(forwards) == 0 {
+		content.WriteString(styles.Help.Render("No port forwards. Press F on a cluster or pod to forward a local port to it."))
+		return content.String()
+	}
+
+	content.WriteString(styles.Help.Render(fmt.Sprintf("  "+forwardRowFormat,
+		"ID", "CLUSTER", "LOCAL", "TARGET", "STATUS", "CONNS", "IN", "OUT", "AGE")))
+	content.WriteString("\n")
+
+	for i, f := range forwards {
+		target := fmt.Sprintf("%s/%s:%d", f.Spec.Namespace, f.Spec.Target(), f.Spec.RemotePort)
+		row := fmt.Sprintf(forwardRowFormat,
+			fmt.Sprint(f.ID),
+			f.Spec.Cluster,
+			fmt.Sprint(f.Spec.LocalPort),
+			target,
+			f.Status,
+			fmt.Sprint(f.Connections),
+			FormatBytes(f.BytesIn),
+			FormatBytes(f.BytesOut),
+			cmd.FormatAge(time.Since(f.Started)),
+		)
+
+		switch {
+		case i == cursor:
+			content.WriteString(styles.Status.Render("> " + row))
+		case f.Status == cmd.ForwardReconnecting:
+			content.WriteString("  " + styles.Error.Render(row))
+		default:
+			content.WriteString("  " + row)
+		}
+		content.WriteString("\n")
+	}
+
+	// Explain why the selected forward is not connected
+	if cursor >= 0 && cursor < len(forwards) && forwards[cursor].Error != "" {
+		content.WriteString("\n")
+		content.WriteString(styles.Error.Render("✗ " + forwards[cursor].Error))
+		content.WriteString("\n")
+	}
+	if cursor >= 0 && cursor < len(forwards) && forwards[cursor].Dropped > 0 {
+		content.WriteString(styles.Help.Render(fmt.Sprintf("%d connection(s) dropped without reaching kubectl", forwards[cursor].Dropped)))
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
+
+// FormatBytes formats a byte count with a binary unit, e.g. 512 B or 1.5 KiB
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
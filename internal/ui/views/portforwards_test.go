@@ -0,0 +1,59 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"ki/internal/cmd"
+)
+
+func TestRenderPortForwards(t *testing.T) {
+	if result := RenderPortForwards(nil, 0); !strings.Contains(result, "No port forwards") {
+		t.Errorf("RenderPortForwards() should explain an empty list.\nGot:\n%s", result)
+	}
+
+	forwards := []cmd.PortForwardInfo{
+		{
+			ID:      1,
+			Spec:    cmd.PortForwardSpec{Cluster: "dev", Namespace: "default", Kind: "svc", Name: "web", LocalPort: 8080, RemotePort: 80},
+			Status:  cmd.ForwardActive,
+			BytesIn: 2048, BytesOut: 300, Connections: 2,
+			Started: time.Now().Add(-3 * time.Minute),
+		},
+		{
+			ID:      2,
+			Spec:    cmd.PortForwardSpec{Cluster: "dev", Namespace: "apps", Kind: "pod", Name: "api-0", LocalPort: 9090, RemotePort: 9090},
+			Status:  cmd.ForwardReconnecting,
+			Error:   "pod api-0 not found",
+			Dropped: 3,
+			Started: time.Now(),
+		},
+	}
+
+	result := RenderPortForwards(forwards, 1)
+	for _, expected := range []string{"Port Forwards", "default/svc/web:80", "8080", "2.0 KiB", "300 B", "3m", "apps/pod/api-0:9090", "reconnecting", "pod api-0 not found", "3 connection(s) dropped"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderPortForwards() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n        int64
+		expected string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1536, "1.5 KiB"},
+		{5 * 1024 * 1024, "5.0 MiB"},
+		{3 << 30, "3.0 GiB"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatBytes(tt.n); got != tt.expected {
+			t.Errorf("FormatBytes(%d) = %q, expected %q", tt.n, got, tt.expected)
+		}
+	}
+}
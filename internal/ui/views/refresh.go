@@ -0,0 +1,20 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	"ki/internal/ui/styles"
+)
+
+// RenderUpdated tells when a view's data last arrived and how often it reloads
+func RenderUpdated(updated time.Time, interval time.Duration) string {
+	if updated.IsZero() {
+		return ""
+	}
+	refresh := "auto-refresh off"
+	if interval > 0 {
+		refresh = "refreshing every " + interval.String()
+	}
+	return styles.Help.Render(fmt.Sprintf("Updated %s • %s", updated.Format(time.TimeOnly), refresh))
+}
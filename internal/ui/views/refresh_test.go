@@ -0,0 +1,21 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderUpdated(t *testing.T) {
+	if result := RenderUpdated(time.Time{}, time.Second); result != "" {
+		t.Errorf("RenderUpdated() before any data = %q, expected nothing", result)
+	}
+
+	updated := time.Date(2024, 5, 3, 10, 4, 5, 0, time.Local)
+	if result := RenderUpdated(updated, 5*time.Second); !strings.Contains(result, "Updated 10:04:05 • refreshing every 5s") {
+		t.Errorf("RenderUpdated() = %q", result)
+	}
+	if result := RenderUpdated(updated, 0); !strings.Contains(result, "auto-refresh off") {
+		t.Errorf("RenderUpdated() without auto-refresh = %q", result)
+	}
+}
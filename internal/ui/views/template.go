@@ -0,0 +1,49 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"ki/internal/cmd"
+	"ki/internal/ui/styles"
+)
+
+// RenderTemplatePreview renders a template and the KIND config it produces
+func RenderTemplatePreview(template *cmd.Template) string {
+	if template == nil {
+		return "No template selected"
+	}
+
+	var preview strings.Builder
+
+	preview.WriteString(styles.Title.Render(fmt.Sprintf("Template: %s", template.Name)))
+	preview.WriteString("\n\n")
+
+	if template.Description != "" {
+		preview.WriteString(template.Description)
+		preview.WriteString("\n")
+	}
+	preview.WriteString(fmt.Sprintf("• Nodes: %s\n", template.Summary()))
+	if template.Cluster.Name != "" {
+		preview.WriteString(fmt.Sprintf("• Default cluster name: %s\n", template.Cluster.Name))
+	}
+	if template.Cluster.Registry {
+		preview.WriteString(fmt.Sprintf("• Local registry: %s\n", cmd.RegistryHost))
+	}
+
+	preview.WriteString("\n")
+	preview.WriteString(styles.Status.Render("KIND config:"))
+	preview.WriteString("\n")
+
+	config, err := cmd.RenderClusterConfig(template.Cluster)
+	if err != nil {
+		preview.WriteString(styles.Error.Render(err.Error()))
+	} else {
+		preview.WriteString(string(config))
+	}
+
+	preview.WriteString("\n")
+	preview.WriteString(styles.Help.Render("Press 'enter' to use, 'e' to edit, 'esc' to go back"))
+
+	return preview.String()
+}
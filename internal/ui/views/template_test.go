@@ -0,0 +1,38 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"ki/internal/cmd"
+)
+
+func TestRenderTemplatePreview(t *testing.T) {
+	if result := RenderTemplatePreview(nil); result != "No template selected" {
+		t.Errorf("RenderTemplatePreview(nil) = %q", result)
+	}
+
+	template := &cmd.Template{
+		Name:        "workers",
+		Description: "One control-plane node and three workers",
+		Cluster:     cmd.NewClusterConfig("", 1, 3, ""),
+	}
+	result := RenderTemplatePreview(template)
+
+	contains := []string{
+		"Template: workers",
+		"One control-plane node and three workers",
+		"Nodes: 1 control-plane, 3 workers",
+		"KIND config:",
+		"apiVersion: kind.x-k8s.io/v1alpha4",
+		"role: worker",
+	}
+	for _, expected := range contains {
+		if !strings.Contains(result, expected) {
+			t.Errorf("RenderTemplatePreview() should contain %q.\nGot:\n%s", expected, result)
+		}
+	}
+	if strings.Contains(result, "Default cluster name") {
+		t.Error("RenderTemplatePreview() should not show an empty default name")
+	}
+}
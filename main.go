@@ -1,16 +1,46 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"ki/internal/cli"
+	"ki/internal/cmd"
 	"ki/internal/ui/app"
+	"ki/internal/ui/models"
 )
 
 
 func main() {
+	provider := flag.String("provider", "", "container runtime for KIND: docker, podman or nerdctl (default: detected)")
+	refresh := flag.Duration("refresh", models.DefaultRefreshInterval, "how often the cluster and node views reload, 0 turns it off")
+	flag.Parse()
+	if *provider != "" {
+		if err := cmd.SetProvider(*provider); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(cli.ExitUsage)
+		}
+	}
+	if *refresh < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --refresh must not be negative\n")
+		os.Exit(cli.ExitUsage)
+	}
+
+	// Subcommands run without the UI so ki can be scripted; they report a
+	// missing kind binary through their exit code
+	if args := flag.Args(); len(args) > 0 {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		code := cli.Run(ctx, args, os.Stdout, os.Stderr)
+		stop()
+		cmd.RemoveKubeconfigs()
+		os.Exit(code)
+	}
+
 	// Check if kind is installed
 	if _, err := exec.LookPath("kind"); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: 'kind' command not found. Please install KIND first.\n")
@@ -18,8 +48,15 @@ func main() {
 		os.Exit(1)
 	}
 
-	p := tea.NewProgram(app.NewApp(), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	a := app.NewApp()
+	a.SetRefreshInterval(*refresh)
+	p := tea.NewProgram(a, tea.WithAltScreen())
+	_, err := p.Run()
+	a.Shutdown(os.Stderr)
+	// Port forwards only live as long as the UI
+	cmd.Forwards.StopAll()
+	cmd.RemoveKubeconfigs()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
 		os.Exit(1)
 	}